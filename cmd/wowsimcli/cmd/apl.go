@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/wowsims/mop/sim/core/proto"
+	"github.com/wowsims/mop/tools/apltext"
+)
+
+const (
+	aplFormatText = "text"
+	aplFormatJSON = "json"
+)
+
+var (
+	aplInfile  string
+	aplOutfile string
+	aplTo      string
+)
+
+var aplCmd = &cobra.Command{
+	Use:   "apl",
+	Short: "convert an APL rotation between protojson and the text format",
+	Long: `Converts an APL rotation, such as a spec preset .apl.json file, to the text format or back.
+
+In the text format every line adds an action or variable, e.g.
+  actions+=/cast_spell,spell_id=12345,if=aura_remaining_time(67890)<2s`,
+	RunE:         aplMain,
+	SilenceUsage: true,
+}
+
+func init() {
+	aplCmd.Flags().StringVar(&aplInfile, "infile", "", "location of the rotation, in protojson or text format")
+	aplCmd.Flags().StringVar(&aplOutfile, "outfile", "", "location of output file, defaults to stdout")
+	aplCmd.Flags().StringVar(&aplTo, "to", "", "output format: text or json, defaults to the format the input is not in")
+	aplCmd.MarkFlagRequired("infile")
+}
+
+func aplMain(cmd *cobra.Command, args []string) error {
+	data, err := os.ReadFile(aplInfile)
+	if err != nil {
+		return fmt.Errorf("failed to load input file %q: %w", aplInfile, err)
+	}
+
+	// The text format never starts with a brace, so this tells the two apart.
+	var rotation *proto.APLRotation
+	inFormat := aplFormatText
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("{")) {
+		inFormat = aplFormatJSON
+		rotation, err = apltext.ParseJSON(data)
+	} else {
+		rotation, err = apltext.Parse(string(data))
+	}
+	if err != nil {
+		return fmt.Errorf("failed to parse input file %q: %w", aplInfile, err)
+	}
+
+	to := aplTo
+	if to == "" {
+		to = aplFormatJSON
+		if inFormat == aplFormatJSON {
+			to = aplFormatText
+		}
+	}
+
+	var output []byte
+	switch to {
+	case aplFormatText:
+		output = []byte(apltext.Format(rotation))
+	case aplFormatJSON:
+		if output, err = apltext.FormatJSON(rotation); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unknown output format %q, expected text or json", to)
+	}
+
+	out, err := openOutput(aplOutfile)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+	_, err = out.Write(output)
+	return err
+}
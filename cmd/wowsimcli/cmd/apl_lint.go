@@ -0,0 +1,198 @@
+package cmd
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/spf13/cobra"
+	"github.com/wowsims/mop/sim/core"
+	"github.com/wowsims/mop/sim/core/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+var (
+	aplLintInfile  string
+	aplLintLink    string
+	aplLintOutfile string
+	aplLintFormat  string
+)
+
+var aplLintCmd = &cobra.Command{
+	Use:   "apllint",
+	Short: "report problems in each player's APL rotation without running a sim",
+	Long: `Builds every player and reports the validation warnings for their APL rotation, such as unknown spells,
+unreachable actions, conditions that never change, duration/number comparisons, variable cycles and unused groups.`,
+	RunE:         aplLintMain,
+	SilenceUsage: true,
+}
+
+func init() {
+	aplLintCmd.Flags().StringVar(&aplLintInfile, "infile", "", "location of input file (ComputeStatsRequest in protojson format)")
+	aplLintCmd.Flags().StringVar(&aplLintLink, "link", "", "exported individual sim link to use instead of an input file")
+	aplLintCmd.Flags().StringVar(&aplLintOutfile, "outfile", "", "location of output file, defaults to stdout")
+	aplLintCmd.Flags().StringVar(&aplLintFormat, "format", formatTable, "output format: json, csv or table")
+	aplLintCmd.MarkFlagsMutuallyExclusive("infile", "link")
+}
+
+type aplFinding struct {
+	Player   string `json:"player"`
+	Location string `json:"location"`
+	Level    string `json:"level"`
+	Message  string `json:"message"`
+}
+
+func aplLintMain(cmd *cobra.Command, args []string) error {
+	if err := validateFormat(aplLintFormat); err != nil {
+		return err
+	}
+
+	request := &proto.ComputeStatsRequest{}
+	if aplLintInfile == "" && aplLintLink == "" {
+		return errors.New("one of --infile or --link is required")
+	} else if aplLintLink != "" {
+		settings, err := individualSettingsFromLink(aplLintLink)
+		if err != nil {
+			return err
+		}
+		request.Raid = individualSettingsToRaid(settings)
+		request.Encounter = settings.Encounter
+	} else if err := readProtoJSON(aplLintInfile, request); err != nil {
+		return err
+	}
+	if request.Raid == nil {
+		return errors.New("apl lint request requires a raid")
+	}
+
+	// Value validations are reported by UUID, so give every value one that says where it is.
+	valueLocations := &aplValueLocations{order: make(map[string]int)}
+	for _, party := range request.Raid.Parties {
+		for _, player := range party.Players {
+			if player.GetRotation() != nil {
+				valueLocations.label(player.Rotation.ProtoReflect(), "")
+			}
+		}
+	}
+
+	result := core.ComputeStats(request)
+	if result.ErrorResult != "" {
+		return fmt.Errorf("apl lint failed: %s", result.ErrorResult)
+	}
+
+	var findings []aplFinding
+	for partyIdx, party := range result.RaidStats.GetParties() {
+		for playerIdx, player := range party.Players {
+			stats := player.GetRotationStats()
+			if stats == nil {
+				continue
+			}
+			name := fmt.Sprintf("%d-%d", partyIdx+1, playerIdx+1)
+			add := func(location string, validations []*proto.APLValidation) {
+				for _, validation := range validations {
+					findings = append(findings, aplFinding{
+						Player:   name,
+						Location: location,
+						Level:    validation.LogLevel.String(),
+						Message:  validation.Validation,
+					})
+				}
+			}
+
+			for i, action := range stats.PrepullActions {
+				add(fmt.Sprintf("prepullActions[%d]", i), action.Validations)
+			}
+			for i, action := range stats.PriorityList {
+				add(fmt.Sprintf("priorityList[%d]", i), action.Validations)
+			}
+			for groupIdx, group := range stats.Groups {
+				add(fmt.Sprintf("groups[%d]", groupIdx), group.Validations)
+				for i, action := range group.Actions {
+					add(fmt.Sprintf("groups[%d].actions[%d]", groupIdx, i), action.Validations)
+				}
+			}
+
+			// UUID validations come back in no particular order, so sort them by where they are in the rotation.
+			uuidValidations := slices.Clone(stats.UuidValidations)
+			slices.SortStableFunc(uuidValidations, func(a, b *proto.UUIDValidations) int {
+				return valueLocations.compare(a.GetUuid().GetValue(), b.GetUuid().GetValue())
+			})
+			for _, validations := range uuidValidations {
+				add(valueLocations.path(validations.GetUuid().GetValue()), validations.Validations)
+			}
+		}
+	}
+
+	out, err := openOutput(aplLintOutfile)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	if aplLintFormat == formatJSON {
+		return writeJSON(out, findings)
+	}
+
+	header := []string{"Player", "Location", "Level", "Message"}
+	rows := make([][]string, len(findings))
+	for i, finding := range findings {
+		rows[i] = []string{finding.Player, finding.Location, finding.Level, finding.Message}
+	}
+	return writeRows(out, aplLintFormat, header, rows)
+}
+
+// Paths of the APLValues in the input rotations, e.g. priorityList[2].action.condition, by UUID.
+type aplValueLocations struct {
+	paths []string
+	order map[string]int
+}
+
+// Records the path of every APLValue in the message, and sets it as the UUID of values that don't have one yet.
+func (locations *aplValueLocations) label(msg protoreflect.Message, path string) {
+	if value, ok := msg.Interface().(*proto.APLValue); ok {
+		if value.GetUuid().GetValue() == "" {
+			value.Uuid = &proto.UUID{Value: path}
+		}
+		locations.order[value.Uuid.Value] = len(locations.paths)
+		locations.paths = append(locations.paths, path)
+	}
+
+	msg.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		if fd.Kind() != protoreflect.MessageKind {
+			return true
+		}
+		fieldPath := fd.JSONName()
+		if path != "" {
+			fieldPath = path + "." + fieldPath
+		}
+		if fd.IsList() {
+			list := v.List()
+			for i := 0; i < list.Len(); i++ {
+				locations.label(list.Get(i).Message(), fmt.Sprintf("%s[%d]", fieldPath, i))
+			}
+		} else if !fd.IsMap() {
+			locations.label(v.Message(), fieldPath)
+		}
+		return true
+	})
+}
+
+func (locations *aplValueLocations) path(uuid string) string {
+	if idx, ok := locations.order[uuid]; ok {
+		return locations.paths[idx]
+	}
+	return uuid
+}
+
+// Orders UUIDs by their position in the rotation, with unknown UUIDs last.
+func (locations *aplValueLocations) compare(a string, b string) int {
+	idxA, okA := locations.order[a]
+	idxB, okB := locations.order[b]
+	if !okA {
+		idxA = len(locations.paths)
+	}
+	if !okB {
+		idxB = len(locations.paths)
+	}
+	return cmp.Compare(idxA, idxB)
+}
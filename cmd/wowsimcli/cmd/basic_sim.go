@@ -4,10 +4,12 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/wowsims/mop/sim/core"
 	"github.com/wowsims/mop/sim/core/proto"
+	"github.com/wowsims/mop/sim/distributed"
 	"google.golang.org/protobuf/encoding/protojson"
 )
 
@@ -15,6 +17,17 @@ var (
 	infile  string
 	outfile string
 	verbose bool
+
+	simLink       string
+	simIterations int32
+	simSeed       int64
+	simDuration   float64
+	simWorkers    string
+
+	combatLogOutfile string
+	combatLogFormat  string
+
+	simAPLTrace bool
 )
 
 var simCmd = &cobra.Command{
@@ -27,23 +40,64 @@ func init() {
 	simCmd.Flags().StringVar(&infile, "infile", "input.json", "location of input file (RaidSimRequest in protojson format)")
 	simCmd.Flags().StringVar(&outfile, "outfile", "", "location of output file, defaults to stdout")
 	simCmd.Flags().BoolVar(&verbose, "verbose", false, "print information during runtime")
-	simCmd.MarkFlagRequired("infile")
+	simCmd.Flags().StringVar(&simLink, "link", "", "exported individual or raid sim link to sim instead of an input file")
+	simCmd.Flags().Int32Var(&simIterations, "iterations", 0, "override the number of iterations")
+	simCmd.Flags().Int64Var(&simSeed, "seed", 0, "override the random seed")
+	simCmd.Flags().Float64Var(&simDuration, "duration", 0, "override the encounter duration, in seconds")
+	simCmd.Flags().StringVar(&simWorkers, "workers", "", "comma separated wowsimworker URLs to split the sim across instead of simming locally")
+	simCmd.Flags().StringVar(&combatLogOutfile, "combat-log", "", "location to export the structured combat log of the first iteration to")
+	simCmd.Flags().StringVar(&combatLogFormat, "combat-log-format", combatLogFormatJSONL, "combat log format: jsonl or protobuf")
+	simCmd.Flags().BoolVar(&simAPLTrace, "apl-trace", false, "profile each player's APL actions, and trace the rotation's decisions in the first iteration")
+	simCmd.MarkFlagsMutuallyExclusive("infile", "link")
+}
+
+func applySimOverrides(rsr *proto.RaidSimRequest) {
+	if rsr.SimOptions == nil {
+		rsr.SimOptions = &proto.SimOptions{Iterations: defaultIterations}
+	}
+	if simIterations > 0 {
+		rsr.SimOptions.Iterations = simIterations
+	}
+	if simSeed != 0 {
+		rsr.SimOptions.RandomSeed = simSeed
+	}
+	if simDuration > 0 {
+		if rsr.Encounter == nil {
+			rsr.Encounter = &proto.Encounter{}
+		}
+		rsr.Encounter.Duration = simDuration
+	}
 }
 
 func simMain(cmd *cobra.Command, args []string) {
-	data, err := os.ReadFile(infile)
-	if err != nil {
-		log.Fatalf("failed to load input json file %q: %v", infile, err)
+	source := infile
+	if simLink != "" {
+		source = simLink
 	}
-	input := &proto.RaidSimRequest{}
-	err = protojson.UnmarshalOptions{DiscardUnknown: true}.Unmarshal(data, input)
+	input, err := loadRaidSimRequest(source)
 	if err != nil {
-		log.Fatalf("failed to load input json file: %s", err)
+		log.Fatal(err)
+	}
+	applySimOverrides(input)
+	if combatLogOutfile != "" {
+		if err := validateCombatLogFormat(combatLogFormat); err != nil {
+			log.Fatal(err)
+		}
+		input.SimOptions.CombatLog = true
+		input.SimOptions.DebugFirstIteration = true
+	}
+	if simAPLTrace {
+		input.SimOptions.AplTrace = true
+		input.SimOptions.DebugFirstIteration = true
 	}
 
 	var output []byte
 	reporter := make(chan *proto.ProgressMetrics, 10)
-	core.RunRaidSimConcurrentAsync(input, reporter, "cmd-raid-sim")
+	if simWorkers != "" {
+		distributed.NewCoordinator(strings.Split(simWorkers, ",")).RunRaidSimAsync(input, reporter, "cmd-raid-sim")
+	} else {
+		core.RunRaidSimConcurrentAsync(input, reporter, "cmd-raid-sim")
+	}
 
 	var finalResult *proto.RaidSimResult
 	for v := range reporter {
@@ -56,6 +110,17 @@ func simMain(cmd *cobra.Command, args []string) {
 		}
 	}
 
+	if combatLogOutfile != "" && finalResult.Error == nil {
+		if err := writeCombatLog(combatLogOutfile, combatLogFormat, finalResult.CombatLog); err != nil {
+			log.Fatalf("failed to write combat log: %s", err)
+		}
+		if verbose {
+			fmt.Printf("Wrote %d combat log events to `%s`.\n", len(finalResult.CombatLog), combatLogOutfile)
+		}
+		// Already exported, don't repeat it in the result.
+		finalResult.CombatLog = nil
+	}
+
 	output, err = protojson.MarshalOptions{EmitUnpopulated: true}.Marshal(finalResult)
 	if err != nil {
 		log.Fatalf("failed to marshal final results: %s", err)
@@ -0,0 +1,231 @@
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/spf13/cobra"
+	"github.com/wowsims/mop/sim/core"
+	"github.com/wowsims/mop/sim/core/proto"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+var (
+	batchWorkers    int
+	batchIterations int32
+	batchOutfile    string
+	batchFormat     string
+	batchVerbose    bool
+)
+
+var batchCmd = &cobra.Command{
+	Use:   "batch [directory or jsonl file]",
+	Short: "sim many requests in parallel",
+	Long: "Sims every RaidSimRequest in a directory of protojson files, or in a JSONL file with one request per line.\n" +
+		"JSONL lines may also be exported sim links.",
+	Args:         cobra.ExactArgs(1),
+	RunE:         batchMain,
+	SilenceUsage: true,
+}
+
+func init() {
+	batchCmd.Flags().IntVar(&batchWorkers, "workers", runtime.NumCPU(), "number of sims to run at the same time")
+	batchCmd.Flags().Int32Var(&batchIterations, "iterations", 0, "override the number of iterations of every request")
+	batchCmd.Flags().StringVar(&batchOutfile, "outfile", "", "location of output file, defaults to stdout")
+	batchCmd.Flags().StringVar(&batchFormat, "format", formatTable, "output format: json, csv or table")
+	batchCmd.Flags().BoolVar(&batchVerbose, "verbose", false, "print information during runtime")
+}
+
+type batchInput struct {
+	name    string
+	request *proto.RaidSimRequest
+	err     error
+}
+
+type batchResult struct {
+	Name       string  `json:"name"`
+	Iterations int32   `json:"iterations"`
+	Dps        float64 `json:"dps"`
+	DpsStdev   float64 `json:"dpsStdev"`
+	Hps        float64 `json:"hps"`
+	Tps        float64 `json:"tps"`
+	Dtps       float64 `json:"dtps"`
+	Error      string  `json:"error,omitempty"`
+}
+
+func loadBatchInputs(path string) ([]batchInput, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var inputs []batchInput
+	if info.IsDir() {
+		files, err := filepath.Glob(filepath.Join(path, "*.json"))
+		if err != nil {
+			return nil, err
+		}
+		sort.Strings(files)
+		for _, file := range files {
+			request, err := loadRaidSimRequest(file)
+			inputs = append(inputs, batchInput{
+				name:    strings.TrimSuffix(filepath.Base(file), ".json"),
+				request: request,
+				err:     err,
+			})
+		}
+		return inputs, nil
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 1024*1024), 64*1024*1024)
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		input := batchInput{name: "line " + strconv.Itoa(lineNum)}
+		if isLink(line) {
+			input.request, input.err = raidSimRequestFromLink(line)
+		} else {
+			input.request = &proto.RaidSimRequest{}
+			input.err = protojson.UnmarshalOptions{DiscardUnknown: true}.Unmarshal([]byte(line), input.request)
+		}
+		if input.request != nil && input.request.RequestId != "" {
+			input.name = input.request.RequestId
+		}
+		inputs = append(inputs, input)
+	}
+	return inputs, scanner.Err()
+}
+
+func runBatchInput(input batchInput) batchResult {
+	result := batchResult{Name: input.name}
+	if input.err != nil {
+		result.Error = input.err.Error()
+		return result
+	}
+	if input.request.SimOptions == nil {
+		input.request.SimOptions = &proto.SimOptions{Iterations: defaultIterations}
+	}
+	if batchIterations > 0 {
+		input.request.SimOptions.Iterations = batchIterations
+	}
+	result.Iterations = input.request.SimOptions.Iterations
+
+	simResult := core.RunRaidSim(input.request)
+	if simResult.Error != nil {
+		result.Error = simResult.Error.Message
+		return result
+	}
+
+	raidMetrics := simResult.RaidMetrics
+	result.Dps = raidMetrics.GetDps().GetAvg()
+	result.DpsStdev = raidMetrics.GetDps().GetStdev()
+	result.Hps = raidMetrics.GetHps().GetAvg()
+	if len(raidMetrics.GetParties()) > 0 && len(raidMetrics.Parties[0].Players) > 0 {
+		player := raidMetrics.Parties[0].Players[0]
+		result.Tps = player.GetThreat().GetAvg()
+		result.Dtps = player.GetDtps().GetAvg()
+	}
+	return result
+}
+
+func batchMain(cmd *cobra.Command, args []string) error {
+	if err := validateFormat(batchFormat); err != nil {
+		return err
+	}
+	if batchWorkers < 1 {
+		return errors.New("--workers must be at least 1")
+	}
+
+	inputs, err := loadBatchInputs(args[0])
+	if err != nil {
+		return err
+	}
+	if len(inputs) == 0 {
+		return fmt.Errorf("no requests found in %s", args[0])
+	}
+
+	results := make([]batchResult, len(inputs))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	var progressMut sync.Mutex
+	completed := 0
+	for range min(batchWorkers, len(inputs)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for idx := range jobs {
+				results[idx] = runBatchInput(inputs[idx])
+				if batchVerbose {
+					progressMut.Lock()
+					completed++
+					fmt.Fprintf(os.Stderr, "Batch Progress: %d / %d (%s)\n", completed, len(inputs), inputs[idx].name)
+					progressMut.Unlock()
+				}
+			}
+		}()
+	}
+	for idx := range inputs {
+		jobs <- idx
+	}
+	close(jobs)
+	wg.Wait()
+
+	out, err := openOutput(batchOutfile)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	numFailed := 0
+	header := []string{"Name", "Iterations", "DPS", "DPS Stdev", "HPS", "TPS", "DTPS", "Error"}
+	rows := make([][]string, 0, len(results))
+	for _, result := range results {
+		if result.Error != "" {
+			numFailed++
+		}
+		rows = append(rows, []string{
+			result.Name,
+			strconv.Itoa(int(result.Iterations)),
+			formatFloat(result.Dps),
+			formatFloat(result.DpsStdev),
+			formatFloat(result.Hps),
+			formatFloat(result.Tps),
+			formatFloat(result.Dtps),
+			result.Error,
+		})
+	}
+
+	if batchFormat == formatJSON {
+		err = writeJSON(out, results)
+	} else {
+		err = writeRows(out, batchFormat, header, rows)
+	}
+	if err != nil {
+		return err
+	}
+
+	if numFailed > 0 {
+		return fmt.Errorf("%d of %d sims failed", numFailed, len(results))
+	}
+	return nil
+}
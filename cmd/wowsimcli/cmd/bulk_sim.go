@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/wowsims/mop/sim/core"
+	"github.com/wowsims/mop/sim/core/proto"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+var (
+	bulkInfile  string
+	bulkOutfile string
+	bulkFormat  string
+	bulkVerbose bool
+)
+
+var bulkCmd = &cobra.Command{
+	Use:   "bulk",
+	Short: "sim all combinations of a set of items",
+	Run:   bulkMain,
+}
+
+func init() {
+	bulkCmd.Flags().StringVar(&bulkInfile, "infile", "input.json", "location of input file (BulkSimRequest in protojson format)")
+	bulkCmd.Flags().StringVar(&bulkOutfile, "outfile", "", "location of output file, defaults to stdout")
+	bulkCmd.Flags().StringVar(&bulkFormat, "format", formatJSON, "output format: json, csv or table")
+	bulkCmd.Flags().BoolVar(&bulkVerbose, "verbose", false, "print information during runtime")
+	bulkCmd.MarkFlagRequired("infile")
+}
+
+func bulkMain(cmd *cobra.Command, args []string) {
+	if err := validateFormat(bulkFormat); err != nil {
+		log.Fatal(err)
+	}
+
+	data, err := os.ReadFile(bulkInfile)
+	if err != nil {
+		log.Fatalf("failed to load input json file %q: %v", bulkInfile, err)
+	}
+	input := &proto.BulkSimRequest{}
+	err = protojson.UnmarshalOptions{DiscardUnknown: true}.Unmarshal(data, input)
+	if err != nil {
+		log.Fatalf("failed to load input json file: %s", err)
+	}
+
+	reporter := make(chan *proto.ProgressMetrics, 10)
+	core.BulkSimAsync(input, reporter, "cmd-bulk-sim")
+
+	var finalResult *proto.BulkSimResult
+	for v := range reporter {
+		if v.FinalBulkResult != nil {
+			finalResult = v.FinalBulkResult
+			break
+		}
+		if bulkVerbose {
+			fmt.Printf("Bulk Progress: %d / %d sims, %d / %d iterations\n", v.CompletedSims, v.TotalSims, v.CompletedIterations, v.TotalIterations)
+		}
+	}
+
+	if finalResult.Error != nil {
+		log.Fatalf("bulk sim failed: %s", finalResult.Error.Message)
+	}
+
+	header := []string{"Rank", "Items", "DPS", "HPS"}
+	rows := [][]string{{"-", "equipped", formatFloat(finalResult.EquippedGearResult.Dps), formatFloat(finalResult.EquippedGearResult.Hps)}}
+	for rank, combo := range finalResult.Results {
+		items := make([]string, len(combo.ItemsAdded))
+		for i, item := range combo.ItemsAdded {
+			items[i] = fmt.Sprintf("%s:%d", strings.TrimPrefix(item.Slot.String(), "ItemSlot"), item.Item.Id)
+		}
+		rows = append(rows, []string{strconv.Itoa(rank + 1), strings.Join(items, " "), formatFloat(combo.Dps), formatFloat(combo.Hps)})
+	}
+
+	if err := writeResult(bulkOutfile, bulkFormat, finalResult, header, rows); err != nil {
+		log.Fatalf("failed to write output: %s", err)
+	}
+	if bulkVerbose && bulkOutfile != "" {
+		fmt.Printf("Wrote output file: `%s` successfully.\n", bulkOutfile)
+	}
+}
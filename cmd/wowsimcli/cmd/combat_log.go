@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+
+	"github.com/wowsims/mop/sim/core/proto"
+	"google.golang.org/protobuf/encoding/protodelim"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+const (
+	combatLogFormatJSONL    = "jsonl"
+	combatLogFormatProtobuf = "protobuf"
+)
+
+func validateCombatLogFormat(format string) error {
+	switch format {
+	case combatLogFormatJSONL, combatLogFormatProtobuf:
+		return nil
+	}
+	return fmt.Errorf("unknown combat log format %q, expected jsonl or protobuf", format)
+}
+
+// Writes one event per line for jsonl, or size-delimited CombatLogEvent
+// messages for protobuf so readers can decode the file as a stream.
+func writeCombatLog(path string, format string, events []*proto.CombatLogEvent) error {
+	out, err := openOutput(path)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	w := bufio.NewWriter(out)
+	for _, event := range events {
+		if format == combatLogFormatProtobuf {
+			if _, err := protodelim.MarshalTo(w, event); err != nil {
+				return fmt.Errorf("failed to write combat log event: %w", err)
+			}
+			continue
+		}
+
+		data, err := protojson.Marshal(event)
+		if err != nil {
+			return fmt.Errorf("failed to marshal combat log event: %w", err)
+		}
+		w.Write(data)
+		w.WriteByte('\n')
+	}
+	return w.Flush()
+}
@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/wowsims/mop/sim/core"
+	"github.com/wowsims/mop/sim/core/proto"
+)
+
+var (
+	computeStatsInfile  string
+	computeStatsLink    string
+	computeStatsOutfile string
+	computeStatsFormat  string
+)
+
+var computeStatsCmd = &cobra.Command{
+	Use:          "computestats",
+	Short:        "compute character stats without running a sim",
+	RunE:         computeStatsMain,
+	SilenceUsage: true,
+}
+
+func init() {
+	computeStatsCmd.Flags().StringVar(&computeStatsInfile, "infile", "", "location of input file (ComputeStatsRequest in protojson format)")
+	computeStatsCmd.Flags().StringVar(&computeStatsLink, "link", "", "exported individual sim link to use instead of an input file")
+	computeStatsCmd.Flags().StringVar(&computeStatsOutfile, "outfile", "", "location of output file, defaults to stdout")
+	computeStatsCmd.Flags().StringVar(&computeStatsFormat, "format", formatJSON, "output format: json, csv or table")
+	computeStatsCmd.MarkFlagsMutuallyExclusive("infile", "link")
+}
+
+func computeStatsMain(cmd *cobra.Command, args []string) error {
+	if err := validateFormat(computeStatsFormat); err != nil {
+		return err
+	}
+
+	request := &proto.ComputeStatsRequest{}
+	if computeStatsInfile == "" && computeStatsLink == "" {
+		return errors.New("one of --infile or --link is required")
+	} else if computeStatsLink != "" {
+		settings, err := individualSettingsFromLink(computeStatsLink)
+		if err != nil {
+			return err
+		}
+		request.Raid = individualSettingsToRaid(settings)
+		request.Encounter = settings.Encounter
+	} else if err := readProtoJSON(computeStatsInfile, request); err != nil {
+		return err
+	}
+	if request.Raid == nil {
+		return errors.New("compute stats request requires a raid")
+	}
+
+	result := core.ComputeStats(request)
+	if result.ErrorResult != "" {
+		return fmt.Errorf("compute stats failed: %s", result.ErrorResult)
+	}
+
+	header := []string{"Player", "Stat", "Base", "Gear", "Talents", "Buffs", "Consumes", "Final"}
+	var rows [][]string
+	for partyIdx, party := range result.RaidStats.GetParties() {
+		for playerIdx, player := range party.Players {
+			if player.GetFinalStats() == nil {
+				continue
+			}
+			name := fmt.Sprintf("%d-%d", partyIdx+1, playerIdx+1)
+			for stat := range player.FinalStats.Stats {
+				rows = append(rows, []string{
+					name,
+					statName(proto.Stat(stat)),
+					formatFloat(player.BaseStats.GetStats()[stat]),
+					formatFloat(player.GearStats.GetStats()[stat]),
+					formatFloat(player.TalentsStats.GetStats()[stat]),
+					formatFloat(player.BuffsStats.GetStats()[stat]),
+					formatFloat(player.ConsumesStats.GetStats()[stat]),
+					formatFloat(player.FinalStats.Stats[stat]),
+				})
+			}
+		}
+	}
+
+	return writeResult(computeStatsOutfile, computeStatsFormat, result, header, rows)
+}
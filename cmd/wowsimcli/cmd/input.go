@@ -0,0 +1,143 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/wowsims/mop/sim/core"
+	"github.com/wowsims/mop/sim/core/proto"
+	"google.golang.org/protobuf/encoding/protojson"
+	goproto "google.golang.org/protobuf/proto"
+)
+
+// Matches the default iteration count of the web UI.
+const defaultIterations = 12500
+
+var errRaidLink = errors.New("raid sim links are not supported by this command")
+
+func isLink(input string) bool {
+	return strings.HasPrefix(input, "http://") || strings.HasPrefix(input, "https://")
+}
+
+func readProtoJSON(path string, msg goproto.Message) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to load input json file %q: %w", path, err)
+	}
+	if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(data, msg); err != nil {
+		return fmt.Errorf("failed to parse input json file %q: %w", path, err)
+	}
+	return nil
+}
+
+func individualSettingsFromLink(link string) (*proto.IndividualSimSettings, error) {
+	settings, err := decodeLinkSettings(link)
+	if err != nil {
+		return nil, err
+	}
+	individual, ok := settings.(*proto.IndividualSimSettings)
+	if !ok {
+		return nil, errRaidLink
+	}
+	return individual, nil
+}
+
+// The UI only keeps the extra wrist and hands sockets for blacksmiths, so
+// drop any gems left in them by other players.
+func removeBlacksmithSockets(player *proto.Player) {
+	if player.GetEquipment() == nil || player.Profession1 == proto.Profession_Blacksmithing || player.Profession2 == proto.Profession_Blacksmithing {
+		return
+	}
+
+	for _, slot := range []proto.ItemSlot{proto.ItemSlot_ItemSlotWrist, proto.ItemSlot_ItemSlotHands} {
+		if int(slot) >= len(player.Equipment.Items) {
+			continue
+		}
+		spec := player.Equipment.Items[slot]
+		item := core.GetItemByID(spec.GetId())
+		if item == nil || len(spec.Gems) <= len(item.GemSockets) {
+			continue
+		}
+		spec.Gems = spec.Gems[:len(item.GemSockets)]
+	}
+}
+
+// Builds the raid for an individual sim the same way the UI does: the player
+// alone in the first party, plus any target dummies.
+func individualSettingsToRaid(settings *proto.IndividualSimSettings) *proto.Raid {
+	removeBlacksmithSockets(settings.Player)
+	raid := core.SinglePlayerRaidProto(settings.Player, settings.PartyBuffs, settings.RaidBuffs, settings.Debuffs)
+	raid.Tanks = settings.Tanks
+	raid.TargetDummies = settings.TargetDummies
+	return raid
+}
+
+func simSettingsToSimOptions(settings *proto.SimSettings) *proto.SimOptions {
+	simOptions := &proto.SimOptions{
+		Iterations: settings.GetIterations(),
+		RandomSeed: settings.GetFixedRngSeed(),
+	}
+	if simOptions.Iterations == 0 {
+		simOptions.Iterations = defaultIterations
+	}
+	if simOptions.RandomSeed == 0 {
+		simOptions.RandomSeed = time.Now().UnixNano()
+	}
+	return simOptions
+}
+
+func individualSettingsToRaidSimRequest(settings *proto.IndividualSimSettings) *proto.RaidSimRequest {
+	return &proto.RaidSimRequest{
+		Raid:       individualSettingsToRaid(settings),
+		Encounter:  settings.Encounter,
+		SimOptions: simSettingsToSimOptions(settings.Settings),
+	}
+}
+
+func raidSettingsToRaidSimRequest(settings *proto.RaidSimSettings) *proto.RaidSimRequest {
+	for _, party := range settings.GetRaid().GetParties() {
+		for _, player := range party.Players {
+			if player != nil {
+				removeBlacksmithSockets(player)
+			}
+		}
+	}
+
+	return &proto.RaidSimRequest{
+		Raid:       settings.Raid,
+		Encounter:  settings.Encounter,
+		SimOptions: simSettingsToSimOptions(settings.Settings),
+	}
+}
+
+// Converts either kind of exported link into a RaidSimRequest.
+func raidSimRequestFromLink(link string) (*proto.RaidSimRequest, error) {
+	settings, err := decodeLinkSettings(link)
+	if err != nil {
+		return nil, err
+	}
+
+	switch settings := settings.(type) {
+	case *proto.IndividualSimSettings:
+		return individualSettingsToRaidSimRequest(settings), nil
+	case *proto.RaidSimSettings:
+		return raidSettingsToRaidSimRequest(settings), nil
+	}
+	return nil, errInvalidLink
+}
+
+// Reads a RaidSimRequest from either a protojson file or an exported link.
+func loadRaidSimRequest(input string) (*proto.RaidSimRequest, error) {
+	if isLink(input) {
+		return raidSimRequestFromLink(input)
+	}
+
+	rsr := &proto.RaidSimRequest{}
+	if err := readProtoJSON(input, rsr); err != nil {
+		return nil, err
+	}
+	return rsr, nil
+}
@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/wowsims/mop/tools/logreplay"
+)
+
+var (
+	logReplayInfile        string
+	logReplayLink          string
+	logReplayLog           string
+	logReplaySourceID      int32
+	logReplayCastTolerance float64
+	logReplayOutfile       string
+	logReplayFormat        string
+)
+
+var logReplayCmd = &cobra.Command{
+	Use:          "logreplay",
+	Short:        "replay the casts of a combat log and report where the sim disagrees",
+	RunE:         logReplayMain,
+	SilenceUsage: true,
+}
+
+func init() {
+	logReplayCmd.Flags().StringVar(&logReplayInfile, "infile", "", "location of input file (RaidSimRequest in protojson format) with the logged player's setup")
+	logReplayCmd.Flags().StringVar(&logReplayLink, "link", "", "exported sim link with the logged player's setup to use instead of an input file")
+	logReplayCmd.Flags().StringVar(&logReplayLog, "log", "", "location of the combat log, as WarcraftLogs events in JSON or CSV")
+	logReplayCmd.Flags().Int32Var(&logReplaySourceID, "source-id", 0, "log source ID of the player, defaults to the source with the most casts")
+	logReplayCmd.Flags().Float64Var(&logReplayCastTolerance, "cast-tolerance", 1, "seconds the sim may delay a logged cast before skipping it")
+	logReplayCmd.Flags().StringVar(&logReplayOutfile, "outfile", "", "location of output file, defaults to stdout")
+	logReplayCmd.Flags().StringVar(&logReplayFormat, "format", formatTable, "output format: json, csv or table")
+	logReplayCmd.MarkFlagsMutuallyExclusive("infile", "link")
+	logReplayCmd.MarkFlagRequired("log")
+}
+
+func logReplayMain(cmd *cobra.Command, args []string) error {
+	if err := validateFormat(logReplayFormat); err != nil {
+		return err
+	}
+	if logReplayInfile == "" && logReplayLink == "" {
+		return errors.New("one of --infile or --link is required")
+	}
+
+	source := logReplayInfile
+	if logReplayLink != "" {
+		source = logReplayLink
+	}
+	request, err := loadRaidSimRequest(source)
+	if err != nil {
+		return err
+	}
+	combatLog, err := logreplay.ReadFile(logReplayLog)
+	if err != nil {
+		return err
+	}
+
+	report, err := logreplay.Replay(request, combatLog, logreplay.Options{
+		SourceID:      logReplaySourceID,
+		CastTolerance: time.Duration(logReplayCastTolerance * float64(time.Second)),
+	})
+	if err != nil {
+		return err
+	}
+
+	out, err := openOutput(logReplayOutfile)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	if logReplayFormat == formatJSON {
+		return writeJSON(out, report)
+	}
+
+	header := []string{"Time", "Kind", "SpellID", "Message"}
+	var rows [][]string
+	for _, discrepancy := range report.Discrepancies {
+		rows = append(rows, []string{
+			formatFloat(discrepancy.Time),
+			discrepancy.Kind,
+			strconv.Itoa(int(discrepancy.SpellID)),
+			discrepancy.Message,
+		})
+	}
+	if err := writeRows(out, logReplayFormat, header, rows); err != nil {
+		return err
+	}
+	if logReplayFormat == formatTable {
+		fmt.Fprintf(out, "\nSource %d: the sim performed %d of %d logged casts.\n", report.SourceID, report.MatchedCasts, report.Casts)
+	}
+	return nil
+}
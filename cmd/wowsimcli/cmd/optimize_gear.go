@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/wowsims/mop/sim/core"
+	"github.com/wowsims/mop/sim/core/proto"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+var (
+	optimizeInfile  string
+	optimizeOutfile string
+	optimizeVerbose bool
+)
+
+var optimizeCmd = &cobra.Command{
+	Use:   "optimize",
+	Short: "find the best reforges and gems for a set of equipment",
+	Run:   optimizeMain,
+}
+
+func init() {
+	optimizeCmd.Flags().StringVar(&optimizeInfile, "infile", "input.json", "location of input file (GearOptimizationRequest in protojson format)")
+	optimizeCmd.Flags().StringVar(&optimizeOutfile, "outfile", "", "location of output file, defaults to stdout")
+	optimizeCmd.Flags().BoolVar(&optimizeVerbose, "verbose", false, "print information during runtime")
+	optimizeCmd.MarkFlagRequired("infile")
+}
+
+func optimizeMain(cmd *cobra.Command, args []string) {
+	data, err := os.ReadFile(optimizeInfile)
+	if err != nil {
+		log.Fatalf("failed to load input json file %q: %v", optimizeInfile, err)
+	}
+	input := &proto.GearOptimizationRequest{}
+	err = protojson.UnmarshalOptions{DiscardUnknown: true}.Unmarshal(data, input)
+	if err != nil {
+		log.Fatalf("failed to load input json file: %s", err)
+	}
+
+	reporter := make(chan *proto.ProgressMetrics, 10)
+	core.OptimizeGearAsync(input, reporter, "cmd-optimize-gear")
+
+	var finalResult *proto.GearOptimizationResult
+	for v := range reporter {
+		if v.FinalGearOptimizationResult != nil {
+			finalResult = v.FinalGearOptimizationResult
+			break
+		}
+		if optimizeVerbose {
+			fmt.Printf("Stat Weight Progress: %d / %d sims, %d / %d iterations\n", v.CompletedSims, v.TotalSims, v.CompletedIterations, v.TotalIterations)
+		}
+	}
+
+	if finalResult.Error != nil {
+		log.Fatalf("gear optimization failed: %s", finalResult.Error.Message)
+	}
+
+	output, err := protojson.MarshalOptions{EmitUnpopulated: true}.Marshal(finalResult)
+	if err != nil {
+		log.Fatalf("failed to marshal final results: %s", err)
+	}
+
+	if optimizeOutfile == "" {
+		fmt.Print(string(output))
+	} else {
+		err = os.WriteFile(optimizeOutfile, output, 0666)
+		if err != nil {
+			log.Fatalf("failed to write output file:: %s", err)
+		}
+		if optimizeVerbose {
+			fmt.Printf("Wrote output file: `%s` successfully.\n", optimizeOutfile)
+		}
+	}
+}
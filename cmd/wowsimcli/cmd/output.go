@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/wowsims/mop/sim/core/proto"
+	"google.golang.org/protobuf/encoding/protojson"
+	goproto "google.golang.org/protobuf/proto"
+)
+
+const (
+	formatJSON  = "json"
+	formatCSV   = "csv"
+	formatTable = "table"
+)
+
+func validateFormat(format string) error {
+	switch format {
+	case formatJSON, formatCSV, formatTable:
+		return nil
+	}
+	return fmt.Errorf("unknown output format %q, expected one of json, csv or table", format)
+}
+
+// Opens the output file, or stdout if no path is given.
+func openOutput(path string) (io.WriteCloser, error) {
+	if path == "" {
+		return nopWriteCloser{os.Stdout}, nil
+	}
+	return os.Create(path)
+}
+
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error { return nil }
+
+// Writes the message as protojson for the json format, or the given rows otherwise.
+func writeResult(path string, format string, msg goproto.Message, header []string, rows [][]string) error {
+	out, err := openOutput(path)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	if format == formatJSON {
+		data, err := protojson.MarshalOptions{EmitUnpopulated: true}.Marshal(msg)
+		if err != nil {
+			return fmt.Errorf("failed to marshal results: %w", err)
+		}
+		_, err = out.Write(data)
+		return err
+	}
+	return writeRows(out, format, header, rows)
+}
+
+func writeRows(w io.Writer, format string, header []string, rows [][]string) error {
+	switch format {
+	case formatCSV:
+		cw := csv.NewWriter(w)
+		cw.Write(header)
+		cw.WriteAll(rows)
+		return cw.Error()
+	case formatTable:
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, strings.Join(header, "\t"))
+		for _, row := range rows {
+			fmt.Fprintln(tw, strings.Join(row, "\t"))
+		}
+		return tw.Flush()
+	}
+	return validateFormat(format)
+}
+
+func writeJSON(w io.Writer, v any) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
+
+func statName(stat proto.Stat) string {
+	return strings.TrimPrefix(stat.String(), "Stat")
+}
+
+// Parses a comma separated list of stat names, with or without the "Stat" prefix.
+func parseStats(list string) ([]proto.Stat, error) {
+	var result []proto.Stat
+	for _, name := range strings.Split(list, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		value, ok := proto.Stat_value[name]
+		if !ok {
+			value, ok = proto.Stat_value["Stat"+name]
+		}
+		if !ok {
+			return nil, fmt.Errorf("unknown stat %q", name)
+		}
+		result = append(result, proto.Stat(value))
+	}
+	return result, nil
+}
+
+func formatFloat(value float64) string {
+	return fmt.Sprintf("%.2f", value)
+}
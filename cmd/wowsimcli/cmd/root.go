@@ -17,6 +17,15 @@ func Execute(version string) {
 	rootCmd.AddCommand(newVersionCommand(version))
 	rootCmd.AddCommand(simCmd)
 	rootCmd.AddCommand(decodeLinkCmd)
+	rootCmd.AddCommand(bulkCmd)
+	rootCmd.AddCommand(optimizeCmd)
+	rootCmd.AddCommand(statWeightsCmd)
+	rootCmd.AddCommand(computeStatsCmd)
+	rootCmd.AddCommand(batchCmd)
+	rootCmd.AddCommand(logReplayCmd)
+	rootCmd.AddCommand(aplCmd)
+	rootCmd.AddCommand(aplLintCmd)
+	rootCmd.AddCommand(tuneAPLCmd)
 
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Fprintln(os.Stderr, err)
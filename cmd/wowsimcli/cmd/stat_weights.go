@@ -0,0 +1,178 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/wowsims/mop/sim/core"
+	"github.com/wowsims/mop/sim/core/proto"
+	"github.com/wowsims/mop/sim/distributed"
+)
+
+var (
+	statWeightsInfile  string
+	statWeightsLink    string
+	statWeightsStats   string
+	statWeightsRefStat string
+	statWeightsOutfile string
+	statWeightsFormat  string
+	statWeightsVerbose bool
+	statWeightsWorkers string
+)
+
+// Stats weighed for links which don't have any EP weights set.
+var defaultStatsToWeigh = []proto.Stat{
+	proto.Stat_StatStrength,
+	proto.Stat_StatAgility,
+	proto.Stat_StatIntellect,
+	proto.Stat_StatSpirit,
+	proto.Stat_StatHitRating,
+	proto.Stat_StatCritRating,
+	proto.Stat_StatHasteRating,
+	proto.Stat_StatExpertiseRating,
+	proto.Stat_StatMasteryRating,
+}
+
+var statWeightsCmd = &cobra.Command{
+	Use:          "statweights",
+	Short:        "calculate stat weights and EP values",
+	RunE:         statWeightsMain,
+	SilenceUsage: true,
+}
+
+func init() {
+	statWeightsCmd.Flags().StringVar(&statWeightsInfile, "infile", "", "location of input file (StatWeightsRequest in protojson format)")
+	statWeightsCmd.Flags().StringVar(&statWeightsLink, "link", "", "exported individual sim link to use instead of an input file")
+	statWeightsCmd.Flags().StringVar(&statWeightsStats, "stats", "", "comma separated stats to weigh, e.g. Agility,HitRating (overrides the input)")
+	statWeightsCmd.Flags().StringVar(&statWeightsRefStat, "ref-stat", "", "stat used as the EP reference (overrides the input)")
+	statWeightsCmd.Flags().StringVar(&statWeightsOutfile, "outfile", "", "location of output file, defaults to stdout")
+	statWeightsCmd.Flags().StringVar(&statWeightsFormat, "format", formatJSON, "output format: json, csv or table")
+	statWeightsCmd.Flags().BoolVar(&statWeightsVerbose, "verbose", false, "print information during runtime")
+	statWeightsCmd.Flags().StringVar(&statWeightsWorkers, "workers", "", "comma separated wowsimworker URLs to run the sims on instead of simming locally")
+	statWeightsCmd.MarkFlagsMutuallyExclusive("infile", "link")
+}
+
+func statWeightsRequestFromLink(link string) (*proto.StatWeightsRequest, error) {
+	settings, err := individualSettingsFromLink(link)
+	if err != nil {
+		return nil, err
+	}
+
+	removeBlacksmithSockets(settings.Player)
+	request := &proto.StatWeightsRequest{
+		Player:          settings.Player,
+		RaidBuffs:       settings.RaidBuffs,
+		PartyBuffs:      settings.PartyBuffs,
+		Debuffs:         settings.Debuffs,
+		Encounter:       settings.Encounter,
+		SimOptions:      simSettingsToSimOptions(settings.Settings),
+		Tanks:           settings.Tanks,
+		EpReferenceStat: settings.DpsRefStat,
+	}
+	for stat, weight := range settings.GetEpWeightsStats().GetStats() {
+		if weight != 0 {
+			request.StatsToWeigh = append(request.StatsToWeigh, proto.Stat(stat))
+		}
+	}
+	if len(request.StatsToWeigh) == 0 {
+		request.StatsToWeigh = slices.Clone(defaultStatsToWeigh)
+	}
+	return request, nil
+}
+
+func statWeightsMain(cmd *cobra.Command, args []string) error {
+	if err := validateFormat(statWeightsFormat); err != nil {
+		return err
+	}
+
+	var request *proto.StatWeightsRequest
+	var err error
+	if statWeightsInfile == "" && statWeightsLink == "" {
+		return errors.New("one of --infile or --link is required")
+	} else if statWeightsLink != "" {
+		request, err = statWeightsRequestFromLink(statWeightsLink)
+	} else {
+		request = &proto.StatWeightsRequest{}
+		err = readProtoJSON(statWeightsInfile, request)
+	}
+	if err != nil {
+		return err
+	}
+
+	if statWeightsStats != "" {
+		if request.StatsToWeigh, err = parseStats(statWeightsStats); err != nil {
+			return err
+		}
+	}
+	if statWeightsRefStat != "" {
+		refStat, err := parseStats(statWeightsRefStat)
+		if err != nil {
+			return err
+		}
+		if len(refStat) != 1 {
+			return errors.New("--ref-stat takes a single stat")
+		}
+		request.EpReferenceStat = refStat[0]
+	}
+	if request.Player == nil || request.SimOptions == nil {
+		return errors.New("stat weights request requires a player and sim options")
+	}
+
+	reporter := make(chan *proto.ProgressMetrics, 10)
+	if statWeightsWorkers != "" {
+		distributed.NewCoordinator(strings.Split(statWeightsWorkers, ",")).StatWeightsAsync(request, reporter, "cmd-stat-weights")
+	} else {
+		core.StatWeightsAsync(request, reporter, "cmd-stat-weights")
+	}
+
+	var finalResult *proto.StatWeightsResult
+	for v := range reporter {
+		if v.FinalWeightResult != nil {
+			finalResult = v.FinalWeightResult
+			break
+		}
+		if statWeightsVerbose {
+			fmt.Printf("Stat Weights Progress: %d / %d sims, %d / %d iterations\n", v.CompletedSims, v.TotalSims, v.CompletedIterations, v.TotalIterations)
+		}
+	}
+
+	if finalResult.Error != nil {
+		return fmt.Errorf("stat weights failed: %s", finalResult.Error.Message)
+	}
+
+	weighed := append([]proto.Stat{request.EpReferenceStat}, request.StatsToWeigh...)
+	slices.Sort(weighed)
+	weighed = slices.Compact(weighed)
+
+	header := []string{"Metric", "Stat", "Weight", "Weight Stdev", "EP", "EP Stdev"}
+	var rows [][]string
+	for _, metric := range []struct {
+		name   string
+		values *proto.StatWeightValues
+	}{
+		{"DPS", finalResult.Dps},
+		{"HPS", finalResult.Hps},
+		{"TPS", finalResult.Tps},
+		{"DTPS", finalResult.Dtps},
+	} {
+		weights := metric.values.GetWeights().GetStats()
+		if !slices.ContainsFunc(weights, func(w float64) bool { return w != 0 }) {
+			continue
+		}
+		for _, stat := range weighed {
+			rows = append(rows, []string{
+				metric.name,
+				statName(stat),
+				formatFloat(weights[stat]),
+				formatFloat(metric.values.WeightsStdev.Stats[stat]),
+				formatFloat(metric.values.EpValues.Stats[stat]),
+				formatFloat(metric.values.EpValuesStdev.Stats[stat]),
+			})
+		}
+	}
+
+	return writeResult(statWeightsOutfile, statWeightsFormat, finalResult, header, rows)
+}
@@ -0,0 +1,145 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/spf13/cobra"
+	"github.com/wowsims/mop/sim/core"
+	"github.com/wowsims/mop/sim/core/proto"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+var (
+	tuneAPLInfile            string
+	tuneAPLLink              string
+	tuneAPLRotation          string
+	tuneAPLRotationOutfile   string
+	tuneAPLCandidates        int32
+	tuneAPLInitialIterations int32
+	tuneAPLIterations        int32
+	tuneAPLSeed              int64
+	tuneAPLOutfile           string
+	tuneAPLFormat            string
+	tuneAPLVerbose           bool
+)
+
+var tuneAPLCmd = &cobra.Command{
+	Use:   "tune-apl",
+	Short: "tune the constants with a tunable range in a player's APL rotation",
+	Long: `Sims combinations of the APL constants that have a tunable range, keeping the best half each round with
+twice the iterations, and reports the best values with the range of values that were not significantly worse.
+Pass a preset rotation with --rotation and --rotation-outfile to re-tune it for a different character.`,
+	RunE:         tuneAPLMain,
+	SilenceUsage: true,
+}
+
+func init() {
+	tuneAPLCmd.Flags().StringVar(&tuneAPLInfile, "infile", "", "location of input file (APLTuningRequest in protojson format)")
+	tuneAPLCmd.Flags().StringVar(&tuneAPLLink, "link", "", "exported individual or raid sim link to use instead of an input file")
+	tuneAPLCmd.Flags().StringVar(&tuneAPLRotation, "rotation", "", "APL rotation file (protojson) to use for the first player, e.g. a preset with tunable ranges")
+	tuneAPLCmd.Flags().StringVar(&tuneAPLRotationOutfile, "rotation-outfile", "", "location to write the tuned rotation to")
+	tuneAPLCmd.Flags().Int32Var(&tuneAPLCandidates, "candidates", 0, "number of combinations of constants in the first round")
+	tuneAPLCmd.Flags().Int32Var(&tuneAPLInitialIterations, "initial-iterations", 0, "iterations for each combination in the first round")
+	tuneAPLCmd.Flags().Int32Var(&tuneAPLIterations, "iterations", 0, "override the iterations of the final comparison")
+	tuneAPLCmd.Flags().Int64Var(&tuneAPLSeed, "seed", 0, "override the random seed")
+	tuneAPLCmd.Flags().StringVar(&tuneAPLOutfile, "outfile", "", "location of output file, defaults to stdout")
+	tuneAPLCmd.Flags().StringVar(&tuneAPLFormat, "format", formatTable, "output format: json, csv or table")
+	tuneAPLCmd.Flags().BoolVar(&tuneAPLVerbose, "verbose", false, "print information during runtime")
+	tuneAPLCmd.MarkFlagsMutuallyExclusive("infile", "link")
+}
+
+func tuneAPLMain(cmd *cobra.Command, args []string) error {
+	if err := validateFormat(tuneAPLFormat); err != nil {
+		return err
+	}
+
+	request := &proto.APLTuningRequest{}
+	if tuneAPLInfile == "" && tuneAPLLink == "" {
+		return errors.New("one of --infile or --link is required")
+	} else if tuneAPLLink != "" {
+		baseSettings, err := loadRaidSimRequest(tuneAPLLink)
+		if err != nil {
+			return err
+		}
+		request.BaseSettings = baseSettings
+	} else if err := readProtoJSON(tuneAPLInfile, request); err != nil {
+		return err
+	}
+	if request.BaseSettings == nil {
+		return errors.New("apl tuning request requires base settings")
+	}
+
+	if tuneAPLRotation != "" {
+		rotation := &proto.APLRotation{}
+		if err := readProtoJSON(tuneAPLRotation, rotation); err != nil {
+			return err
+		}
+		parties := request.BaseSettings.GetRaid().GetParties()
+		if len(parties) == 0 || len(parties[0].Players) == 0 {
+			return errors.New("apl tuning request requires a player in the first party")
+		}
+		parties[0].Players[0].Rotation = rotation
+	}
+	if tuneAPLCandidates > 0 {
+		request.NumCandidates = tuneAPLCandidates
+	}
+	if tuneAPLInitialIterations > 0 {
+		request.InitialIterations = tuneAPLInitialIterations
+	}
+	applySimOverrides(request.BaseSettings)
+	if tuneAPLIterations > 0 {
+		request.BaseSettings.SimOptions.Iterations = tuneAPLIterations
+	}
+	if tuneAPLSeed != 0 {
+		request.BaseSettings.SimOptions.RandomSeed = tuneAPLSeed
+	}
+
+	reporter := make(chan *proto.ProgressMetrics, 10)
+	core.TuneAPLAsync(request, reporter, "cmd-tune-apl")
+
+	var finalResult *proto.APLTuningResult
+	for v := range reporter {
+		if v.FinalAplTuningResult != nil {
+			finalResult = v.FinalAplTuningResult
+			break
+		}
+		if tuneAPLVerbose {
+			fmt.Printf("APL Tuning Progress: %d / %d sims, %d / %d iterations\n", v.CompletedSims, v.TotalSims, v.CompletedIterations, v.TotalIterations)
+		}
+	}
+	if finalResult.Error != nil {
+		return fmt.Errorf("apl tuning failed: %s", finalResult.Error.Message)
+	}
+
+	if tuneAPLRotationOutfile != "" {
+		data, err := protojson.MarshalOptions{Multiline: true, Indent: "  "}.Marshal(finalResult.Rotation)
+		if err != nil {
+			return fmt.Errorf("failed to marshal tuned rotation: %w", err)
+		}
+		if err := os.WriteFile(tuneAPLRotationOutfile, data, 0666); err != nil {
+			return fmt.Errorf("failed to write tuned rotation: %w", err)
+		}
+	}
+
+	if tuneAPLFormat != formatJSON {
+		fmt.Fprintf(os.Stderr, "DPS %s ± %s, %s with the original constants (gain %s ± %s) after %d sims\n",
+			formatFloat(finalResult.Dps), formatFloat(finalResult.DpsStderr), formatFloat(finalResult.OriginalDps),
+			formatFloat(finalResult.DpsGain), formatFloat(finalResult.DpsGainStderr), finalResult.NumSims)
+	}
+
+	header := []string{"Location", "Original", "Tuned", "Low", "High"}
+	rows := make([][]string, len(finalResult.Consts))
+	for i, tuned := range finalResult.Consts {
+		rows[i] = []string{
+			tuned.Location,
+			tuned.OriginalVal,
+			tuned.Val,
+			strconv.FormatFloat(tuned.Low, 'f', -1, 64),
+			strconv.FormatFloat(tuned.High, 'f', -1, 64),
+		}
+	}
+	return writeResult(tuneAPLOutfile, tuneAPLFormat, finalResult, header, rows)
+}
@@ -0,0 +1,35 @@
+// Headless sim server for pooling machines. Point a coordinator at it with the
+// -workers flag of the web server or wowsimcli.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/wowsims/mop/sim"
+	"github.com/wowsims/mop/sim/distributed"
+)
+
+func init() {
+	sim.RegisterAll()
+}
+
+// Version information.
+// This variable is set by the makefile in the release process.
+var Version string
+
+func main() {
+	if Version == "" {
+		Version = "development"
+	}
+	var host = flag.String("host", ":3334", "Address to listen on.")
+	flag.Parse()
+
+	fmt.Printf("Version: %s\n", Version)
+	log.Printf("Sim worker listening on %s", *host)
+	if err := http.ListenAndServe(*host, distributed.NewWorkerHandler()); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -21,8 +21,15 @@ type Agent interface {
 
 	// Updates the input Buffs to include raid-wide buffs provided by this Agent.
 	AddRaidBuffs(raidBuffs *proto.RaidBuffs)
+	// Updates the input Buffs to include raid-wide buffs this Agent provides to the
+	// rest of the raid. Only called for raids with more than one player. Buffs the
+	// Agent casts itself, e.g. shouts, are left to its rotation.
+	AddRaidMemberBuffs(raidBuffs *proto.RaidBuffs)
 	// Updates the input Buffs to include party-wide buffs provided by this Agent.
 	AddPartyBuffs(partyBuffs *proto.PartyBuffs)
+	// Updates the input Debuffs to include raid-wide debuffs provided by this Agent.
+	// Only called for raids with more than one player.
+	AddDebuffs(debuffs *proto.Debuffs)
 
 	// All talent stats / auras should be added within this callback. This makes sure
 	// talents are applied at the right time so we can calculate groups of stats.
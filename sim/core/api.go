@@ -60,6 +60,90 @@ func StatWeightCompute(request *proto.StatWeightsCalcRequest) *proto.StatWeights
 	return computeStatWeights(request)
 }
 
+/**
+ * Runs a bulk sim, simming every combination of the provided items and ranking the results.
+ */
+func RunBulkSim(request *proto.BulkSimRequest) *proto.BulkSimResult {
+	return runBulkSim(request, nil, simsignals.CreateSignals())
+}
+
+func BulkSimAsync(request *proto.BulkSimRequest, progress chan *proto.ProgressMetrics, requestId string) {
+	signals, err := simsignals.RegisterWithId(requestId)
+	if err != nil {
+		progress <- &proto.ProgressMetrics{
+			FinalBulkResult: &proto.BulkSimResult{
+				Error: &proto.ErrorOutcome{
+					Message: "Couldn't register for signal API: " + err.Error(),
+				},
+			},
+		}
+		return
+	}
+	go func() {
+		defer simsignals.UnregisterId(requestId)
+		result := runBulkSim(request, progress, signals)
+		progress <- &proto.ProgressMetrics{
+			FinalBulkResult: result,
+		}
+	}()
+}
+
+/**
+ * Finds the best reforges and gems for a player's equipment given stat weights and caps.
+ */
+func OptimizeGear(request *proto.GearOptimizationRequest) *proto.GearOptimizationResult {
+	return runOptimizeGear(request, nil, simsignals.CreateSignals())
+}
+
+func OptimizeGearAsync(request *proto.GearOptimizationRequest, progress chan *proto.ProgressMetrics, requestId string) {
+	signals, err := simsignals.RegisterWithId(requestId)
+	if err != nil {
+		progress <- &proto.ProgressMetrics{
+			FinalGearOptimizationResult: &proto.GearOptimizationResult{
+				Error: &proto.ErrorOutcome{
+					Message: "Couldn't register for signal API: " + err.Error(),
+				},
+			},
+		}
+		return
+	}
+	go func() {
+		defer simsignals.UnregisterId(requestId)
+		result := runOptimizeGear(request, progress, signals)
+		progress <- &proto.ProgressMetrics{
+			FinalGearOptimizationResult: result,
+		}
+	}()
+}
+
+/**
+ * Tunes the constants marked with a tunable range in a player's APL rotation.
+ */
+func TuneAPL(request *proto.APLTuningRequest) *proto.APLTuningResult {
+	return runTuneAPL(request, nil, simsignals.CreateSignals())
+}
+
+func TuneAPLAsync(request *proto.APLTuningRequest, progress chan *proto.ProgressMetrics, requestId string) {
+	signals, err := simsignals.RegisterWithId(requestId)
+	if err != nil {
+		progress <- &proto.ProgressMetrics{
+			FinalAplTuningResult: &proto.APLTuningResult{
+				Error: &proto.ErrorOutcome{
+					Message: "Couldn't register for signal API: " + err.Error(),
+				},
+			},
+		}
+		return
+	}
+	go func() {
+		defer simsignals.UnregisterId(requestId)
+		result := runTuneAPL(request, progress, signals)
+		progress <- &proto.ProgressMetrics{
+			FinalAplTuningResult: result,
+		}
+	}()
+}
+
 /**
  * Runs multiple iterations of the sim with a full raid.
  */
@@ -14,6 +14,12 @@ type APLRotation struct {
 	groups         []*APLGroup
 	valueVariables []*APLValueVariable
 
+	// Variables changed by the Set Variable and Modify Variable actions, keyed by name.
+	mutableVariables map[string]*APLMutableVariable
+
+	// Names of the value variables currently being parsed, used to detect cycles.
+	resolvingVariables []string
+
 	// Action currently controlling this rotation (only used for certain actions, such as StrictSequence).
 	controllingActions []APLActionImpl
 
@@ -33,12 +39,21 @@ type APLRotation struct {
 	// Used to override MCD restrictions within sequences.
 	inSequence bool
 
+	// Profiles of the priority list and group actions, for SimOptions.AplTrace.
+	actionProfiles []*APLActionProfile
+	// Whether any actions were profiled, and whether they are being profiled right now.
+	profiled  bool
+	profiling bool
+	// The decision being traced, only set in logged iterations.
+	decision *proto.APLDecision
+
 	// Validation warnings that occur during proto parsing.
 	// We return these back to the user for display in the UI.
 	curValidations          []*proto.APLValidation
 	prepullValidations      [][]*proto.APLValidation
 	priorityListValidations [][]*proto.APLValidation
 	groupListValidations    [][][]*proto.APLValidation
+	groupValidations        [][]*proto.APLValidation
 	uuidValidations         map[*proto.UUID][]*proto.APLValidation
 
 	// Maps indices in filtered sim lists to indices in configs.
@@ -113,7 +128,9 @@ func (unit *Unit) newAPLRotation(config *proto.APLRotation) *APLRotation {
 		prepullValidations:      make([][]*proto.APLValidation, len(config.PrepullActions)),
 		priorityListValidations: make([][]*proto.APLValidation, len(config.PriorityList)),
 		groupListValidations:    make([][][]*proto.APLValidation, len(groupsConfig)),
+		groupValidations:        make([][]*proto.APLValidation, len(groupsConfig)),
 		uuidValidations:         make(map[*proto.UUID][]*proto.APLValidation),
+		mutableVariables:        make(map[string]*APLMutableVariable),
 	}
 
 	// Parse value variables FIRST, before any actions that might reference them
@@ -234,6 +251,8 @@ func (unit *Unit) newAPLRotation(config *proto.APLRotation) *APLRotation {
 		}
 	}
 
+	rotation.setupProfiles(config, groupsConfig)
+
 	// Finalize
 	for i, action := range rotation.prepullActions {
 		rotation.doAndRecordWarnings(&rotation.prepullValidations[rotation.prepullIdxMap[i]], true, func() {
@@ -255,6 +274,8 @@ func (unit *Unit) newAPLRotation(config *proto.APLRotation) *APLRotation {
 		})
 	}
 
+	rotation.analyze(len(config.Groups))
+
 	agent := unit.Env.GetAgentFromUnit(unit)
 	if agent != nil {
 		character := agent.GetCharacter()
@@ -344,6 +365,15 @@ func (rot *APLRotation) getStats() *proto.APLStats {
 		i++
 	}
 
+	groupStats := MapSlice(rot.groupListValidations, func(validations [][]*proto.APLValidation) *proto.APLGroupStats {
+		return &proto.APLGroupStats{Actions: MapSlice(validations, func(validations []*proto.APLValidation) *proto.APLActionStats {
+			return &proto.APLActionStats{Validations: validations}
+		})}
+	})
+	for i, validations := range rot.groupValidations {
+		groupStats[i].Validations = validations
+	}
+
 	return &proto.APLStats{
 		PrepullActions: MapSlice(rot.prepullValidations, func(validations []*proto.APLValidation) *proto.APLActionStats {
 			return &proto.APLActionStats{Validations: validations}
@@ -351,11 +381,7 @@ func (rot *APLRotation) getStats() *proto.APLStats {
 		PriorityList: MapSlice(rot.priorityListValidations, func(validations []*proto.APLValidation) *proto.APLActionStats {
 			return &proto.APLActionStats{Validations: validations}
 		}),
-		Groups: MapSlice(rot.groupListValidations, func(validations [][]*proto.APLValidation) *proto.APLGroupStats {
-			return &proto.APLGroupStats{Actions: MapSlice(validations, func(validations []*proto.APLValidation) *proto.APLActionStats {
-				return &proto.APLActionStats{Validations: validations}
-			})}
-		}),
+		Groups:          groupStats,
 		UuidValidations: uuidValidationsArr,
 	}
 }
@@ -384,6 +410,9 @@ func (rot *APLRotation) reset(sim *Simulation) {
 	rot.inLoop = false
 	rot.interruptChannelIf = nil
 	rot.allowChannelRecastOnInterrupt = false
+	for _, variable := range rot.mutableVariables {
+		variable.value = 0
+	}
 	for _, action := range rot.allAPLActions() {
 		action.impl.Reset(sim)
 	}
@@ -413,7 +442,7 @@ func (apl *APLRotation) DoNextAction(sim *Simulation) {
 	apl.inLoop = true
 
 	apl.unit.UpdatePosition(sim)
-	for nextAction := apl.getNextAction(sim); nextAction != nil; i, nextAction = i+1, apl.getNextAction(sim) {
+	for nextAction := apl.profileNextAction(sim); nextAction != nil; i, nextAction = i+1, apl.profileNextAction(sim) {
 		if i > 1000 {
 			panic(fmt.Sprintf("[USER_ERROR] Infinite loop detected, current action:\n%s", nextAction))
 		}
@@ -421,6 +450,7 @@ func (apl *APLRotation) DoNextAction(sim *Simulation) {
 		nextAction.Execute(sim)
 	}
 	apl.inLoop = false
+	apl.decision = nil
 
 	if sim.Log != nil && i == 0 {
 		apl.unit.Log(sim, "No available actions!")
@@ -9,6 +9,9 @@ import (
 type APLAction struct {
 	condition APLValue
 	impl      APLActionImpl
+
+	// Only set for actions in the priority list and groups.
+	profile *APLActionProfile
 }
 
 func (action *APLAction) Finalize(rot *APLRotation) {
@@ -24,10 +27,16 @@ func (action *APLAction) Finalize(rot *APLRotation) {
 }
 
 func (action *APLAction) IsReady(sim *Simulation) bool {
+	if action.profile != nil && action.profile.rot.profiling {
+		return action.profile.isReady(sim, action)
+	}
 	return (action.condition == nil || action.condition.GetBool(sim)) && action.impl.IsReady(sim)
 }
 
 func (action *APLAction) Execute(sim *Simulation) {
+	if action.profile != nil && sim.Options.AplTrace {
+		action.profile.execute()
+	}
 	action.impl.Execute(sim)
 }
 
@@ -151,6 +160,9 @@ func (rot *APLRotation) newAPLAction(config *proto.APLAction) *APLAction {
 		condition: rot.coerceTo(rot.newAPLValue(config.Condition), proto.APLValueType_ValueTypeBool),
 		impl:      impl,
 	}
+	if config.Condition.GetValue() != nil && action.condition == nil {
+		rot.ValidationMessage(proto.LogLevel_Warning, "Condition is invalid and will be ignored, so this action is always used when ready")
+	}
 
 	return action
 }
@@ -226,9 +238,17 @@ func (rot *APLRotation) newAPLActionImpl(config *proto.APLAction) APLActionImpl
 		return rot.newActionMoveDuration(config.GetMoveDuration())
 	case *proto.APLAction_CustomRotation:
 		return rot.newActionCustomRotation(config.GetCustomRotation())
+	case *proto.APLAction_Replay:
+		return rot.newActionReplay(config.GetReplay())
 	case *proto.APLAction_GroupReference:
 		return rot.newActionGroupReference(config.GetGroupReference())
 
+	// Variables
+	case *proto.APLAction_SetVariable:
+		return rot.newActionSetVariable(config.GetSetVariable())
+	case *proto.APLAction_ModifyVariable:
+		return rot.newActionModifyVariable(config.GetModifyVariable())
+
 	default:
 		return nil
 	}
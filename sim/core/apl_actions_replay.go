@@ -0,0 +1,154 @@
+package core
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/wowsims/mop/sim/core/proto"
+)
+
+// How often a replayed cast which can't be cast yet is retried, when there is
+// no better estimate of when it will become castable.
+const replayRetryInterval = time.Millisecond * 50
+
+type aplReplayCast struct {
+	at     time.Duration
+	spell  *Spell
+	target UnitReference
+}
+
+// APLActionReplay takes control of the rotation and forces a recorded cast
+// sequence at the recorded times. Casts which can't be performed within the
+// tolerance are skipped and reported as failed casts.
+type APLActionReplay struct {
+	defaultAPLActionImpl
+	unit      *Unit
+	casts     []aplReplayCast
+	tolerance time.Duration
+
+	nextCast int
+}
+
+func (rot *APLRotation) newActionReplay(config *proto.APLActionReplay) APLActionImpl {
+	tolerance := DurationFromSeconds(config.ToleranceSeconds)
+	if tolerance <= 0 {
+		tolerance = time.Second
+	}
+
+	casts := make([]aplReplayCast, 0, len(config.Casts))
+	for _, castConfig := range config.Casts {
+		spell := rot.GetAPLSpell(castConfig.SpellId)
+		if spell == nil {
+			continue
+		}
+		casts = append(casts, aplReplayCast{
+			at:     DurationFromSeconds(castConfig.TimeSeconds),
+			spell:  spell,
+			target: rot.GetTargetUnit(castConfig.Target),
+		})
+	}
+	if len(casts) == 0 {
+		return nil
+	}
+
+	return &APLActionReplay{
+		unit:      rot.unit,
+		casts:     casts,
+		tolerance: tolerance,
+	}
+}
+func (action *APLActionReplay) Reset(sim *Simulation) {
+	action.nextCast = 0
+}
+func (action *APLActionReplay) IsReady(sim *Simulation) bool {
+	return action.nextCast < len(action.casts)
+}
+func (action *APLActionReplay) Execute(sim *Simulation) {
+	action.unit.Rotation.pushControllingAction(action)
+}
+
+func (action *APLActionReplay) GetNextAction(sim *Simulation) *APLAction {
+	for action.nextCast < len(action.casts) {
+		cast := action.casts[action.nextCast]
+		if cast.at > sim.CurrentTime {
+			action.unit.WaitUntil(sim, cast.at)
+			return nil
+		}
+
+		target := cast.target.Get()
+		if cast.spell.CanCast(sim, target) {
+			cast.spell.Cast(sim, target)
+			action.nextCast++
+			continue
+		}
+
+		deadline := cast.at + action.tolerance
+		if sim.CurrentTime >= deadline {
+			action.skipCast(sim, cast, target)
+			action.nextCast++
+			continue
+		}
+
+		action.unit.WaitUntil(sim, min(deadline, action.retryAt(sim, cast.spell)))
+		return nil
+	}
+
+	action.unit.Rotation.popControllingAction(action)
+	return action.unit.Rotation.getNextAction(sim)
+}
+
+// Estimates when the spell might become castable.
+func (action *APLActionReplay) retryAt(sim *Simulation, spell *Spell) time.Duration {
+	retryAt := sim.CurrentTime
+	if action.unit.Hardcast.Expires > retryAt {
+		retryAt = action.unit.Hardcast.Expires
+	}
+	if !action.unit.GCD.IsReady(sim) {
+		retryAt = max(retryAt, action.unit.NextGCDAt())
+	}
+	if !spell.IsReady(sim) {
+		retryAt = max(retryAt, spell.ReadyAt())
+	}
+	if retryAt <= sim.CurrentTime {
+		retryAt = sim.CurrentTime + replayRetryInterval
+	}
+	return retryAt
+}
+
+func (action *APLActionReplay) skipCast(sim *Simulation, cast aplReplayCast, target *Unit) {
+	reason := replayFailureReason(sim, cast.spell, target)
+	if sim.Log != nil {
+		action.unit.Log(sim, "Skipping replayed cast of %s from %s: %s", cast.spell.ActionID, cast.at, reason)
+	}
+	if sim.CombatLog != nil {
+		cast.spell.logCastFailed(sim, "replay: "+reason)
+	}
+}
+
+// Failure reasons of skipped replay casts, prefixed by "replay: " in the
+// combat log.
+const (
+	ReplayFailureCooldown  = "on cooldown"
+	ReplayFailureResources = "not enough resources"
+	ReplayFailureGCD       = "on GCD"
+	ReplayFailureCasting   = "busy casting"
+	ReplayFailureOther     = "not castable"
+)
+
+func replayFailureReason(sim *Simulation, spell *Spell, target *Unit) string {
+	switch {
+	case !spell.IsReady(sim) || (spell.MaxCharges > 0 && spell.GetNumCharges() == 0):
+		return ReplayFailureCooldown
+	case spell.Cost != nil && !spell.Cost.MeetsRequirement(sim, spell):
+		return ReplayFailureResources
+	case spell.Unit.Hardcast.Expires > sim.CurrentTime || spell.Unit.IsChanneling():
+		return ReplayFailureCasting
+	case spell.DefaultCast.GCD > 0 && !spell.Unit.GCD.IsReady(sim):
+		return ReplayFailureGCD
+	}
+	return ReplayFailureOther
+}
+
+func (action *APLActionReplay) String() string {
+	return fmt.Sprintf("Replay(%d casts)", len(action.casts))
+}
@@ -0,0 +1,184 @@
+package core
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/wowsims/mop/sim/core/proto"
+)
+
+// A rotation variable that can be changed by the Set Variable and Modify Variable
+// actions, unlike value variables which are re-evaluated each time they are used.
+// Durations are stored in seconds.
+type APLMutableVariable struct {
+	name  string
+	value float64
+
+	// Whether any action sets or modifies this variable.
+	assigned bool
+}
+
+func (rot *APLRotation) getMutableVariable(name string) *APLMutableVariable {
+	if variable, ok := rot.mutableVariables[name]; ok {
+		return variable
+	}
+	if rot.mutableVariables == nil {
+		rot.mutableVariables = make(map[string]*APLMutableVariable)
+	}
+	variable := &APLMutableVariable{name: name}
+	rot.mutableVariables[name] = variable
+	return variable
+}
+
+// Returns the value converted to a number, or nil if it can't be stored in a mutable variable.
+func (rot *APLRotation) newMutableVariableValue(config *proto.APLValue, actionName string) APLValue {
+	value := rot.newAPLValue(config)
+	if value == nil {
+		rot.ValidationMessage(proto.LogLevel_Warning, "%s must provide a value", actionName)
+		return nil
+	}
+	if value.Type() == proto.APLValueType_ValueTypeString {
+		rot.ValidationMessage(proto.LogLevel_Warning, "%s can only store numbers or durations", actionName)
+		return nil
+	}
+	if value.Type() == proto.APLValueType_ValueTypeFloat {
+		return value
+	}
+	// Not using coerceTo, because duration constants don't have a float value to copy.
+	return &APLValueCoerced{
+		valueType: proto.APLValueType_ValueTypeFloat,
+		inner:     value,
+	}
+}
+
+type APLActionSetVariable struct {
+	defaultAPLActionImpl
+	unit     *Unit
+	variable *APLMutableVariable
+	value    APLValue
+
+	lastExecutedAt time.Duration
+}
+
+func (rot *APLRotation) newActionSetVariable(config *proto.APLActionSetVariable) APLActionImpl {
+	if config.Name == "" {
+		rot.ValidationMessage(proto.LogLevel_Warning, "Set Variable must provide a variable name")
+		return nil
+	}
+	value := rot.newMutableVariableValue(config.Value, "Set Variable")
+	if value == nil {
+		return nil
+	}
+
+	variable := rot.getMutableVariable(config.Name)
+	variable.assigned = true
+	return &APLActionSetVariable{
+		unit:     rot.unit,
+		variable: variable,
+		value:    value,
+	}
+}
+func (action *APLActionSetVariable) GetAPLValues() []APLValue {
+	return []APLValue{action.value}
+}
+func (action *APLActionSetVariable) Reset(sim *Simulation) {
+	action.lastExecutedAt = NeverExpires
+}
+func (action *APLActionSetVariable) IsReady(sim *Simulation) bool {
+	// Prevent infinite loops by only allowing this action to be performed once at each timestamp.
+	return action.lastExecutedAt != sim.CurrentTime
+}
+func (action *APLActionSetVariable) Execute(sim *Simulation) {
+	action.lastExecutedAt = sim.CurrentTime
+	action.variable.value = action.value.GetFloat(sim)
+	if sim.Log != nil {
+		action.unit.Log(sim, "Setting variable '%s' to %0.3f", action.variable.name, action.variable.value)
+	}
+}
+func (action *APLActionSetVariable) String() string {
+	return fmt.Sprintf("Set Variable(name = '%s', value = %s)", action.variable.name, action.value)
+}
+
+type APLActionModifyVariable struct {
+	defaultAPLActionImpl
+	unit     *Unit
+	variable *APLMutableVariable
+	op       proto.APLActionModifyVariable_ModifyOperation
+	value    APLValue
+
+	lastExecutedAt time.Duration
+}
+
+func (rot *APLRotation) newActionModifyVariable(config *proto.APLActionModifyVariable) APLActionImpl {
+	if config.Name == "" {
+		rot.ValidationMessage(proto.LogLevel_Warning, "Modify Variable must provide a variable name")
+		return nil
+	}
+	if config.Op == proto.APLActionModifyVariable_OpUnknown {
+		rot.ValidationMessage(proto.LogLevel_Warning, "Modify Variable must provide an operation")
+		return nil
+	}
+
+	var value APLValue
+	if config.Op != proto.APLActionModifyVariable_OpReset {
+		if value = rot.newMutableVariableValue(config.Value, "Modify Variable"); value == nil {
+			return nil
+		}
+	}
+
+	variable := rot.getMutableVariable(config.Name)
+	variable.assigned = true
+	return &APLActionModifyVariable{
+		unit:     rot.unit,
+		variable: variable,
+		op:       config.Op,
+		value:    value,
+	}
+}
+func (action *APLActionModifyVariable) GetAPLValues() []APLValue {
+	if action.value == nil {
+		return nil
+	}
+	return []APLValue{action.value}
+}
+func (action *APLActionModifyVariable) Reset(sim *Simulation) {
+	action.lastExecutedAt = NeverExpires
+}
+func (action *APLActionModifyVariable) IsReady(sim *Simulation) bool {
+	// Prevent infinite loops by only allowing this action to be performed once at each timestamp.
+	return action.lastExecutedAt != sim.CurrentTime
+}
+func (action *APLActionModifyVariable) Execute(sim *Simulation) {
+	action.lastExecutedAt = sim.CurrentTime
+
+	variable := action.variable
+	switch action.op {
+	case proto.APLActionModifyVariable_OpAdd:
+		variable.value += action.value.GetFloat(sim)
+	case proto.APLActionModifyVariable_OpSub:
+		variable.value -= action.value.GetFloat(sim)
+	case proto.APLActionModifyVariable_OpMul:
+		variable.value *= action.value.GetFloat(sim)
+	case proto.APLActionModifyVariable_OpDiv:
+		divisor := action.value.GetFloat(sim)
+		if divisor == 0 {
+			if sim.Log != nil {
+				action.unit.Log(sim, "Not dividing variable '%s' by 0", variable.name)
+			}
+			return
+		}
+		variable.value /= divisor
+	case proto.APLActionModifyVariable_OpReset:
+		variable.value = 0
+	}
+
+	if sim.Log != nil {
+		action.unit.Log(sim, "Variable '%s' is now %0.3f", variable.name, variable.value)
+	}
+}
+func (action *APLActionModifyVariable) String() string {
+	if action.value == nil {
+		return fmt.Sprintf("Modify Variable(name = '%s', op = %s)", action.variable.name, action.op)
+	}
+	return fmt.Sprintf("Modify Variable(name = '%s', op = %s, value = %s)", action.variable.name, action.op, action.value)
+}
@@ -0,0 +1,94 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/wowsims/mop/sim/core/proto"
+)
+
+func newVariablesTestRotation() *APLRotation {
+	target := &Target{}
+	target.Env = &Environment{
+		Raid:      &Raid{},
+		Encounter: Encounter{AllTargets: []*Target{target}},
+	}
+	return &APLRotation{
+		unit:            &target.Unit,
+		uuidValidations: make(map[*proto.UUID][]*proto.APLValidation),
+	}
+}
+
+func constAPLValue(val string) *proto.APLValue {
+	return &proto.APLValue{Value: &proto.APLValue_Const{Const: &proto.APLValueConst{Val: val}}}
+}
+
+func TestMutableVariables(t *testing.T) {
+	sim := &Simulation{}
+	rot := newVariablesTestRotation()
+
+	value := rot.newValueVariableValue(&proto.APLValueVariableValue{Name: "counter"}, nil)
+	set := rot.newActionSetVariable(&proto.APLActionSetVariable{Name: "counter", Value: constAPLValue("2s")})
+	add := rot.newActionModifyVariable(&proto.APLActionModifyVariable{Name: "counter", Op: proto.APLActionModifyVariable_OpAdd, Value: constAPLValue("1")})
+	div := rot.newActionModifyVariable(&proto.APLActionModifyVariable{Name: "counter", Op: proto.APLActionModifyVariable_OpDiv, Value: constAPLValue("0")})
+	reset := rot.newActionModifyVariable(&proto.APLActionModifyVariable{Name: "counter", Op: proto.APLActionModifyVariable_OpReset})
+	if len(rot.curValidations) > 0 {
+		t.Fatalf("Unexpected validation messages: %v", rot.curValidations)
+	}
+	for _, action := range []APLActionImpl{set, add, div, reset} {
+		action.Reset(sim)
+	}
+
+	expect := func(expected float64) {
+		t.Helper()
+		if actual := value.GetFloat(sim); actual != expected {
+			t.Fatalf("Expected variable value %f but got %f", expected, actual)
+		}
+	}
+
+	expect(0)
+	set.Execute(sim)
+	expect(2)
+	if add.Execute(sim); add.IsReady(sim) {
+		t.Fatalf("Modify Variable should only run once per timestamp")
+	}
+	expect(3)
+	sim.CurrentTime = time.Second
+	if !add.IsReady(sim) {
+		t.Fatalf("Modify Variable should be ready at a new timestamp")
+	}
+	add.Execute(sim)
+	div.Execute(sim)
+	expect(4)
+	reset.Execute(sim)
+	expect(0)
+
+	set.Execute(sim)
+	rot.reset(sim)
+	expect(0)
+}
+
+func TestMutableVariablesValidation(t *testing.T) {
+	rot := newVariablesTestRotation()
+
+	if rot.newActionSetVariable(&proto.APLActionSetVariable{Value: constAPLValue("1")}) != nil {
+		t.Errorf("Expected Set Variable without a name to be invalid")
+	}
+	if rot.newActionSetVariable(&proto.APLActionSetVariable{Name: "x", Value: constAPLValue("\"text\"")}) != nil {
+		t.Errorf("Expected Set Variable with a string value to be invalid")
+	}
+	if rot.newActionModifyVariable(&proto.APLActionModifyVariable{Name: "x", Value: constAPLValue("1")}) != nil {
+		t.Errorf("Expected Modify Variable without an operation to be invalid")
+	}
+	if rot.newActionModifyVariable(&proto.APLActionModifyVariable{Name: "x", Op: proto.APLActionModifyVariable_OpMul}) != nil {
+		t.Errorf("Expected Modify Variable without a value to be invalid")
+	}
+
+	uuid := &proto.UUID{Value: "unset"}
+	value := rot.newValueVariableValue(&proto.APLValueVariableValue{Name: "unset"}, uuid)
+	value.(*APLValueVariableValue).Uuid = uuid
+	value.Finalize(rot)
+	if len(rot.uuidValidations[uuid]) != 1 {
+		t.Errorf("Expected a warning for a variable that is never set")
+	}
+}
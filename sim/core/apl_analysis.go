@@ -0,0 +1,165 @@
+package core
+
+import (
+	"slices"
+
+	"github.com/wowsims/mop/sim/core/proto"
+)
+
+// Looks for mistakes across the whole rotation that can't be found while parsing
+// individual actions and values. Must be called after all actions are finalized.
+func (rot *APLRotation) analyze(numGroups int) {
+	for i, action := range rot.prepullActions {
+		rot.doAndRecordWarnings(&rot.prepullValidations[rot.prepullIdxMap[i]], true, func() {
+			rot.checkConstantConditions(action)
+		})
+	}
+
+	rot.analyzeActionList(rot.priorityList, func(i int) *[]*proto.APLValidation {
+		return &rot.priorityListValidations[rot.priorityListIdxMap[i]]
+	})
+
+	// Groups referenced more than once are parsed again for each reference, so only
+	// the original groups need to be checked.
+	for groupIdx, group := range rot.groups[:numGroups] {
+		rot.analyzeActionList(group.actions, func(i int) *[]*proto.APLValidation {
+			return &rot.groupListValidations[groupIdx][rot.groupListIdxMap[groupIdx][i]]
+		})
+	}
+
+	rot.checkUnusedGroups(numGroups)
+}
+
+func (rot *APLRotation) analyzeActionList(actions []*APLAction, validations func(i int) *[]*proto.APLValidation) {
+	// Unconditional casts seen so far. Once one of these is reached, the spell is
+	// cast whenever it is ready, so later casts of it can never happen.
+	var alwaysCast []*APLActionCastSpell
+
+	for i, action := range actions {
+		rot.doAndRecordWarnings(validations(i), false, func() {
+			rot.checkConstantConditions(action)
+
+			castSpell, ok := action.impl.(*APLActionCastSpell)
+			if !ok {
+				return
+			}
+			if slices.ContainsFunc(alwaysCast, func(earlier *APLActionCastSpell) bool {
+				return earlier.spell == castSpell.spell && earlier.target == castSpell.target
+			}) {
+				rot.ValidationMessage(proto.LogLevel_Warning, "Unreachable: an earlier action always casts %s when it is ready", castSpell.spell.ActionID)
+				return
+			}
+			if isAlwaysTrue(action.condition) {
+				alwaysCast = append(alwaysCast, castSpell)
+			}
+		})
+	}
+}
+
+// Warns about conditions of the action and its inner actions that never change during the sim,
+// e.g. because they only depend on which spells and auras the character has.
+func (rot *APLRotation) checkConstantConditions(action *APLAction) {
+	for _, a := range action.GetAllActions() {
+		if a.condition == nil {
+			continue
+		}
+		if result, ok := constantBoolValue(a.condition); ok {
+			if result {
+				rot.ValidationMessage(proto.LogLevel_Warning, "Condition is always true for this character and can be removed")
+			} else {
+				rot.ValidationMessage(proto.LogLevel_Warning, "Condition is always false for this character, so this action is never used")
+			}
+		}
+	}
+}
+
+func (rot *APLRotation) checkUnusedGroups(numGroups int) {
+	referenced := make(map[string]bool)
+	addReferences := func(actions []*APLAction) {
+		for _, action := range actions {
+			for _, a := range action.GetAllActions() {
+				if groupReference, ok := a.impl.(*APLActionGroupReference); ok {
+					referenced[groupReference.groupName] = true
+				}
+			}
+		}
+	}
+
+	// Groups only count as used if they can be reached from the prepull or priority list.
+	addReferences(rot.prepullActions)
+	addReferences(rot.priorityList)
+	for numReferenced := -1; numReferenced != len(referenced); {
+		numReferenced = len(referenced)
+		for _, group := range rot.groups {
+			if referenced[group.name] {
+				addReferences(group.actions)
+			}
+		}
+	}
+
+	for groupIdx, group := range rot.groups[:numGroups] {
+		if !referenced[group.name] {
+			rot.groupValidations[groupIdx] = append(rot.groupValidations[groupIdx], &proto.APLValidation{
+				LogLevel:   proto.LogLevel_Warning,
+				Validation: "Group is never referenced by the priority list",
+			})
+		}
+	}
+}
+
+func isAlwaysTrue(condition APLValue) bool {
+	if condition == nil {
+		return true
+	}
+	result, ok := constantBoolValue(condition)
+	return ok && result
+}
+
+// Returns the value of a boolean that is the same for the whole sim, and whether it is.
+func constantBoolValue(value APLValue) (bool, bool) {
+	switch v := value.(type) {
+	case *APLValueAnd:
+		allConstant := true
+		for _, val := range v.vals {
+			result, ok := constantBoolValue(val)
+			if ok && !result {
+				return false, true
+			}
+			allConstant = allConstant && ok
+		}
+		return true, allConstant
+	case *APLValueOr:
+		allConstant := true
+		for _, val := range v.vals {
+			result, ok := constantBoolValue(val)
+			if ok && result {
+				return true, true
+			}
+			allConstant = allConstant && ok
+		}
+		return false, allConstant
+	case *APLValueNot:
+		result, ok := constantBoolValue(v.val)
+		return !result, ok
+	}
+
+	if !isConstantValue(value) {
+		return false, false
+	}
+	// Constant values don't depend on the sim state.
+	return value.GetBool(nil), true
+}
+
+func isConstantValue(value APLValue) bool {
+	switch v := value.(type) {
+	case *APLValueConst, *APLValueSpellIsKnown:
+		return true
+	case *APLValueAuraIsKnown:
+		// Auras on the current target can change when switching targets.
+		return v.aura.fixedAura != nil || v.aura.targetRef.targetLookupSource == nil
+	case *APLValueCoerced, *APLValueAnd, *APLValueOr, *APLValueNot, *APLValueCompare, *APLValueMath, *APLValueVariableRef:
+		inner := value.GetInnerValues()
+		return len(inner) > 0 && !slices.ContainsFunc(inner, func(val APLValue) bool { return val == nil || !isConstantValue(val) })
+	}
+	return false
+}
@@ -0,0 +1,170 @@
+package core
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/wowsims/mop/sim/core/proto"
+)
+
+var fakeSpellID = &proto.ActionID{RawId: &proto.ActionID_SpellId{SpellId: 42}}
+
+func castFakeSpell(condition *proto.APLValue) *proto.APLListItem {
+	return &proto.APLListItem{Action: &proto.APLAction{
+		Condition: condition,
+		Action:    &proto.APLAction_CastSpell{CastSpell: &proto.APLActionCastSpell{SpellId: fakeSpellID}},
+	}}
+}
+
+func referenceGroup(name string) *proto.APLListItem {
+	return &proto.APLListItem{Action: &proto.APLAction{
+		Action: &proto.APLAction_GroupReference{GroupReference: &proto.APLActionGroupReference{GroupName: name}},
+	}}
+}
+
+func cmpAPLValue(uuid string, op proto.APLValueCompare_ComparisonOperator, lhs *proto.APLValue, rhs *proto.APLValue) *proto.APLValue {
+	return &proto.APLValue{
+		Uuid:  &proto.UUID{Value: uuid},
+		Value: &proto.APLValue_Cmp{Cmp: &proto.APLValueCompare{Op: op, Lhs: lhs, Rhs: rhs}},
+	}
+}
+
+var currentTimeValue = &proto.APLValue{Value: &proto.APLValue_CurrentTime{CurrentTime: &proto.APLValueCurrentTime{}}}
+
+func spellIsKnownValue(spellID int32) *proto.APLValue {
+	return &proto.APLValue{Value: &proto.APLValue_SpellIsKnown{SpellIsKnown: &proto.APLValueSpellIsKnown{
+		SpellId: &proto.ActionID{RawId: &proto.ActionID_SpellId{SpellId: spellID}},
+	}}}
+}
+
+func analyzeFakeRotation(t *testing.T, config *proto.APLRotation) *proto.APLStats {
+	t.Helper()
+	sim := SetupFakeSim()
+	fa := sim.Raid.Parties[0].Players[0].(*FakeAgent)
+	return fa.newAPLRotation(config).getStats()
+}
+
+func hasValidation(validations []*proto.APLValidation, substr string) bool {
+	for _, validation := range validations {
+		if strings.Contains(validation.Validation, substr) {
+			return true
+		}
+	}
+	return false
+}
+
+func uuidValidations(stats *proto.APLStats, uuid string) []*proto.APLValidation {
+	for _, uuidValidations := range stats.UuidValidations {
+		if uuidValidations.Uuid.Value == uuid {
+			return uuidValidations.Validations
+		}
+	}
+	return nil
+}
+
+func TestAnalyzeUnreachableCasts(t *testing.T) {
+	stats := analyzeFakeRotation(t, &proto.APLRotation{
+		PriorityList: []*proto.APLListItem{
+			castFakeSpell(cmpAPLValue("", proto.APLValueCompare_OpGt, currentTimeValue, constAPLValue("1s"))),
+			castFakeSpell(nil),
+			castFakeSpell(cmpAPLValue("", proto.APLValueCompare_OpGt, currentTimeValue, constAPLValue("2s"))),
+		},
+	})
+
+	for i, expected := range []bool{false, false, true} {
+		if actual := hasValidation(stats.PriorityList[i].Validations, "Unreachable"); actual != expected {
+			t.Errorf("Expected unreachable warning for action %d to be %t but got %t", i, expected, actual)
+		}
+	}
+}
+
+func TestAnalyzeConstantConditions(t *testing.T) {
+	stats := analyzeFakeRotation(t, &proto.APLRotation{
+		PriorityList: []*proto.APLListItem{
+			castFakeSpell(&proto.APLValue{Value: &proto.APLValue_Not{Not: &proto.APLValueNot{Val: spellIsKnownValue(42)}}}),
+			castFakeSpell(&proto.APLValue{Value: &proto.APLValue_And{And: &proto.APLValueAnd{Vals: []*proto.APLValue{
+				spellIsKnownValue(42),
+				cmpAPLValue("", proto.APLValueCompare_OpGt, currentTimeValue, constAPLValue("1s")),
+			}}}}),
+			castFakeSpell(&proto.APLValue{Value: &proto.APLValue_Or{Or: &proto.APLValueOr{Vals: []*proto.APLValue{
+				spellIsKnownValue(42),
+				cmpAPLValue("", proto.APLValueCompare_OpGt, currentTimeValue, constAPLValue("1s")),
+			}}}}),
+		},
+	})
+
+	if !hasValidation(stats.PriorityList[0].Validations, "always false") {
+		t.Errorf("Expected an always false warning, got %v", stats.PriorityList[0].Validations)
+	}
+	if hasValidation(stats.PriorityList[1].Validations, "always") {
+		t.Errorf("Expected no constant condition warning, got %v", stats.PriorityList[1].Validations)
+	}
+	if !hasValidation(stats.PriorityList[2].Validations, "always true") {
+		t.Errorf("Expected an always true warning, got %v", stats.PriorityList[2].Validations)
+	}
+}
+
+func TestAnalyzeComparisonTypes(t *testing.T) {
+	stats := analyzeFakeRotation(t, &proto.APLRotation{
+		PriorityList: []*proto.APLListItem{
+			castFakeSpell(&proto.APLValue{Value: &proto.APLValue_Or{Or: &proto.APLValueOr{Vals: []*proto.APLValue{
+				cmpAPLValue("number", proto.APLValueCompare_OpGt, currentTimeValue, constAPLValue("5")),
+				cmpAPLValue("zero", proto.APLValueCompare_OpGt, currentTimeValue, constAPLValue("0")),
+				cmpAPLValue("duration", proto.APLValueCompare_OpGt, currentTimeValue, constAPLValue("5s")),
+			}}}}),
+		},
+	})
+
+	if !hasValidation(uuidValidations(stats, "number"), "Comparing a duration to a number") {
+		t.Errorf("Expected a type mismatch warning when comparing a duration to a number")
+	}
+	for _, uuid := range []string{"zero", "duration"} {
+		if validations := uuidValidations(stats, uuid); len(validations) > 0 {
+			t.Errorf("Expected no warnings for %s comparison, got %v", uuid, validations)
+		}
+	}
+}
+
+func TestAnalyzeVariableCycles(t *testing.T) {
+	variableRef := func(uuid string, name string) *proto.APLValue {
+		return &proto.APLValue{
+			Uuid:  &proto.UUID{Value: uuid},
+			Value: &proto.APLValue_VariableRef{VariableRef: &proto.APLValueVariableRef{Name: name}},
+		}
+	}
+	stats := analyzeFakeRotation(t, &proto.APLRotation{
+		ValueVariables: []*proto.APLValueVariable{
+			{Name: "a", Value: variableRef("a_to_b", "b")},
+			{Name: "b", Value: variableRef("b_to_a", "a")},
+		},
+		PriorityList: []*proto.APLListItem{
+			castFakeSpell(variableRef("root", "a")),
+			castFakeSpell(variableRef("missing", "c")),
+		},
+	})
+
+	if !hasValidation(uuidValidations(stats, "b_to_a"), "a -> b -> a") {
+		t.Errorf("Expected a cycle warning, got %v", stats.UuidValidations)
+	}
+	if !hasValidation(uuidValidations(stats, "missing"), "not found") {
+		t.Errorf("Expected a missing variable warning, got %v", stats.UuidValidations)
+	}
+}
+
+func TestAnalyzeUnusedGroups(t *testing.T) {
+	stats := analyzeFakeRotation(t, &proto.APLRotation{
+		PriorityList: []*proto.APLListItem{referenceGroup("used")},
+		Groups: []*proto.APLGroup{
+			{Name: "used", Actions: []*proto.APLListItem{referenceGroup("nested")}},
+			{Name: "nested", Actions: []*proto.APLListItem{castFakeSpell(nil)}},
+			{Name: "unused", Actions: []*proto.APLListItem{referenceGroup("unused_nested")}},
+			{Name: "unused_nested", Actions: []*proto.APLListItem{castFakeSpell(nil)}},
+		},
+	})
+
+	for i, expected := range []bool{false, false, true, true} {
+		if actual := hasValidation(stats.Groups[i].Validations, "never referenced"); actual != expected {
+			t.Errorf("Expected unused warning for group %d to be %t but got %t", i, expected, actual)
+		}
+	}
+}
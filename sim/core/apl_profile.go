@@ -0,0 +1,171 @@
+package core
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/wowsims/mop/sim/core/proto"
+)
+
+// Counts how often an action in the priority list or a group was used, for SimOptions.AplTrace.
+type APLActionProfile struct {
+	rot      *APLRotation
+	location string
+	uuid     *proto.UUID
+
+	// Totals over all iterations.
+	evaluations   int64
+	conditionTrue int64
+	executions    int64
+}
+
+// Gives every action in the priority list and groups a profile. Groups referenced more
+// than once are duplicated, so their copies share the profile of the original action.
+func (rot *APLRotation) setupProfiles(config *proto.APLRotation, groupsConfig []*proto.APLGroup) {
+	profiles := make(map[string]*APLActionProfile)
+	getProfile := func(location string, actionConfig *proto.APLAction) *APLActionProfile {
+		if profile, ok := profiles[location]; ok {
+			return profile
+		}
+		profile := &APLActionProfile{
+			rot:      rot,
+			location: location,
+			uuid:     actionConfig.GetCondition().GetUuid(),
+		}
+		profiles[location] = profile
+		rot.actionProfiles = append(rot.actionProfiles, profile)
+		return profile
+	}
+
+	for i, action := range rot.priorityList {
+		idx := rot.priorityListIdxMap[i]
+		action.profile = getProfile(fmt.Sprintf("priorityList[%d]", idx), config.PriorityList[idx].Action)
+	}
+
+	for groupIdx, group := range rot.groups {
+		configIdx := slices.Index(config.Groups, groupsConfig[groupIdx])
+		for i, action := range group.actions {
+			idx := rot.groupListIdxMap[groupIdx][i]
+			action.profile = getProfile(fmt.Sprintf("groups[%d].actions[%d]", configIdx, idx), groupsConfig[groupIdx].Actions[idx].Action)
+		}
+	}
+}
+
+// Same as APLAction.IsReady, but also counts the evaluation and records it in the current decision.
+func (profile *APLActionProfile) isReady(sim *Simulation, action *APLAction) bool {
+	profile.evaluations++
+
+	var step *proto.APLDecisionStep
+	if decision := profile.rot.decision; decision != nil {
+		step = &proto.APLDecisionStep{
+			Location: profile.location,
+			Uuid:     profile.uuid,
+			Action:   action.impl.String(),
+		}
+		// Appended before checking the action, so that group actions come after their group reference.
+		decision.Steps = append(decision.Steps, step)
+	}
+
+	conditionTrue := action.condition == nil || action.condition.GetBool(sim)
+	if !conditionTrue {
+		if step != nil {
+			for _, value := range failedAPLValues(sim, action.condition) {
+				step.FailedValues = append(step.FailedValues, &proto.APLFailedValue{
+					Uuid:  aplValueUUID(value),
+					Value: value.String(),
+				})
+			}
+		}
+		return false
+	}
+
+	profile.conditionTrue++
+	ready := action.impl.IsReady(sim)
+	if step != nil {
+		step.ConditionTrue = true
+		step.Ready = ready
+	}
+	return ready
+}
+
+func (profile *APLActionProfile) execute() {
+	profile.executions++
+
+	if decision := profile.rot.decision; decision != nil {
+		for i := len(decision.Steps) - 1; i >= 0; i-- {
+			if decision.Steps[i].Location == profile.location {
+				decision.Steps[i].Executed = true
+				break
+			}
+		}
+	}
+}
+
+// Wraps getNextAction to profile the actions it checks when SimOptions.AplTrace is enabled,
+// and to trace the decision in logged iterations.
+func (apl *APLRotation) profileNextAction(sim *Simulation) *APLAction {
+	if !sim.Options.AplTrace {
+		return apl.getNextAction(sim)
+	}
+
+	apl.profiled = true
+	apl.decision = nil
+	if sim.Log != nil {
+		apl.decision = &proto.APLDecision{
+			Timestamp: sim.CurrentTime.Seconds(),
+			Unit:      apl.unit.combatLogReference(),
+		}
+		sim.aplTrace = append(sim.aplTrace, apl.decision)
+	}
+
+	apl.profiling = true
+	nextAction := apl.getNextAction(sim)
+	apl.profiling = false
+
+	if apl.decision != nil && nextAction != nil {
+		apl.decision.Action = nextAction.impl.String()
+	}
+	return nextAction
+}
+
+func (rot *APLRotation) getProfileProto(numIterations float64) []*proto.APLActionProfile {
+	if !rot.profiled {
+		return nil
+	}
+
+	return MapSlice(rot.actionProfiles, func(profile *APLActionProfile) *proto.APLActionProfile {
+		return &proto.APLActionProfile{
+			Location:         profile.location,
+			Uuid:             profile.uuid,
+			EvaluationsAvg:   float64(profile.evaluations) / numIterations,
+			ConditionTrueAvg: float64(profile.conditionTrue) / numIterations,
+			ExecutionsAvg:    float64(profile.executions) / numIterations,
+		}
+	})
+}
+
+// Returns the parts of a false condition that made it false.
+func failedAPLValues(sim *Simulation, value APLValue) []APLValue {
+	switch v := value.(type) {
+	case *APLValueAnd:
+		for _, val := range v.vals {
+			if !val.GetBool(sim) {
+				return failedAPLValues(sim, val)
+			}
+		}
+	case *APLValueOr:
+		var failed []APLValue
+		for _, val := range v.vals {
+			failed = append(failed, failedAPLValues(sim, val)...)
+		}
+		return failed
+	}
+	return []APLValue{value}
+}
+
+func aplValueUUID(value APLValue) *proto.UUID {
+	if withUUID, ok := value.(interface{ GetUUID() *proto.UUID }); ok {
+		return withUUID.GetUUID()
+	}
+	return nil
+}
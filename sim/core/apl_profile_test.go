@@ -0,0 +1,95 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/wowsims/mop/sim/core/proto"
+)
+
+func TestAPLTrace(t *testing.T) {
+	sim := SetupFakeSim()
+	sim.CurrentTime = 0
+	sim.Options.AplTrace = true
+	sim.Log = func(string, ...interface{}) {}
+	fa := sim.Raid.Parties[0].Players[0].(*FakeAgent)
+
+	addToCounter := func(condition *proto.APLValue) *proto.APLListItem {
+		return &proto.APLListItem{Action: &proto.APLAction{
+			Condition: condition,
+			Action: &proto.APLAction_ModifyVariable{ModifyVariable: &proto.APLActionModifyVariable{
+				Name: "counter", Op: proto.APLActionModifyVariable_OpAdd, Value: constAPLValue("1"),
+			}},
+		}}
+	}
+	rot := fa.newAPLRotation(&proto.APLRotation{
+		PriorityList: []*proto.APLListItem{
+			addToCounter(&proto.APLValue{
+				Uuid: &proto.UUID{Value: "first"},
+				Value: &proto.APLValue_And{And: &proto.APLValueAnd{Vals: []*proto.APLValue{
+					spellIsKnownValue(42),
+					cmpAPLValue("later", proto.APLValueCompare_OpGt, currentTimeValue, constAPLValue("1s")),
+				}}},
+			}),
+			{Action: &proto.APLAction{Action: &proto.APLAction_SetVariable{SetVariable: &proto.APLActionSetVariable{
+				Name: "counter", Value: constAPLValue("1"),
+			}}}},
+			referenceGroup("group"),
+		},
+		Groups: []*proto.APLGroup{
+			{Name: "group", Actions: []*proto.APLListItem{addToCounter(nil)}},
+		},
+	})
+	rot.reset(sim)
+
+	numExecuted := 0
+	for nextAction := rot.profileNextAction(sim); nextAction != nil; nextAction = rot.profileNextAction(sim) {
+		nextAction.Execute(sim)
+		numExecuted++
+	}
+	if numExecuted != 2 {
+		t.Fatalf("Expected 2 actions to be executed, got %d", numExecuted)
+	}
+
+	if len(sim.aplTrace) != 3 {
+		t.Fatalf("Expected 3 traced decisions, got %d", len(sim.aplTrace))
+	}
+	first := sim.aplTrace[0]
+	if len(first.Steps) != 2 || first.Steps[0].ConditionTrue || !first.Steps[1].Executed {
+		t.Errorf("Unexpected steps in first decision: %v", first.Steps)
+	}
+	if failed := first.Steps[0].FailedValues; len(failed) != 1 || failed[0].Uuid.GetValue() != "later" {
+		t.Errorf("Expected only the time comparison to fail, got %v", failed)
+	}
+	var executed []string
+	for _, step := range sim.aplTrace[1].Steps {
+		if step.Executed {
+			executed = append(executed, step.Location)
+		}
+	}
+	if len(executed) != 2 || executed[0] != "priorityList[2]" || executed[1] != "groups[0].actions[0]" {
+		t.Errorf("Expected the group and its action to be executed, got %v", executed)
+	}
+	if sim.aplTrace[2].Action != "" {
+		t.Errorf("Expected no action in the last decision, got %s", sim.aplTrace[2].Action)
+	}
+
+	expected := []*proto.APLActionProfile{
+		{Location: "priorityList[0]", EvaluationsAvg: 3, ConditionTrueAvg: 0, ExecutionsAvg: 0},
+		{Location: "priorityList[1]", EvaluationsAvg: 3, ConditionTrueAvg: 3, ExecutionsAvg: 1},
+		{Location: "priorityList[2]", EvaluationsAvg: 2, ConditionTrueAvg: 2, ExecutionsAvg: 1},
+		{Location: "groups[0].actions[0]", EvaluationsAvg: 2, ConditionTrueAvg: 2, ExecutionsAvg: 1},
+	}
+	profiles := rot.getProfileProto(1)
+	if len(profiles) != len(expected) {
+		t.Fatalf("Expected %d action profiles, got %d", len(expected), len(profiles))
+	}
+	for i, profile := range profiles {
+		if profile.Location != expected[i].Location || profile.EvaluationsAvg != expected[i].EvaluationsAvg ||
+			profile.ConditionTrueAvg != expected[i].ConditionTrueAvg || profile.ExecutionsAvg != expected[i].ExecutionsAvg {
+			t.Errorf("Expected profile %v but got %v", expected[i], profile)
+		}
+	}
+	if profiles[0].Uuid.GetValue() != "first" {
+		t.Errorf("Expected profile to be keyed by the condition UUID, got %v", profiles[0].Uuid)
+	}
+}
@@ -0,0 +1,372 @@
+package core
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"runtime/debug"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/wowsims/mop/sim/core/proto"
+	"github.com/wowsims/mop/sim/core/simsignals"
+	googleProto "google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const (
+	DefaultAPLTuningCandidates = 16
+	DefaultAPLTuningIterations = 200
+)
+
+// A constant in the tuned rotation, with the range of values to try.
+type aplTunable struct {
+	location string
+	uuid     *proto.UUID
+	config   *proto.APLValueConst // Points into the request being simmed.
+	original string
+	valRange *proto.APLTunableRange
+}
+
+// Finds the constants with a tunable range in a rotation, in the order they appear.
+func findAPLTunables(msg protoreflect.Message, path string, tunables []*aplTunable) ([]*aplTunable, error) {
+	if value, ok := msg.Interface().(*proto.APLValue); ok && value.GetConst().GetTunable() != nil {
+		config := value.GetConst()
+		valRange := config.Tunable
+		if valRange.Min >= valRange.Max || valRange.Step < 0 {
+			return nil, fmt.Errorf("invalid tunable range for %s: min must be less than max and step must not be negative", path)
+		}
+		if _, err := parseTunableConst(config.Val); err != nil {
+			return nil, fmt.Errorf("constant %s is not a number, duration or percentage: %q", path, config.Val)
+		}
+		tunables = append(tunables, &aplTunable{
+			location: path + ".const",
+			uuid:     value.Uuid,
+			config:   config,
+			original: config.Val,
+			valRange: valRange,
+		})
+	}
+
+	var err error
+	msg.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		if fd.Kind() != protoreflect.MessageKind || fd.IsMap() {
+			return true
+		}
+		fieldPath := fd.JSONName()
+		if path != "" {
+			fieldPath = path + "." + fieldPath
+		}
+		if fd.IsList() {
+			list := v.List()
+			for i := 0; i < list.Len() && err == nil; i++ {
+				tunables, err = findAPLTunables(list.Get(i).Message(), fmt.Sprintf("%s[%d]", fieldPath, i), tunables)
+			}
+		} else {
+			tunables, err = findAPLTunables(v.Message(), fieldPath, tunables)
+		}
+		return err == nil
+	})
+	return tunables, err
+}
+
+// Returns the value of a constant in the units of its tunable range.
+func parseTunableConst(val string) (float64, error) {
+	if percent, ok := strings.CutSuffix(val, "%"); ok {
+		return strconv.ParseFloat(percent, 64)
+	}
+	if floatVal, err := strconv.ParseFloat(val, 64); err == nil {
+		return floatVal, nil
+	}
+	durVal, err := time.ParseDuration(val)
+	return durVal.Seconds(), err
+}
+
+// Formats a value in the same way as the original constant.
+func formatTunableConst(original string, value float64) string {
+	formatted := strconv.FormatFloat(value, 'f', -1, 64)
+	if strings.HasSuffix(original, "%") {
+		return formatted + "%"
+	}
+	if _, err := strconv.ParseFloat(original, 64); err != nil {
+		return formatted + "s"
+	}
+	return formatted
+}
+
+func (tunable *aplTunable) round(value float64) float64 {
+	valRange := tunable.valRange
+	if valRange.Step > 0 {
+		value = valRange.Min + math.Round((value-valRange.Min)/valRange.Step)*valRange.Step
+		if value > valRange.Max {
+			value -= valRange.Step
+		}
+	}
+	// Keeps the constants readable.
+	return math.Round(value*1000) / 1000
+}
+
+// One combination of values for the tunables.
+type aplTuningCandidate struct {
+	values []float64
+
+	// Per-iteration results of each round the candidate was simmed in.
+	samples [][]float64
+	mean    float64
+}
+
+func (candidate *aplTuningCandidate) key() string {
+	return fmt.Sprint(candidate.values)
+}
+
+// Picks the original values, followed by a latin hypercube sample of the ranges so
+// every part of each range is tried. Duplicates from rounding to steps are dropped.
+func newAPLTuningCandidates(tunables []*aplTunable, numCandidates int, rng *rand.Rand) []*aplTuningCandidate {
+	original := &aplTuningCandidate{values: make([]float64, len(tunables))}
+	for i, tunable := range tunables {
+		original.values[i], _ = parseTunableConst(tunable.original)
+	}
+	candidates := []*aplTuningCandidate{original}
+	seen := map[string]bool{original.key(): true}
+
+	numSamples := numCandidates - 1
+	if numSamples <= 0 {
+		return candidates
+	}
+	strata := make([][]int, len(tunables))
+	for i := range tunables {
+		strata[i] = rng.Perm(numSamples)
+	}
+	for sampleIdx := 0; sampleIdx < numSamples; sampleIdx++ {
+		candidate := &aplTuningCandidate{values: make([]float64, len(tunables))}
+		for i, tunable := range tunables {
+			fraction := (float64(strata[i][sampleIdx]) + rng.Float64()) / float64(numSamples)
+			candidate.values[i] = tunable.round(tunable.valRange.Min + fraction*(tunable.valRange.Max-tunable.valRange.Min))
+		}
+		if !seen[candidate.key()] {
+			seen[candidate.key()] = true
+			candidates = append(candidates, candidate)
+		}
+	}
+	return candidates
+}
+
+// Mean and standard error of the per-iteration differences between two candidates simmed
+// with the same random numbers.
+func pairedDifference(x []float64, y []float64) (float64, float64) {
+	var diff aggregator
+	for i := range x {
+		diff.add(x[i] - y[i])
+	}
+	if diff.n < 2 {
+		return 0, 0
+	}
+	mean, stdev := diff.meanAndStdDev()
+	return mean, stdev / math.Sqrt(float64(diff.n-1))
+}
+
+// Number of sims run by successive halving, not counting the final comparison.
+func aplTuningRoundSims(numCandidates int) int {
+	numSims := 0
+	for n := numCandidates; n > 1; n = (n + 1) / 2 {
+		numSims += n
+	}
+	return numSims
+}
+
+func runTuneAPL(request *proto.APLTuningRequest, progress chan *proto.ProgressMetrics, signals simsignals.Signals) (result *proto.APLTuningResult) {
+	errorResult := func(message string) *proto.APLTuningResult {
+		return &proto.APLTuningResult{Error: &proto.ErrorOutcome{Message: message}}
+	}
+
+	defer func() {
+		if err := recover(); err != nil {
+			errStr := ""
+			switch errt := err.(type) {
+			case string:
+				errStr = errt
+			case error:
+				errStr = errt.Error()
+			}
+
+			errStr += "\nStack Trace:\n" + string(debug.Stack())
+			result = errorResult(errStr)
+		}
+	}()
+
+	baseRequest := request.BaseSettings
+	if baseRequest == nil || baseRequest.SimOptions == nil {
+		return errorResult("APL tuning requires base settings with sim options!")
+	}
+	if baseRequest.Raid == nil || len(baseRequest.Raid.Parties) == 0 || len(baseRequest.Raid.Parties[0].Players) == 0 {
+		return errorResult("APL tuning requires a player in the first party!")
+	}
+
+	simRequest := googleProto.Clone(baseRequest).(*proto.RaidSimRequest)
+	player := simRequest.Raid.Parties[0].Players[0]
+	if player.Rotation == nil {
+		return errorResult("APL tuning requires a player with an APL rotation!")
+	}
+	tunables, err := findAPLTunables(player.Rotation.ProtoReflect(), "", nil)
+	if err != nil {
+		return errorResult(err.Error())
+	}
+	if len(tunables) == 0 {
+		return errorResult("No constants in the rotation have a tunable range!")
+	}
+
+	numCandidates := int(request.NumCandidates)
+	if numCandidates <= 0 {
+		numCandidates = DefaultAPLTuningCandidates
+	}
+	iterations := request.InitialIterations
+	if iterations <= 0 {
+		iterations = DefaultAPLTuningIterations
+	}
+
+	// Every candidate in a round shares its random numbers, so differences between
+	// them come from the constants rather than RNG.
+	if simRequest.SimOptions.RandomSeed == 0 {
+		simRequest.SimOptions.RandomSeed = time.Now().UnixNano()
+	}
+	rng := rand.New(rand.NewSource(simRequest.SimOptions.RandomSeed))
+	finalIterations := simRequest.SimOptions.Iterations
+	simRequest.SimOptions.CommonRandomNumbers = true
+	simRequest.SimOptions.SaveAllValues = true
+	simRequest.SimOptions.TargetPrecision = 0
+	simRequest.SimOptions.Debug = false
+	simRequest.SimOptions.DebugFirstIteration = false
+
+	candidates := newAPLTuningCandidates(tunables, numCandidates, rng)
+
+	var simsTotal int32 = int32(aplTuningRoundSims(len(candidates))) + 2
+	var simsCompleted int32 = 0
+	var iterationsTotal int32 = finalIterations * 2
+	for n, roundIterations := len(candidates), iterations; n > 1; n, roundIterations = (n+1)/2, roundIterations*2 {
+		iterationsTotal += int32(n) * roundIterations
+	}
+	var iterationsDone int32 = 0
+
+	simFunc := runSimConcurrent
+	// Don't use go threads in wasm, it just adds more overhead and makes the worker more unresponsive.
+	if IsRunningInWasm() {
+		simFunc = RunSim
+	}
+
+	useHps := false
+	simCandidate := func(candidate *aplTuningCandidate, numIterations int32, seed int64) (*proto.DistributionMetrics, *proto.ErrorOutcome) {
+		for i, tunable := range tunables {
+			tunable.config.Val = formatTunableConst(tunable.original, candidate.values[i])
+		}
+		candidateRequest := googleProto.Clone(simRequest).(*proto.RaidSimRequest)
+		candidateRequest.SimOptions.Iterations = numIterations
+		candidateRequest.SimOptions.RandomSeed = seed
+
+		candidateProgress := make(chan *proto.ProgressMetrics, 100)
+		go simFunc(candidateRequest, candidateProgress, signals)
+		var lastCompleted int32 = 0
+		for metrics := range candidateProgress {
+			iterationsDone += metrics.CompletedIterations - lastCompleted
+			lastCompleted = metrics.CompletedIterations
+
+			if progress != nil {
+				progress <- &proto.ProgressMetrics{
+					TotalIterations:     iterationsTotal,
+					CompletedIterations: iterationsDone,
+					CompletedSims:       simsCompleted,
+					TotalSims:           simsTotal,
+					Dps:                 metrics.Dps,
+					Hps:                 metrics.Hps,
+				}
+			}
+
+			if simResult := metrics.FinalRaidResult; simResult != nil {
+				simsCompleted++
+				if simResult.Error != nil {
+					return nil, simResult.Error
+				}
+				playerMetrics := simResult.RaidMetrics.Parties[0].Players[0]
+				if simsCompleted == 1 {
+					useHps = playerMetrics.Hps.Avg > playerMetrics.Dps.Avg
+				}
+				return Ternary(useHps, playerMetrics.Hps, playerMetrics.Dps), nil
+			}
+		}
+		return nil, &proto.ErrorOutcome{Message: "Missing sim result!"}
+	}
+
+	// Successive halving: sim every remaining candidate, keep the best half and double the iterations.
+	remaining := slices.Clone(candidates)
+	for len(remaining) > 1 {
+		seed := rng.Int63()
+		for _, candidate := range remaining {
+			if signals.Abort.IsTriggered() {
+				return &proto.APLTuningResult{Error: &proto.ErrorOutcome{Type: proto.ErrorOutcomeType_ErrorOutcomeAborted}}
+			}
+			metrics, errorOutcome := simCandidate(candidate, iterations, seed)
+			if errorOutcome != nil {
+				return &proto.APLTuningResult{Error: errorOutcome}
+			}
+			candidate.samples = append(candidate.samples, metrics.AllValues)
+			candidate.mean = metrics.Avg
+		}
+
+		slices.SortStableFunc(remaining, func(a, b *aplTuningCandidate) int {
+			if a.mean != b.mean {
+				return TernaryInt(a.mean > b.mean, -1, 1)
+			}
+			return 0
+		})
+		remaining = remaining[:(len(remaining)+1)/2]
+		iterations *= 2
+	}
+	best := remaining[0]
+
+	// The best candidate was simmed in every round, so each candidate can be compared to it
+	// in the last round that both were simmed in.
+	result = &proto.APLTuningResult{}
+	for i, tunable := range tunables {
+		low, high := best.values[i], best.values[i]
+		for _, candidate := range candidates {
+			round := len(candidate.samples) - 1
+			if candidate == best || round < 0 {
+				continue
+			}
+			diff, stderr := pairedDifference(best.samples[round], candidate.samples[round])
+			if diff <= 1.96*stderr {
+				low = min(low, candidate.values[i])
+				high = max(high, candidate.values[i])
+			}
+		}
+		result.Consts = append(result.Consts, &proto.APLTunedConst{
+			Location:    tunable.location,
+			Uuid:        tunable.uuid,
+			OriginalVal: tunable.original,
+			Val:         formatTunableConst(tunable.original, best.values[i]),
+			Low:         low,
+			High:        high,
+		})
+	}
+
+	// Compare the best constants to the original ones with the requested iterations.
+	seed := rng.Int63()
+	originalMetrics, errorOutcome := simCandidate(candidates[0], finalIterations, seed)
+	if errorOutcome != nil {
+		return &proto.APLTuningResult{Error: errorOutcome}
+	}
+	bestMetrics, errorOutcome := simCandidate(best, finalIterations, seed)
+	if errorOutcome != nil {
+		return &proto.APLTuningResult{Error: errorOutcome}
+	}
+
+	// The tunables point into simRequest, which now has the best constants.
+	result.Rotation = player.Rotation
+	result.Dps = bestMetrics.Avg
+	result.DpsStderr = bestMetrics.Stdev / math.Sqrt(float64(len(bestMetrics.AllValues)))
+	result.OriginalDps = originalMetrics.Avg
+	result.DpsGain, result.DpsGainStderr = pairedDifference(bestMetrics.AllValues, originalMetrics.AllValues)
+	result.NumSims = simsCompleted
+	return result
+}
@@ -0,0 +1,117 @@
+package core
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/wowsims/mop/sim/core/proto"
+)
+
+func TestTunableConstFormats(t *testing.T) {
+	for _, test := range []struct {
+		original string
+		value    float64
+		expected string
+	}{
+		{"2.5s", 1.25, "1.25s"},
+		{"500ms", 0.75, "0.75s"},
+		{"20%", 35, "35%"},
+		{"3", 4, "4"},
+		{"0", 0.5, "0.5"},
+	} {
+		if _, err := parseTunableConst(test.original); err != nil {
+			t.Errorf("Failed to parse %q: %s", test.original, err)
+		}
+		if actual := formatTunableConst(test.original, test.value); actual != test.expected {
+			t.Errorf("Expected %q for %f in the format of %q, got %q", test.expected, test.value, test.original, actual)
+		}
+	}
+}
+
+func TestTuneAPL(t *testing.T) {
+	// The fake dot does the same damage per second whenever it is up, so it should be cast as early as possible.
+	startTime := &proto.APLValue{
+		Uuid:  &proto.UUID{Value: "start"},
+		Value: &proto.APLValue_Const{Const: &proto.APLValueConst{Val: "8s", Tunable: &proto.APLTunableRange{Min: 0, Max: 12, Step: 0.5}}},
+	}
+	dotRemaining := &proto.APLValue{Value: &proto.APLValue_DotRemainingTime{DotRemainingTime: &proto.APLValueDotRemainingTime{SpellId: fakeSpellID}}}
+	rotation := &proto.APLRotation{
+		PriorityList: []*proto.APLListItem{
+			castFakeSpell(&proto.APLValue{Value: &proto.APLValue_And{And: &proto.APLValueAnd{Vals: []*proto.APLValue{
+				cmpAPLValue("", proto.APLValueCompare_OpGe, currentTimeValue, startTime),
+				cmpAPLValue("", proto.APLValueCompare_OpLt, dotRemaining, constAPLValue("1s")),
+			}}}}),
+		},
+	}
+
+	result := TuneAPL(&proto.APLTuningRequest{
+		BaseSettings: &proto.RaidSimRequest{
+			SimOptions: &proto.SimOptions{Iterations: 50, RandomSeed: 101},
+			Raid: &proto.Raid{Parties: []*proto.Party{{
+				Players: []*proto.Player{{
+					Name:      "Caster",
+					Class:     proto.Class_ClassShaman,
+					Spec:      &proto.Player_ElementalShaman{},
+					Equipment: &proto.EquipmentSpec{},
+					Rotation:  rotation,
+				}},
+			}}},
+			Encounter: &proto.Encounter{
+				Duration: 120,
+				Targets:  []*proto.Target{{Name: "target", Level: 90, MobType: proto.MobType_MobTypeDemon}},
+			},
+		},
+		NumCandidates:     4,
+		InitialIterations: 20,
+	})
+	if result.Error != nil {
+		t.Fatalf("APL tuning failed: %s", result.Error.Message)
+	}
+
+	if len(result.Consts) != 1 {
+		t.Fatalf("Expected 1 tuned constant, got %d", len(result.Consts))
+	}
+	tuned := result.Consts[0]
+	if tuned.Location != "priorityList[0].action.condition.and.vals[0].cmp.rhs.const" || tuned.Uuid.GetValue() != "start" || tuned.OriginalVal != "8s" {
+		t.Errorf("Unexpected tuned constant: %v", tuned)
+	}
+	value, err := parseTunableConst(tuned.Val)
+	if err != nil || value >= 8 || value < tuned.Low || value > tuned.High {
+		t.Errorf("Expected a start time earlier than 8s within [%f, %f], got %s", tuned.Low, tuned.High, tuned.Val)
+	}
+	if tunedVal := result.Rotation.PriorityList[0].Action.Condition.GetAnd().Vals[0].GetCmp().Rhs.GetConst().Val; tunedVal != tuned.Val {
+		t.Errorf("Expected the tuned rotation to use %s, got %s", tuned.Val, tunedVal)
+	}
+	if startTime.GetConst().Val != "8s" {
+		t.Errorf("Expected the request rotation to be unchanged")
+	}
+	if result.DpsGain <= 0 || result.Dps <= result.OriginalDps {
+		t.Errorf("Expected the tuned constant to gain DPS, got %f (%f -> %f)", result.DpsGain, result.OriginalDps, result.Dps)
+	}
+}
+
+func TestAPLTuningCandidates(t *testing.T) {
+	tunables := []*aplTunable{
+		{original: "8s", valRange: &proto.APLTunableRange{Min: 0, Max: 12, Step: 0.5}},
+		{original: "3", valRange: &proto.APLTunableRange{Min: 1, Max: 5, Step: 1}},
+	}
+	candidates := newAPLTuningCandidates(tunables, 16, rand.New(rand.NewSource(1)))
+
+	if candidates[0].values[0] != 8 || candidates[0].values[1] != 3 {
+		t.Errorf("Expected the original values first, got %v", candidates[0].values)
+	}
+	seen := make(map[string]bool)
+	for _, candidate := range candidates {
+		if seen[candidate.key()] {
+			t.Errorf("Duplicate candidate %v", candidate.values)
+		}
+		seen[candidate.key()] = true
+		for i, tunable := range tunables {
+			value := candidate.values[i]
+			if value < tunable.valRange.Min || value > tunable.valRange.Max || math.Mod(value-tunable.valRange.Min, tunable.valRange.Step) != 0 {
+				t.Errorf("Value %f is not a step of the range %v", value, tunable.valRange)
+			}
+		}
+	}
+}
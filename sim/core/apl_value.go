@@ -36,6 +36,7 @@ type DefaultAPLValueImpl struct {
 
 func (impl DefaultAPLValueImpl) GetInnerValues() []APLValue { return nil }
 func (impl DefaultAPLValueImpl) Finalize(*APLRotation)      {}
+func (impl DefaultAPLValueImpl) GetUUID() *proto.UUID       { return impl.Uuid }
 
 func (impl DefaultAPLValueImpl) GetBool(sim *Simulation) bool {
 	panic("Unimplemented GetBool")
@@ -255,6 +256,8 @@ func (rot *APLRotation) newAPLValueWithContext(config *proto.APLValue, groupVari
 		value = rot.newValueAuraICDIsReady(inputConfig, config.Uuid)
 	case *proto.APLValue_AuraShouldRefresh:
 		value = rot.newValueAuraShouldRefresh(config.GetAuraShouldRefresh(), config.Uuid)
+	case *proto.APLValue_ExternalBuffTimeToNext:
+		value = rot.newValueExternalBuffTimeToNext(config.GetExternalBuffTimeToNext(), config.Uuid)
 
 	// Aura sets
 	case *proto.APLValue_AllTrinketStatProcsActive:
@@ -314,6 +317,8 @@ func (rot *APLRotation) newAPLValueWithContext(config *proto.APLValue, groupVari
 
 	case *proto.APLValue_VariableRef:
 		value = rot.newValueVariableRef(config.GetVariableRef(), config.Uuid)
+	case *proto.APLValue_VariableValue:
+		value = rot.newValueVariableValue(config.GetVariableValue(), config.Uuid)
 
 	case *proto.APLValue_VariablePlaceholder:
 		// If we have group variables, replace the placeholder immediately
@@ -284,3 +284,38 @@ func (value *APLValueAuraShouldRefresh) GetBool(sim *Simulation) bool {
 func (value *APLValueAuraShouldRefresh) String() string {
 	return fmt.Sprintf("Should Refresh Aura(%s)", value.aura.String())
 }
+
+type APLValueExternalBuffTimeToNext struct {
+	DefaultAPLValueImpl
+	character *Character
+	spell     *Spell
+}
+
+func (rot *APLRotation) newValueExternalBuffTimeToNext(config *proto.APLValueExternalBuffTimeToNext, uuid *proto.UUID) APLValue {
+	spell := rot.GetAPLSpell(config.SpellId)
+	if spell == nil {
+		return nil
+	}
+	character := rot.unit.Env.Raid.GetPlayerFromUnit(rot.unit).GetCharacter()
+	if character.GetInitialMajorCooldown(spell.ActionID).Spell == nil {
+		rot.ValidationMessageByUUID(uuid, proto.LogLevel_Warning, "%s is not an external cooldown", spell.ActionID)
+		return nil
+	}
+	return &APLValueExternalBuffTimeToNext{
+		character: character,
+		spell:     spell,
+	}
+}
+func (value *APLValueExternalBuffTimeToNext) Type() proto.APLValueType {
+	return proto.APLValueType_ValueTypeDuration
+}
+func (value *APLValueExternalBuffTimeToNext) GetDuration(sim *Simulation) time.Duration {
+	mcd := value.character.GetMajorCooldown(value.spell.ActionID)
+	if mcd == nil {
+		return NeverExpires
+	}
+	return mcd.timeToNextExternalWindow(sim, value.character)
+}
+func (value *APLValueExternalBuffTimeToNext) String() string {
+	return fmt.Sprintf("External Buff Time To Next(%s)", value.spell.ActionID)
+}
@@ -2,6 +2,7 @@ package core
 
 import (
 	"fmt"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -635,9 +636,17 @@ type APLValueVariableRef struct {
 }
 
 func (rot *APLRotation) newValueVariableRef(config *proto.APLValueVariableRef, uuid *proto.UUID) APLValue {
+	if slices.Contains(rot.resolvingVariables, config.Name) {
+		cycle := append(rot.resolvingVariables[slices.Index(rot.resolvingVariables, config.Name):], config.Name)
+		rot.ValidationMessageByUUID(uuid, proto.LogLevel_Error, "Value variable '%s' references itself: %s", config.Name, strings.Join(cycle, " -> "))
+		return nil
+	}
+
 	for _, condVar := range rot.valueVariables {
 		if condVar.name == config.Name {
+			rot.resolvingVariables = append(rot.resolvingVariables, config.Name)
 			resolved := rot.newAPLValue(condVar.value)
+			rot.resolvingVariables = rot.resolvingVariables[:len(rot.resolvingVariables)-1]
 			if resolved == nil {
 				rot.ValidationMessageByUUID(uuid, proto.LogLevel_Error, "Value variable '%s' is empty or invalid", config.Name)
 			}
@@ -743,12 +752,19 @@ func (v *APLValueVariablePlaceholder) String() string {
 // Operator functions that handle groupVariables context for placeholder replacement
 
 func (rot *APLRotation) newValueCompare(config *proto.APLValueCompare, uuid *proto.UUID, groupVariables map[string]*proto.APLValue) APLValue {
-	lhs, rhs := rot.coerceToSameType(rot.newAPLValueWithContext(config.Lhs, groupVariables), rot.newAPLValueWithContext(config.Rhs, groupVariables))
-
+	lhs, rhs := rot.newAPLValueWithContext(config.Lhs, groupVariables), rot.newAPLValueWithContext(config.Rhs, groupVariables)
 	if lhs == nil || rhs == nil {
 		return nil
 	}
 
+	// Numbers compared to durations are treated as seconds, which is easy to get wrong.
+	// Zero is the same either way, so it is fine to leave out the unit.
+	if isDurationNumberMismatch(lhs, rhs) || isDurationNumberMismatch(rhs, lhs) {
+		rot.ValidationMessageByUUID(uuid, proto.LogLevel_Information, "Comparing a duration to a number, which will be treated as seconds. Use a duration such as '5s' instead.")
+	}
+
+	lhs, rhs = rot.coerceToSameType(lhs, rhs)
+
 	// Validate type constraints (skip if placeholders are present during initial parsing)
 	if lhs.Type() != proto.APLValueType_ValueTypeUnknown && rhs.Type() != proto.APLValueType_ValueTypeUnknown {
 		if lhs.Type() == proto.APLValueType_ValueTypeBool && !(config.Op == proto.APLValueCompare_OpEq || config.Op == proto.APLValueCompare_OpNe) {
@@ -764,6 +780,17 @@ func (rot *APLRotation) newValueCompare(config *proto.APLValueCompare, uuid *pro
 	}
 }
 
+func isDurationNumberMismatch(durationVal APLValue, numberVal APLValue) bool {
+	if durationVal.Type() != proto.APLValueType_ValueTypeDuration {
+		return false
+	}
+	if numberVal.Type() != proto.APLValueType_ValueTypeInt && numberVal.Type() != proto.APLValueType_ValueTypeFloat {
+		return false
+	}
+	_, isConst := numberVal.(*APLValueConst)
+	return !isConst || numberVal.GetFloat(nil) != 0
+}
+
 func (rot *APLRotation) newValueMath(config *proto.APLValueMath, uuid *proto.UUID, groupVariables map[string]*proto.APLValue) APLValue {
 	lhs, rhs := rot.newAPLValue(config.Lhs), rot.newAPLValue(config.Rhs)
 	if config.Op == proto.APLValueMath_OpAdd || config.Op == proto.APLValueMath_OpSub {
@@ -0,0 +1,36 @@
+package core
+
+import (
+	"fmt"
+
+	"github.com/wowsims/mop/sim/core/proto"
+)
+
+type APLValueVariableValue struct {
+	DefaultAPLValueImpl
+	variable *APLMutableVariable
+}
+
+func (rot *APLRotation) newValueVariableValue(config *proto.APLValueVariableValue, uuid *proto.UUID) APLValue {
+	if config.Name == "" {
+		rot.ValidationMessageByUUID(uuid, proto.LogLevel_Warning, "Variable Value() must provide a variable name")
+		return nil
+	}
+	return &APLValueVariableValue{
+		variable: rot.getMutableVariable(config.Name),
+	}
+}
+func (value *APLValueVariableValue) Finalize(rot *APLRotation) {
+	if !value.variable.assigned {
+		rot.ValidationMessageByUUID(value.Uuid, proto.LogLevel_Warning, "Mutable variable '%s' is never set", value.variable.name)
+	}
+}
+func (value *APLValueVariableValue) Type() proto.APLValueType {
+	return proto.APLValueType_ValueTypeFloat
+}
+func (value *APLValueVariableValue) GetFloat(sim *Simulation) float64 {
+	return value.variable.value
+}
+func (value *APLValueVariableValue) String() string {
+	return fmt.Sprintf("Variable Value(%s)", value.variable.name)
+}
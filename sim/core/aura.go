@@ -208,6 +208,9 @@ func (aura *Aura) SetStacks(sim *Simulation, newStacks int32) {
 		aura.Unit.Log(sim, "%s stacks: %d --> %d", aura.ActionID, oldStacks, newStacks)
 	}
 	aura.stacks = newStacks
+	if sim.CombatLog != nil && !aura.ActionID.IsEmptyAction() {
+		aura.logCombatEvent(sim, proto.CombatLogEventType_CombatLogEventAuraStacks)
+	}
 	if aura.OnStacksChange != nil {
 		aura.OnStacksChange(aura, sim, oldStacks, newStacks)
 	}
@@ -666,6 +669,9 @@ func (aura *Aura) activate(sim *Simulation, triggerOnGain bool) {
 		if sim.Log != nil && !aura.ActionID.IsEmptyAction() {
 			aura.Unit.Log(sim, "Aura refreshed: %s", aura.ActionID)
 		}
+		if sim.CombatLog != nil && !aura.ActionID.IsEmptyAction() {
+			aura.logCombatEvent(sim, proto.CombatLogEventType_CombatLogEventAuraRefreshed)
+		}
 		aura.Refresh(sim)
 		return
 	}
@@ -756,6 +762,9 @@ func (aura *Aura) activate(sim *Simulation, triggerOnGain bool) {
 	if sim.Log != nil && !aura.ActionID.IsEmptyAction() {
 		aura.Unit.Log(sim, "Aura gained: %s", aura.ActionID)
 	}
+	if sim.CombatLog != nil && !aura.ActionID.IsEmptyAction() {
+		aura.logCombatEvent(sim, proto.CombatLogEventType_CombatLogEventAuraGained)
+	}
 
 	// don't invoke possible callbacks until the internal state is consistent
 	if triggerOnGain && aura.OnGain != nil {
@@ -778,13 +787,16 @@ func (aura *Aura) Deactivate(sim *Simulation) {
 		}
 	}
 
-	if sim.Log != nil && !aura.ActionID.IsEmptyAction() {
+	if (sim.Log != nil || sim.CombatLog != nil) && !aura.ActionID.IsEmptyAction() {
 		// fix logging timestamps for lazy aura expiration
 		oldTime := sim.CurrentTime
 		sim.CurrentTime = min(sim.CurrentTime, aura.expires)
 		if sim.Log != nil {
 			aura.Unit.Log(sim, "Aura faded: %s", aura.ActionID)
 		}
+		if sim.CombatLog != nil {
+			aura.logCombatEvent(sim, proto.CombatLogEventType_CombatLogEventAuraFaded)
+		}
 		sim.CurrentTime = oldTime
 	}
 
@@ -296,6 +296,10 @@ func MakeStackingAura(character *Character, config StackingStatAura) *StatBuffAu
 // Returns the same Aura for chaining.
 func MakePermanent(aura *Aura) *Aura {
 	aura.Duration = NeverExpires
+	if aura.Unit.hasPartialBuffUptime(aura.ActionID.SpellID) {
+		// Activated by applyBuffUptimes instead.
+		return aura
+	}
 	if aura.OnReset == nil {
 		aura.OnReset = func(aura *Aura, sim *Simulation) {
 			aura.Activate(sim)
@@ -794,6 +798,10 @@ func (caster *Unit) NewAllyDamageAbsorptionAuraArray(makeAura func(*Unit) *Damag
 }
 
 func ApplyFixedUptimeAura(aura *Aura, uptime float64, tickLength time.Duration, startTime time.Duration) {
+	applyFixedUptimeAura(aura, uptime, tickLength, startTime, aura.Activate)
+}
+
+func applyFixedUptimeAura(aura *Aura, uptime float64, tickLength time.Duration, startTime time.Duration, activate func(*Simulation)) {
 	auraDuration := aura.Duration
 	ticksPerAura := float64(auraDuration) / float64(tickLength)
 	chancePerTick := TernaryFloat64(uptime == 1, 1, 1.0-math.Pow(1-uptime, 1/ticksPerAura))
@@ -803,7 +811,7 @@ func ApplyFixedUptimeAura(aura *Aura, uptime float64, tickLength time.Duration,
 			Period: tickLength,
 			OnAction: func(sim *Simulation) {
 				if sim.RandomFloat("FixedAura") < chancePerTick {
-					aura.Activate(sim)
+					activate(sim)
 					if aura.MaxStacks > 0 {
 						aura.AddStack(sim)
 					}
@@ -821,7 +829,7 @@ func ApplyFixedUptimeAura(aura *Aura, uptime float64, tickLength time.Duration,
 					randomDur := tickLength + time.Duration(float64(auraDuration-tickLength)*sim.RandomFloat("FixedAuraDur"))
 
 					aura.Duration = randomDur
-					aura.Activate(sim)
+					activate(sim)
 					aura.Duration = auraDuration
 				}
 			},
@@ -0,0 +1,179 @@
+package core
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/wowsims/mop/sim/core/proto"
+)
+
+// Length of each randomly rolled window for buffs with a partial uptime.
+const buffUptimeWindow = time.Second * 30
+
+type buffWindow struct {
+	start time.Duration
+	end   time.Duration
+}
+
+// Parses a schedule of the form "10s-2m, 3m-" into a list of windows. An
+// empty end time means the window lasts until the end of the fight.
+func parseBuffSchedule(schedule string) ([]buffWindow, error) {
+	var windows []buffWindow
+	for _, windowStr := range strings.Split(schedule, ",") {
+		windowStr = strings.TrimSpace(windowStr)
+		startStr, endStr, found := strings.Cut(windowStr, "-")
+		if !found {
+			return nil, fmt.Errorf("invalid buff window '%s', expected start-end", windowStr)
+		}
+
+		window := buffWindow{end: NeverExpires}
+		var err error
+		if window.start, err = time.ParseDuration(strings.TrimSpace(startStr)); err != nil {
+			return nil, err
+		}
+		if endStr = strings.TrimSpace(endStr); endStr != "" {
+			if window.end, err = time.ParseDuration(endStr); err != nil {
+				return nil, err
+			}
+		}
+		if window.end <= window.start {
+			return nil, fmt.Errorf("buff window '%s' ends before it starts", windowStr)
+		}
+
+		windows = append(windows, window)
+	}
+	return windows, nil
+}
+
+// Checks that the uptime is a fraction between 0 and 1 and that the schedule
+// parses, returning the schedule's windows if it has one.
+func parseBuffUptime(config *proto.BuffUptime) ([]buffWindow, error) {
+	if config.Uptime != nil && (*config.Uptime < 0 || *config.Uptime > 1) {
+		return nil, fmt.Errorf("invalid uptime for spell %d: %f, expected a fraction between 0 and 1", config.SpellId, *config.Uptime)
+	}
+	if config.Schedule == "" {
+		return nil, nil
+	}
+
+	windows, err := parseBuffSchedule(config.Schedule)
+	if err != nil {
+		return nil, fmt.Errorf("invalid schedule for spell %d: %w", config.SpellId, err)
+	}
+	return windows, nil
+}
+
+// Validates the buff and debuff uptimes of a raid before simming it.
+func validateBuffUptimes(raid *proto.Raid) error {
+	for _, uptimes := range [][]*proto.BuffUptime{raid.GetBuffs().GetUptimes(), raid.GetDebuffs().GetUptimes()} {
+		for _, config := range uptimes {
+			if _, err := parseBuffUptime(config); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Whether the config is valid and keeps the buff down for part of the fight.
+func isPartialBuffUptime(config *proto.BuffUptime) bool {
+	if _, err := parseBuffUptime(config); err != nil {
+		return false
+	}
+	return config.Schedule != "" || (config.Uptime != nil && *config.Uptime < 1)
+}
+
+// Marks the buffs with a partial uptime on the unit, so that MakePermanent
+// leaves their activation to applyBuffUptimes. Must be called before the
+// buff auras are made permanent.
+func (unit *Unit) setBuffUptimes(uptimes []*proto.BuffUptime) {
+	for _, config := range uptimes {
+		if isPartialBuffUptime(config) {
+			if unit.partialUptimeSpellIDs == nil {
+				unit.partialUptimeSpellIDs = make(map[int32]bool)
+			}
+			unit.partialUptimeSpellIDs[config.SpellId] = true
+		}
+	}
+}
+
+func (unit *Unit) hasPartialBuffUptime(spellID int32) bool {
+	return unit.partialUptimeSpellIDs[spellID]
+}
+
+// Restricts buff or debuff auras on the unit to a partial uptime or a fixed
+// schedule. Buffs driven by a major cooldown, e.g. Skull Banner, stop being
+// used as a cooldown when given a schedule. Invalid configs are ignored here,
+// as sims reject them up front.
+func applyBuffUptimes(unit *Unit, uptimes []*proto.BuffUptime, mcdm *majorCooldownManager) {
+	for _, config := range uptimes {
+		if !isPartialBuffUptime(config) {
+			continue
+		}
+		windows, _ := parseBuffUptime(config)
+
+		auras := unit.getAurasBySpellID(config.SpellId)
+		if len(auras) == 0 {
+			continue
+		}
+
+		if windows != nil && mcdm != nil {
+			mcdm.initialMajorCooldowns = slices.DeleteFunc(mcdm.initialMajorCooldowns, func(mcd MajorCooldown) bool {
+				return mcd.Spell.ActionID.SpellID == config.SpellId
+			})
+		}
+
+		for _, aura := range auras {
+			if windows != nil {
+				applyBuffSchedule(aura, windows)
+			} else {
+				aura.Duration = buffUptimeWindow
+				if aura.MaxStacks > 0 {
+					aura.ApplyOnGain(func(aura *Aura, sim *Simulation) {
+						aura.SetStacks(sim, aura.MaxStacks)
+					})
+				}
+				applyFixedUptimeAura(aura, *config.Uptime, time.Second*3, 0, aura.Activate)
+			}
+		}
+	}
+}
+
+func applyBuffSchedule(aura *Aura, windows []buffWindow) {
+	onWindowStart := func(sim *Simulation) {
+		aura.Activate(sim)
+		if aura.MaxStacks > 0 {
+			aura.SetStacks(sim, aura.MaxStacks)
+		}
+	}
+
+	aura.Duration = NeverExpires
+	aura.ApplyOnReset(func(aura *Aura, sim *Simulation) {
+		for _, window := range windows {
+			pa := sim.GetConsumedPendingActionFromPool()
+			pa.NextActionAt = window.start
+			pa.Priority = ActionPriorityAuto
+			pa.OnAction = onWindowStart
+			sim.AddPendingAction(pa)
+
+			if window.end != NeverExpires {
+				pa := sim.GetConsumedPendingActionFromPool()
+				pa.NextActionAt = window.end
+				pa.Priority = ActionPriorityAuto
+				pa.OnAction = aura.Deactivate
+				sim.AddPendingAction(pa)
+			}
+		}
+	})
+}
+
+func (unit *Unit) getAurasBySpellID(spellID int32) []*Aura {
+	var auras []*Aura
+	for _, aura := range unit.auras {
+		if aura.ActionID.SpellID == spellID {
+			auras = append(auras, aura)
+		}
+	}
+	return auras
+}
@@ -0,0 +1,144 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	googleProto "google.golang.org/protobuf/proto"
+
+	"github.com/wowsims/mop/sim/core/proto"
+)
+
+func TestParseBuffSchedule(t *testing.T) {
+	windows, err := parseBuffSchedule("10s-2m, 3m-")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []buffWindow{
+		{start: time.Second * 10, end: time.Minute * 2},
+		{start: time.Minute * 3, end: NeverExpires},
+	}
+	if len(windows) != len(expected) {
+		t.Fatalf("Expected %d windows but got %d", len(expected), len(windows))
+	}
+	for i, window := range windows {
+		if window != expected[i] {
+			t.Errorf("Window %d should be %v but was %v", i, expected[i], window)
+		}
+	}
+
+	for _, schedule := range []string{"10s", "1m-30s", "abc-1m", "10s-abc"} {
+		if _, err := parseBuffSchedule(schedule); err == nil {
+			t.Errorf("Expected an error for schedule '%s'", schedule)
+		}
+	}
+}
+
+func runWeakenedArmorUptime(t *testing.T, uptime *proto.BuffUptime) *proto.AuraMetrics {
+	raid := SinglePlayerRaidProto(&proto.Player{
+		Name:      "Caster",
+		Class:     proto.Class_ClassShaman,
+		Spec:      &proto.Player_ElementalShaman{},
+		Equipment: &proto.EquipmentSpec{},
+	}, &proto.PartyBuffs{}, &proto.RaidBuffs{}, &proto.Debuffs{
+		WeakenedArmor: true,
+		Uptimes:       []*proto.BuffUptime{uptime},
+	})
+
+	result := RunRaidSim(&proto.RaidSimRequest{
+		Raid: raid,
+		Encounter: &proto.Encounter{
+			Duration: 60,
+			Targets:  []*proto.Target{{Name: "target", Level: 93, MobType: proto.MobType_MobTypeDemon}},
+		},
+		SimOptions: &proto.SimOptions{
+			Iterations: 1,
+			RandomSeed: 101,
+		},
+	})
+	if result.Error != nil {
+		t.Fatal(result.Error.Message)
+	}
+
+	for _, auraMetrics := range result.EncounterMetrics.Targets[0].Auras {
+		if auraMetrics.Id.GetSpellId() == uptime.SpellId {
+			return auraMetrics
+		}
+	}
+	t.Fatalf("No metrics for spell %d", uptime.SpellId)
+	return nil
+}
+
+func TestBuffUptimeSchedule(t *testing.T) {
+	// Weakened Armor has its own reset logic, which would otherwise put it up
+	// after the first GCD.
+	metrics := runWeakenedArmorUptime(t, &proto.BuffUptime{SpellId: 113746, Schedule: "10s-20s, 50s-"})
+
+	if metrics.UptimeSecondsAvg != 20 {
+		t.Errorf("Expected 20s of uptime but got %0.2fs", metrics.UptimeSecondsAvg)
+	}
+	if metrics.ProcsAvg != 2 {
+		t.Errorf("Expected 2 activations but got %0.2f", metrics.ProcsAvg)
+	}
+}
+
+func TestBuffUptimeUnset(t *testing.T) {
+	metrics := runWeakenedArmorUptime(t, &proto.BuffUptime{SpellId: 113746})
+
+	// Unset uptimes leave the debuff up from the first GCD onwards.
+	if expected := (time.Minute - GCDMin).Seconds(); metrics.UptimeSecondsAvg != expected {
+		t.Errorf("Expected %0.2fs of uptime but got %0.2fs", expected, metrics.UptimeSecondsAvg)
+	}
+}
+
+func TestBuffUptimeZero(t *testing.T) {
+	metrics := runWeakenedArmorUptime(t, &proto.BuffUptime{SpellId: 113746, Uptime: googleProto.Float64(0)})
+
+	if metrics.UptimeSecondsAvg != 0 || metrics.ProcsAvg != 0 {
+		t.Errorf("Expected no uptime but got %0.2fs over %0.2f activations", metrics.UptimeSecondsAvg, metrics.ProcsAvg)
+	}
+}
+
+func TestBuffUptimeInvalid(t *testing.T) {
+	for _, uptime := range []*proto.BuffUptime{
+		{SpellId: 113746, Uptime: googleProto.Float64(1.5)},
+		{SpellId: 113746, Schedule: "1m-30s"},
+	} {
+		result := RunRaidSim(&proto.RaidSimRequest{
+			Raid: SinglePlayerRaidProto(&proto.Player{
+				Class:     proto.Class_ClassShaman,
+				Spec:      &proto.Player_ElementalShaman{},
+				Equipment: &proto.EquipmentSpec{},
+			}, &proto.PartyBuffs{}, &proto.RaidBuffs{}, &proto.Debuffs{
+				WeakenedArmor: true,
+				Uptimes:       []*proto.BuffUptime{uptime},
+			}),
+			Encounter:  &proto.Encounter{Duration: 60, Targets: []*proto.Target{{Name: "target"}}},
+			SimOptions: &proto.SimOptions{Iterations: 1},
+		})
+
+		if result.Error == nil || result.Error.Message == "" {
+			t.Errorf("Expected an error for %v", uptime)
+		}
+	}
+}
+
+func TestBuffUptimeAllMatchingAuras(t *testing.T) {
+	uptimes := []*proto.BuffUptime{{SpellId: 1, Uptime: googleProto.Float64(0.5)}}
+	unit := &Unit{auraTracker: newAuraTracker()}
+	unit.setBuffUptimes(uptimes)
+	first := MakePermanent(unit.RegisterAura(Aura{Label: "First", ActionID: ActionID{SpellID: 1}, Duration: time.Second}))
+	second := MakePermanent(unit.RegisterAura(Aura{Label: "Second", ActionID: ActionID{SpellID: 1}, Duration: time.Second}))
+
+	applyBuffUptimes(unit, uptimes, nil)
+
+	for _, aura := range []*Aura{first, second} {
+		if aura.Duration != buffUptimeWindow {
+			t.Errorf("Expected %s to last %s but got %s", aura.Label, buffUptimeWindow, aura.Duration)
+		}
+		if aura.OnReset != nil {
+			t.Errorf("Expected %s not to be activated on reset", aura.Label)
+		}
+	}
+}
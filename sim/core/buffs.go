@@ -92,6 +92,7 @@ func makeExclusiveBuff(unit *Unit, config BuffConfig) *Aura {
 func applyBuffEffects(agent Agent, raidBuffs *proto.RaidBuffs, _ *proto.PartyBuffs, individual *proto.IndividualBuffs) {
 	char := agent.GetCharacter()
 	u := &char.Unit
+	u.setBuffUptimes(raidBuffs.Uptimes)
 
 	// +10% Attack Power
 	if raidBuffs.HornOfWinter {
@@ -205,6 +206,7 @@ func applyBuffEffects(agent Agent, raidBuffs *proto.RaidBuffs, _ *proto.PartyBuf
 
 		// Other individual CDs
 		registerUnholyFrenzyCD(agent, individual.UnholyFrenzyCount)
+		registerPowerInfusionCD(agent, individual.PowerInfusionCount)
 		if individual.TricksOfTheTrade {
 			registerTricksOfTheTradeCD(agent)
 		}
@@ -215,6 +217,9 @@ func applyBuffEffects(agent Agent, raidBuffs *proto.RaidBuffs, _ *proto.PartyBuf
 		registerRallyingCryCD(agent, individual.RallyingCryCount)
 		registerShatteringThrowCD(agent, individual.ShatteringThrowCount)
 	}
+
+	applyExternalCooldownSchedules(char, individual.ExternalCooldownSchedules)
+	applyBuffUptimes(u, raidBuffs.Uptimes, &char.majorCooldownManager)
 }
 
 ///////////////////////////////////////////////////////////////////////////
@@ -510,9 +515,23 @@ func BurningWrathAura(u *Unit) *Aura {
 	return makeExclusiveBuff(u, BuffConfig{"Burning Wrath", ActionID{SpellID: 77747}, []StatConfig{{stats.SpellPower, 1.10, true}}})
 }
 func DarkIntentAura(u *Unit) *Aura {
-	return makeExclusiveBuff(u, BuffConfig{"Dark Intent", ActionID{SpellID: 109773}, []StatConfig{{stats.SpellPower, 1.10, true}, {stats.Stamina, 1.10, true}}})
+	return makeExclusiveBuff(u, BuffConfig{"Dark Intent", ActionID{SpellID: 109773}, darkIntentStats})
+}
+
+// Dark Intent cast by a warlock in the raid, rather than assumed to be up
+// from the raid buff settings.
+func DarkIntentCastAura(u *Unit) *Aura {
+	aura := u.GetOrRegisterAura(Aura{
+		Label:    "Dark Intent",
+		ActionID: ActionID{SpellID: 109773},
+		Duration: time.Hour,
+	})
+	registerExlusiveEffects(aura, darkIntentStats)
+	return aura
 }
 
+var darkIntentStats = []StatConfig{{stats.SpellPower, 1.10, true}, {stats.Stamina, 1.10, true}}
+
 /////////////
 /// OLD /////
 ////////////
@@ -800,6 +819,55 @@ func UnholyFrenzyAura(character *Unit, actionTag int32, has2pT14 func() bool) *A
 	return aura
 }
 
+var PowerInfusionAuraTag = "PowerInfusion"
+
+const PowerInfusionDuration = time.Second * 20
+const PowerInfusionCD = time.Minute * 2
+
+func registerPowerInfusionCD(agent Agent, numPowerInfusions int32) {
+	if numPowerInfusions == 0 {
+		return
+	}
+
+	piAura := PowerInfusionAura(&agent.GetCharacter().Unit, -1)
+
+	registerExternalConsecutiveCDApproximation(
+		agent,
+		externalConsecutiveCDApproximation{
+			ActionID:         ActionID{SpellID: 10060, Tag: -1},
+			AuraTag:          PowerInfusionAuraTag,
+			CooldownPriority: CooldownPriorityDefault,
+			RelatedSelfBuff:  piAura,
+			AuraDuration:     PowerInfusionDuration,
+			AuraCD:           PowerInfusionCD,
+			Type:             CooldownTypeDPS,
+
+			ShouldActivate: func(sim *Simulation, character *Character) bool {
+				return true
+			},
+			AddAura: func(sim *Simulation, character *Character) { piAura.Activate(sim) },
+		},
+		numPowerInfusions)
+}
+
+func PowerInfusionAura(character *Unit, actionTag int32) *Aura {
+	actionID := ActionID{SpellID: 10060, Tag: actionTag}
+
+	return character.GetOrRegisterAura(Aura{
+		Label:    "PowerInfusion-" + actionID.String(),
+		Tag:      PowerInfusionAuraTag,
+		ActionID: actionID,
+		Duration: PowerInfusionDuration,
+		OnGain: func(aura *Aura, sim *Simulation) {
+			aura.Unit.PseudoStats.SpellCostPercentModifier -= 20
+		},
+		OnExpire: func(aura *Aura, sim *Simulation) {
+			aura.Unit.PseudoStats.SpellCostPercentModifier += 20
+		},
+	}).AttachMultiplyCastSpeed(1.2).
+		AttachMultiplicativePseudoStatBuff(&character.PseudoStats.DamageDealtMultiplier, 1.05)
+}
+
 func RegisterPercentDamageModifierEffect(aura *Aura, percentDamageModifier float64) *ExclusiveEffect {
 	return aura.NewExclusiveEffect("PercentDamageModifier", true, ExclusiveEffect{
 		Priority: percentDamageModifier,
@@ -0,0 +1,473 @@
+package core
+
+import (
+	"fmt"
+	"runtime/debug"
+	"slices"
+	"time"
+
+	"github.com/wowsims/mop/sim/core/proto"
+	"github.com/wowsims/mop/sim/core/simsignals"
+	googleProto "google.golang.org/protobuf/proto"
+)
+
+// Upper bound on the number of gear combinations a single bulk sim may expand to.
+const MaxBulkCombinations = 100_000
+
+// A single alternative for a group of slots, e.g. one ring pair or one weapon set.
+type bulkSlotOption []*proto.ItemSpecWithSlot
+
+// A group of mutually exclusive options. Exactly one option of every group is used in each combination.
+type bulkSlotGroup []bulkSlotOption
+
+type bulkComboGenerator struct {
+	groups []bulkSlotGroup
+}
+
+func (gen *bulkComboGenerator) NumCombinations() int {
+	if len(gen.groups) == 0 {
+		return 0
+	}
+
+	total := 1
+	for _, group := range gen.groups {
+		total *= len(group)
+		if total > MaxBulkCombinations {
+			return total
+		}
+	}
+	return total
+}
+
+// Returns the items used by the combination with the given index. Each group acts as one
+// digit of a mixed-radix number, the same way the UI bulk tab indexes combinations.
+func (gen *bulkComboGenerator) Combination(comboIdx int) []*proto.ItemSpecWithSlot {
+	var items []*proto.ItemSpecWithSlot
+	for _, group := range gen.groups {
+		optionIdx := comboIdx % len(group)
+		comboIdx /= len(group)
+		items = append(items, group[optionIdx]...)
+	}
+	return items
+}
+
+func newBulkItemWithSlot(spec *proto.ItemSpec, slot proto.ItemSlot) *proto.ItemSpecWithSlot {
+	return &proto.ItemSpecWithSlot{
+		Item: spec,
+		Slot: slot,
+	}
+}
+
+// Builds options for slots which hold two items (rings, trinkets and dual-wielded weapons).
+// With a single candidate, the item replaces either of the equipped items instead.
+func bulkPairOptions(candidates []*proto.ItemSpec, slot1 proto.ItemSlot, slot2 proto.ItemSlot, ordered bool) bulkSlotGroup {
+	if len(candidates) == 1 {
+		return bulkSlotGroup{
+			{newBulkItemWithSlot(candidates[0], slot1)},
+			{newBulkItemWithSlot(candidates[0], slot2)},
+		}
+	}
+
+	var group bulkSlotGroup
+	for i := 0; i < len(candidates); i++ {
+		for j := i + 1; j < len(candidates); j++ {
+			sameItem := candidates[i].Id == candidates[j].Id
+			if sameItem && !ordered {
+				// Rings and trinkets are unique-equipped.
+				continue
+			}
+
+			group = append(group, bulkSlotOption{
+				newBulkItemWithSlot(candidates[i], slot1),
+				newBulkItemWithSlot(candidates[j], slot2),
+			})
+
+			if ordered && !sameItem {
+				group = append(group, bulkSlotOption{
+					newBulkItemWithSlot(candidates[j], slot1),
+					newBulkItemWithSlot(candidates[i], slot2),
+				})
+			}
+		}
+	}
+	return group
+}
+
+func newBulkComboGenerator(player *proto.Player, settings *proto.BulkSettings) (*bulkComboGenerator, error) {
+	equipment := ProtoToEquipmentSpec(player.GetEquipment())
+	isFuryWarrior := player.GetFuryWarrior() != nil
+
+	// Dual-wield pairs are only generated when the player already has an off-hand weapon equipped.
+	canDualWield := isFuryWarrior
+	if ohSpec := equipment[proto.ItemSlot_ItemSlotOffHand]; ohSpec.ID != 0 {
+		if oh := GetItemByID(ohSpec.ID); oh != nil && oh.Type == proto.ItemType_ItemTypeWeapon && oh.SwingSpeed != 0 {
+			canDualWield = true
+		}
+	}
+
+	bySlot := make(map[proto.ItemSlot][]*proto.ItemSpec)
+	var twoHands, mainHands, offHands, oneHands []*proto.ItemSpec
+
+	for _, spec := range settings.Items {
+		item := GetItemByID(spec.Id)
+		if item == nil {
+			return nil, fmt.Errorf("no item with id %d in the database", spec.Id)
+		}
+
+		switch {
+		case item.Type == proto.ItemType_ItemTypeRanged:
+			twoHands = append(twoHands, spec)
+		case item.Type == proto.ItemType_ItemTypeWeapon:
+			switch item.HandType {
+			case proto.HandType_HandTypeTwoHand:
+				if isFuryWarrior {
+					oneHands = append(oneHands, spec)
+				} else {
+					twoHands = append(twoHands, spec)
+				}
+			case proto.HandType_HandTypeOneHand:
+				if canDualWield {
+					oneHands = append(oneHands, spec)
+				} else {
+					mainHands = append(mainHands, spec)
+				}
+			case proto.HandType_HandTypeOffHand:
+				offHands = append(offHands, spec)
+			default:
+				mainHands = append(mainHands, spec)
+			}
+		default:
+			slots := eligibleSlotsForItem(item, isFuryWarrior)
+			if len(slots) == 0 {
+				return nil, fmt.Errorf("item %d (%s) cannot be equipped", item.ID, item.Name)
+			}
+			bySlot[slots[0]] = append(bySlot[slots[0]], spec)
+		}
+	}
+
+	gen := &bulkComboGenerator{}
+
+	for slot := proto.ItemSlot_ItemSlotHead; slot <= proto.ItemSlot_ItemSlotFeet; slot++ {
+		if len(bySlot[slot]) == 0 {
+			continue
+		}
+		gen.groups = append(gen.groups, MapSlice(bySlot[slot], func(spec *proto.ItemSpec) bulkSlotOption {
+			return bulkSlotOption{newBulkItemWithSlot(spec, slot)}
+		}))
+	}
+
+	if rings := bySlot[proto.ItemSlot_ItemSlotFinger1]; len(rings) > 0 {
+		if group := bulkPairOptions(rings, proto.ItemSlot_ItemSlotFinger1, proto.ItemSlot_ItemSlotFinger2, false); len(group) > 0 {
+			gen.groups = append(gen.groups, group)
+		}
+	}
+	if trinkets := bySlot[proto.ItemSlot_ItemSlotTrinket1]; len(trinkets) > 0 {
+		if group := bulkPairOptions(trinkets, proto.ItemSlot_ItemSlotTrinket1, proto.ItemSlot_ItemSlotTrinket2, false); len(group) > 0 {
+			gen.groups = append(gen.groups, group)
+		}
+	}
+
+	var weaponGroup bulkSlotGroup
+	for _, twoHand := range twoHands {
+		weaponGroup = append(weaponGroup, bulkSlotOption{
+			newBulkItemWithSlot(twoHand, proto.ItemSlot_ItemSlotMainHand),
+			newBulkItemWithSlot(&proto.ItemSpec{}, proto.ItemSlot_ItemSlotOffHand),
+		})
+	}
+	if len(mainHands) > 0 {
+		for _, mh := range mainHands {
+			if len(offHands) == 0 {
+				weaponGroup = append(weaponGroup, bulkSlotOption{newBulkItemWithSlot(mh, proto.ItemSlot_ItemSlotMainHand)})
+			}
+			for _, oh := range offHands {
+				weaponGroup = append(weaponGroup, bulkSlotOption{
+					newBulkItemWithSlot(mh, proto.ItemSlot_ItemSlotMainHand),
+					newBulkItemWithSlot(oh, proto.ItemSlot_ItemSlotOffHand),
+				})
+			}
+		}
+	} else {
+		for _, oh := range offHands {
+			weaponGroup = append(weaponGroup, bulkSlotOption{newBulkItemWithSlot(oh, proto.ItemSlot_ItemSlotOffHand)})
+		}
+	}
+	if len(oneHands) > 0 {
+		weaponGroup = append(weaponGroup, bulkPairOptions(oneHands, proto.ItemSlot_ItemSlotMainHand, proto.ItemSlot_ItemSlotOffHand, true)...)
+	}
+	if len(weaponGroup) > 0 {
+		gen.groups = append(gen.groups, weaponGroup)
+	}
+
+	if numCombos := gen.NumCombinations(); numCombos > MaxBulkCombinations {
+		return nil, fmt.Errorf("bulk sim would require more than %d combinations", MaxBulkCombinations)
+	}
+
+	return gen, nil
+}
+
+func bulkDefaultGem(settings *proto.BulkSettings, color proto.GemColor) int32 {
+	switch color {
+	case proto.GemColor_GemColorRed:
+		return settings.DefaultRedGem
+	case proto.GemColor_GemColorBlue:
+		return settings.DefaultBlueGem
+	case proto.GemColor_GemColorYellow:
+		return settings.DefaultYellowGem
+	case proto.GemColor_GemColorMeta:
+		return settings.DefaultMetaGem
+	case proto.GemColor_GemColorPrismatic:
+		return settings.DefaultPrismaticGem
+	}
+	return 0
+}
+
+// Returns a copy of the equipment with the combination's items swapped in. Like the UI, swapped items
+// keep the enchant of the item they replace, and sockets are filled with the default gems. Also returns
+// the final specs of the swapped items.
+func applyBulkCombination(equipment *proto.EquipmentSpec, combo []*proto.ItemSpecWithSlot, settings *proto.BulkSettings, challengeMode bool) (*proto.EquipmentSpec, []*proto.ItemSpecWithSlot) {
+	newEquipment := googleProto.Clone(equipment).(*proto.EquipmentSpec)
+	for len(newEquipment.Items) < int(NumItemSlots) {
+		newEquipment.Items = append(newEquipment.Items, &proto.ItemSpec{})
+	}
+
+	itemsAdded := make([]*proto.ItemSpecWithSlot, 0, len(combo))
+	for _, swap := range combo {
+		equipped := newEquipment.Items[swap.Slot]
+		newSpec := googleProto.Clone(swap.Item).(*proto.ItemSpec)
+
+		if newSpec.Id != 0 {
+			if newSpec.Enchant == 0 {
+				newSpec.Enchant = equipped.Enchant
+			}
+			if newSpec.Tinker == 0 {
+				newSpec.Tinker = equipped.Tinker
+			}
+			if settings.InheritUpgrades && equipped.Id != 0 {
+				newSpec.UpgradeStep = equipped.UpgradeStep
+			}
+			newSpec.ChallengeMode = newSpec.ChallengeMode || challengeMode
+
+			if item := GetItemByID(newSpec.Id); item != nil {
+				for len(newSpec.Gems) < len(item.GemSockets) {
+					newSpec.Gems = append(newSpec.Gems, 0)
+				}
+				for socketIdx, socketColor := range item.GemSockets {
+					if gemID := bulkDefaultGem(settings, socketColor); gemID != 0 {
+						newSpec.Gems[socketIdx] = gemID
+					}
+				}
+			}
+		}
+
+		newEquipment.Items[swap.Slot] = newSpec
+		itemsAdded = append(itemsAdded, newBulkItemWithSlot(newSpec, swap.Slot))
+	}
+
+	return newEquipment, itemsAdded
+}
+
+func newBulkComboResult(items []*proto.ItemSpecWithSlot, result *proto.RaidSimResult) *proto.BulkComboResult {
+	comboResult := &proto.BulkComboResult{
+		ItemsAdded: items,
+		Result:     result,
+	}
+	if result.RaidMetrics != nil && len(result.RaidMetrics.Parties) > 0 && len(result.RaidMetrics.Parties[0].Players) > 0 {
+		player := result.RaidMetrics.Parties[0].Players[0]
+		comboResult.Dps = player.Dps.Avg
+		comboResult.Hps = player.Hps.Avg
+	}
+	return comboResult
+}
+
+// Sorts combinations from best to worst by DPS, or by HPS when ranking healers.
+func sortBulkComboResults(results []*proto.BulkComboResult, rankByHps bool) {
+	slices.SortStableFunc(results, func(a, b *proto.BulkComboResult) int {
+		primaryA, primaryB := a.Dps, b.Dps
+		secondaryA, secondaryB := a.Hps, b.Hps
+		if rankByHps {
+			primaryA, primaryB, secondaryA, secondaryB = secondaryA, secondaryB, primaryA, primaryB
+		}
+		if primaryA != primaryB {
+			return TernaryInt(primaryA > primaryB, -1, 1)
+		}
+		if secondaryA != secondaryB {
+			return TernaryInt(secondaryA > secondaryB, -1, 1)
+		}
+		return 0
+	})
+}
+
+func isHealerPlayer(player *proto.Player) bool {
+	switch player.Spec.(type) {
+	case *proto.Player_RestorationDruid, *proto.Player_MistweaverMonk, *proto.Player_HolyPaladin,
+		*proto.Player_DisciplinePriest, *proto.Player_HolyPriest, *proto.Player_RestorationShaman:
+		return true
+	}
+	return false
+}
+
+// Run the baseline and every gear combination, returning the combinations ranked by DPS, or by HPS for healers.
+func runBulkSim(request *proto.BulkSimRequest, progress chan *proto.ProgressMetrics, signals simsignals.Signals) (result *proto.BulkSimResult) {
+	errorResult := func(message string) *proto.BulkSimResult {
+		return &proto.BulkSimResult{Error: &proto.ErrorOutcome{Message: message}}
+	}
+
+	defer func() {
+		if err := recover(); err != nil {
+			errStr := ""
+			switch errt := err.(type) {
+			case string:
+				errStr = errt
+			case error:
+				errStr = errt.Error()
+			}
+
+			errStr += "\nStack Trace:\n" + string(debug.Stack())
+			result = errorResult(errStr)
+		}
+	}()
+
+	baseRequest := request.BaseSettings
+	settings := request.BulkSettings
+	if baseRequest == nil || settings == nil {
+		return errorResult("Bulk sim requires both base settings and bulk settings!")
+	}
+	if baseRequest.SimOptions == nil {
+		return errorResult("Bulk sim requires sim options!")
+	}
+	if baseRequest.Raid == nil || len(baseRequest.Raid.Parties) == 0 || len(baseRequest.Raid.Parties[0].Players) == 0 {
+		return errorResult("Bulk sim requires a player in the first party!")
+	}
+	if len(settings.Items) == 0 {
+		return errorResult("No items selected for bulk sim!")
+	}
+
+	baseRequest = googleProto.Clone(baseRequest).(*proto.RaidSimRequest)
+	player := baseRequest.Raid.Parties[0].Players[0]
+	if player.Equipment == nil {
+		player.Equipment = &proto.EquipmentSpec{}
+	}
+
+	// Item lookups happen before any environment is built, so load the request databases now.
+	for _, party := range baseRequest.Raid.Parties {
+		for _, p := range party.Players {
+			if p.Database != nil {
+				addToDatabase(p.Database)
+			}
+		}
+	}
+
+	gen, err := newBulkComboGenerator(player, settings)
+	if err != nil {
+		return errorResult(err.Error())
+	}
+	numCombos := gen.NumCombinations()
+	if numCombos == 0 {
+		return errorResult("No valid item combinations for bulk sim!")
+	}
+
+	// Every combination shares the same seed so differences come from gear rather than RNG.
+	if baseRequest.SimOptions.RandomSeed == 0 {
+		baseRequest.SimOptions.RandomSeed = time.Now().UnixNano()
+	}
+
+	comboOptions := googleProto.Clone(baseRequest.SimOptions).(*proto.SimOptions)
+	if settings.IterationsPerCombo > 0 {
+		comboOptions.Iterations = settings.IterationsPerCombo
+	}
+	comboOptions.Debug = false
+	comboOptions.DebugFirstIteration = false
+	comboOptions.SaveAllValues = false
+
+	var iterationsTotal int32 = baseRequest.SimOptions.Iterations + comboOptions.Iterations*int32(numCombos)
+	var iterationsDone int32 = 0
+	var simsTotal int32 = int32(numCombos) + 1
+	var simsCompleted int32 = 0
+
+	waitForResult := func(srcProgressChannel chan *proto.ProgressMetrics) *proto.RaidSimResult {
+		var lastCompleted int32 = 0
+		for metrics := range srcProgressChannel {
+			iterationsDone += metrics.CompletedIterations - lastCompleted
+			lastCompleted = metrics.CompletedIterations
+
+			if progress != nil {
+				progress <- &proto.ProgressMetrics{
+					TotalIterations:     iterationsTotal,
+					CompletedIterations: iterationsDone,
+					CompletedSims:       simsCompleted,
+					TotalSims:           simsTotal,
+					Dps:                 metrics.Dps,
+					Hps:                 metrics.Hps,
+				}
+			}
+
+			if metrics.FinalRaidResult != nil {
+				simsCompleted++
+				return metrics.FinalRaidResult
+			}
+		}
+		return nil
+	}
+
+	simFunc := runSimConcurrent
+	// Don't use go threads in wasm, it just adds more overhead and makes the worker more unresponsive.
+	if IsRunningInWasm() {
+		simFunc = RunSim
+	}
+
+	baseProgress := make(chan *proto.ProgressMetrics, 100)
+	go simFunc(baseRequest, baseProgress, signals)
+	baselineResult := waitForResult(baseProgress)
+	if baselineResult == nil {
+		return errorResult("Missing baseline sim result!")
+	}
+	if baselineResult.Error != nil {
+		return &proto.BulkSimResult{Error: baselineResult.Error}
+	}
+
+	result = &proto.BulkSimResult{
+		EquippedGearResult: newBulkComboResult(nil, baselineResult),
+		Results:            make([]*proto.BulkComboResult, 0, numCombos),
+	}
+
+	for comboIdx := 0; comboIdx < numCombos; comboIdx++ {
+		if signals.Abort.IsTriggered() {
+			return &proto.BulkSimResult{Error: &proto.ErrorOutcome{Type: proto.ErrorOutcomeType_ErrorOutcomeAborted}}
+		}
+
+		comboRequest := googleProto.Clone(baseRequest).(*proto.RaidSimRequest)
+		comboRequest.SimOptions = googleProto.Clone(comboOptions).(*proto.SimOptions)
+		comboPlayer := comboRequest.Raid.Parties[0].Players[0]
+		var itemsAdded []*proto.ItemSpecWithSlot
+		comboPlayer.Equipment, itemsAdded = applyBulkCombination(player.Equipment, gen.Combination(comboIdx), settings, player.ChallengeMode)
+
+		comboProgress := make(chan *proto.ProgressMetrics, 100)
+		go simFunc(comboRequest, comboProgress, signals)
+		comboResult := waitForResult(comboProgress)
+		if comboResult == nil {
+			return errorResult(fmt.Sprintf("Missing sim result for combination %d!", comboIdx))
+		}
+		if comboResult.Error != nil {
+			return &proto.BulkSimResult{Error: comboResult.Error}
+		}
+
+		bulkComboResult := newBulkComboResult(itemsAdded, comboResult)
+		result.Results = append(result.Results, bulkComboResult)
+
+		if progress != nil {
+			progress <- &proto.ProgressMetrics{
+				TotalIterations:     iterationsTotal,
+				CompletedIterations: iterationsDone,
+				CompletedSims:       simsCompleted,
+				TotalSims:           simsTotal,
+				Dps:                 bulkComboResult.Dps,
+				Hps:                 bulkComboResult.Hps,
+				BulkComboResult:     bulkComboResult,
+			}
+		}
+	}
+
+	sortBulkComboResults(result.Results, isHealerPlayer(player))
+
+	return result
+}
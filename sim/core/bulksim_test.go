@@ -0,0 +1,155 @@
+package core
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/wowsims/mop/sim/core/proto"
+	"github.com/wowsims/mop/sim/core/simsignals"
+)
+
+func init() {
+	addToDatabase(&proto.SimDatabase{
+		Items: []*proto.SimItem{
+			{Id: 990001, Name: "Bulk Test Helm A", Type: proto.ItemType_ItemTypeHead, GemSockets: []proto.GemColor{proto.GemColor_GemColorMeta, proto.GemColor_GemColorRed}},
+			{Id: 990002, Name: "Bulk Test Helm B", Type: proto.ItemType_ItemTypeHead},
+			{Id: 990003, Name: "Bulk Test Ring A", Type: proto.ItemType_ItemTypeFinger},
+			{Id: 990004, Name: "Bulk Test Ring B", Type: proto.ItemType_ItemTypeFinger},
+			{Id: 990005, Name: "Bulk Test Ring C", Type: proto.ItemType_ItemTypeFinger},
+			{Id: 990006, Name: "Bulk Test Trinket", Type: proto.ItemType_ItemTypeTrinket},
+			{Id: 990007, Name: "Bulk Test Staff", Type: proto.ItemType_ItemTypeWeapon, HandType: proto.HandType_HandTypeTwoHand},
+			{Id: 990008, Name: "Bulk Test Dagger", Type: proto.ItemType_ItemTypeWeapon, HandType: proto.HandType_HandTypeOneHand, WeaponSpeed: 1.8},
+			{Id: 990009, Name: "Bulk Test Sword", Type: proto.ItemType_ItemTypeWeapon, HandType: proto.HandType_HandTypeOneHand, WeaponSpeed: 2.6},
+		},
+	})
+}
+
+func bulkTestItems(ids ...int32) []*proto.ItemSpec {
+	return MapSlice(ids, func(id int32) *proto.ItemSpec { return &proto.ItemSpec{Id: id} })
+}
+
+func TestBulkCombinationCount(t *testing.T) {
+	player := &proto.Player{Equipment: &proto.EquipmentSpec{}}
+
+	gen, err := newBulkComboGenerator(player, &proto.BulkSettings{
+		Items: bulkTestItems(990001, 990002, 990003, 990004, 990005, 990006),
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	// 2 helms * 3 ring pairs * 2 trinket slots.
+	if numCombos := gen.NumCombinations(); numCombos != 12 {
+		t.Fatalf("Expected 12 combinations, got %d", numCombos)
+	}
+
+	seen := make(map[string]bool)
+	for comboIdx := 0; comboIdx < gen.NumCombinations(); comboIdx++ {
+		key := ""
+		for _, item := range gen.Combination(comboIdx) {
+			key += item.String() + ";"
+		}
+		if seen[key] {
+			t.Fatalf("Duplicate combination %d: %s", comboIdx, key)
+		}
+		seen[key] = true
+	}
+}
+
+func TestBulkCombinationWeapons(t *testing.T) {
+	player := &proto.Player{
+		Equipment: &proto.EquipmentSpec{
+			Items: make([]*proto.ItemSpec, NumItemSlots),
+		},
+	}
+	for i := range player.Equipment.Items {
+		player.Equipment.Items[i] = &proto.ItemSpec{}
+	}
+	player.Equipment.Items[proto.ItemSlot_ItemSlotOffHand] = &proto.ItemSpec{Id: 990008}
+
+	gen, err := newBulkComboGenerator(player, &proto.BulkSettings{
+		Items: bulkTestItems(990007, 990008, 990009),
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	// Staff alone, plus both orderings of the two one-handers.
+	if numCombos := gen.NumCombinations(); numCombos != 3 {
+		t.Fatalf("Expected 3 combinations, got %d", numCombos)
+	}
+
+	staffCombo := gen.Combination(0)
+	if len(staffCombo) != 2 || staffCombo[1].Slot != proto.ItemSlot_ItemSlotOffHand || staffCombo[1].Item.Id != 0 {
+		t.Fatalf("Expected two-hander to clear the off hand, got %v", staffCombo)
+	}
+}
+
+func TestApplyBulkCombination(t *testing.T) {
+	equipment := &proto.EquipmentSpec{
+		Items: []*proto.ItemSpec{
+			{Id: 990002, Enchant: 4444, UpgradeStep: proto.ItemLevelState_UpgradeStepTwo},
+		},
+	}
+	settings := &proto.BulkSettings{
+		DefaultMetaGem:  1,
+		DefaultRedGem:   2,
+		InheritUpgrades: true,
+	}
+
+	newEquipment, itemsAdded := applyBulkCombination(equipment, []*proto.ItemSpecWithSlot{
+		newBulkItemWithSlot(&proto.ItemSpec{Id: 990001}, proto.ItemSlot_ItemSlotHead),
+	}, settings, false)
+
+	if len(newEquipment.Items) != int(NumItemSlots) {
+		t.Fatalf("Expected equipment to be padded to %d slots, got %d", NumItemSlots, len(newEquipment.Items))
+	}
+
+	helm := newEquipment.Items[proto.ItemSlot_ItemSlotHead]
+	if helm.Id != 990001 || helm.Enchant != 4444 || helm.UpgradeStep != proto.ItemLevelState_UpgradeStepTwo {
+		t.Fatalf("Unexpected helm spec: %s", helm)
+	}
+	if !slices.Equal(helm.Gems, []int32{1, 2}) {
+		t.Fatalf("Expected default gems [1 2], got %v", helm.Gems)
+	}
+	if itemsAdded[0].Item != helm {
+		t.Fatalf("Expected added items to reference the final spec")
+	}
+	if equipment.Items[0].Id != 990002 {
+		t.Fatalf("Original equipment was modified")
+	}
+}
+
+func TestSortBulkComboResults(t *testing.T) {
+	newResults := func() []*proto.BulkComboResult {
+		return []*proto.BulkComboResult{
+			{Dps: 100, Hps: 300},
+			{Dps: 300, Hps: 100},
+			{Dps: 200, Hps: 200},
+		}
+	}
+
+	results := newResults()
+	sortBulkComboResults(results, false)
+	if dps := MapSlice(results, func(r *proto.BulkComboResult) float64 { return r.Dps }); !slices.Equal(dps, []float64{300, 200, 100}) {
+		t.Errorf("Expected results sorted by DPS, got %v", dps)
+	}
+
+	results = newResults()
+	sortBulkComboResults(results, true)
+	if hps := MapSlice(results, func(r *proto.BulkComboResult) float64 { return r.Hps }); !slices.Equal(hps, []float64{300, 200, 100}) {
+		t.Errorf("Expected results sorted by HPS, got %v", hps)
+	}
+}
+
+func TestBulkSimRequiresSimOptions(t *testing.T) {
+	result := runBulkSim(&proto.BulkSimRequest{
+		BaseSettings: &proto.RaidSimRequest{
+			Raid: &proto.Raid{Parties: []*proto.Party{{Players: []*proto.Player{{}}}}},
+		},
+		BulkSettings: &proto.BulkSettings{Items: bulkTestItems(990002)},
+	}, nil, simsignals.CreateSignals())
+	if result.Error == nil || result.Error.Message != "Bulk sim requires sim options!" {
+		t.Fatalf("Expected a sim options error, got %v", result.Error)
+	}
+}
@@ -78,9 +78,15 @@ func (spell *Spell) castFailureHelper(sim *Simulation, message string, vals ...a
 
 	if sim.CurrentTime < 0 && spell.Unit.Rotation != nil {
 		spell.Unit.Rotation.ValidationMessage(proto.LogLevel_Warning, formatString, vals)
-	} else if sim.Log != nil && !spell.Flags.Matches(SpellFlagNoLogs) {
+		return false
+	}
+
+	if sim.Log != nil && !spell.Flags.Matches(SpellFlagNoLogs) {
 		spell.Unit.Log(sim, fmt.Sprintf(formatString, vals...))
 	}
+	if sim.CombatLog != nil && !spell.Flags.Matches(SpellFlagNoLogs) {
+		spell.logCastFailed(sim, fmt.Sprintf(formatString, vals...))
+	}
 
 	return false
 }
@@ -180,6 +186,9 @@ func (spell *Spell) makeCastFunc(config CastConfig) CastSuccessFunc {
 				spell.Unit.Log(sim, "Casting %s (Cost = %0.03f, Cast Time = %s, Effective Time = %s)",
 					spell.ActionID, max(0, spell.CurCast.Cost), spell.CurCast.CastTime, spell.CurCast.EffectiveTime())
 			}
+			if sim.CombatLog != nil && !spell.Flags.Matches(SpellFlagNoLogs) {
+				spell.logCastStart(sim, target, spell.CurCast)
+			}
 
 			spell.Unit.Hardcast = Hardcast{
 				Expires:  sim.CurrentTime + spell.CurCast.CastTime,
@@ -188,6 +197,9 @@ func (spell *Spell) makeCastFunc(config CastConfig) CastSuccessFunc {
 					if sim.Log != nil && !spell.Flags.Matches(SpellFlagNoLogs) {
 						spell.Unit.Log(sim, "Completed cast %s", spell.ActionID)
 					}
+					if sim.CombatLog != nil && !spell.Flags.Matches(SpellFlagNoLogs) {
+						spell.logCastComplete(sim, target)
+					}
 
 					if !spell.CanCompleteCast(sim, target, true) {
 						return
@@ -228,6 +240,9 @@ func (spell *Spell) makeCastFunc(config CastConfig) CastSuccessFunc {
 				spell.ActionID, max(0, spell.CurCast.Cost), spell.CurCast.CastTime, spell.CurCast.EffectiveTime())
 			spell.Unit.Log(sim, "Completed cast %s", spell.ActionID)
 		}
+		if sim.CombatLog != nil && !spell.Flags.Matches(SpellFlagNoLogs) {
+			spell.logInstantCast(sim, target, spell.CurCast)
+		}
 
 		if spell.Cost != nil {
 			spell.Cost.SpendCost(sim, spell)
@@ -312,6 +327,9 @@ func (spell *Spell) makeCastFuncSimple() CastSuccessFunc {
 				spell.ActionID, 0.0, "0s", "0s")
 			spell.Unit.Log(sim, "Completed cast %s", spell.ActionID)
 		}
+		if sim.CombatLog != nil && !spell.Flags.Matches(SpellFlagNoLogs) {
+			spell.logInstantCast(sim, target, Cast{})
+		}
 
 		if spell.MaxCharges > 0 {
 			spell.ConsumeCharge(sim)
@@ -342,6 +360,9 @@ func (spell *Spell) makeCastFuncAutosOrProcs() CastSuccessFunc {
 				spell.ActionID, 0.0, "0s", "0s")
 			spell.Unit.Log(sim, "Completed cast %s", spell.ActionID)
 		}
+		if sim.CombatLog != nil && !spell.Flags.Matches(SpellFlagNoLogs) {
+			spell.logInstantCast(sim, target, Cast{})
+		}
 
 		spell.applyEffects(sim, target)
 
@@ -362,6 +383,9 @@ func (spell *Spell) Proc(sim *Simulation, target *Unit) {
 			spell.ActionID, 0.0, "0s", "0s")
 		spell.Unit.Log(sim, "Completed cast %s", spell.ActionID)
 	}
+	if sim.CombatLog != nil && !spell.Flags.Matches(SpellFlagNoLogs) {
+		spell.logInstantCast(sim, target, Cast{})
+	}
 
 	spell.applyEffects(sim, target)
 
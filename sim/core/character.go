@@ -393,8 +393,12 @@ func (character *Character) DefaultCritMultiplier() float64 {
 
 func (character *Character) AddRaidBuffs(_ *proto.RaidBuffs) {
 }
+func (character *Character) AddRaidMemberBuffs(_ *proto.RaidBuffs) {
+}
 func (character *Character) AddPartyBuffs(partyBuffs *proto.PartyBuffs) {
 }
+func (character *Character) AddDebuffs(_ *proto.Debuffs) {
+}
 
 func (character *Character) initialize(agent Agent) {
 	character.majorCooldownManager.initialize(character)
@@ -699,6 +703,9 @@ func (character *Character) GetMetricsProto() *proto.UnitMetrics {
 	metrics.Name = character.Name
 	metrics.UnitIndex = character.UnitIndex
 	metrics.Auras = character.auraTracker.GetMetricsProto()
+	if character.Rotation != nil {
+		metrics.AplProfile = character.Rotation.getProfileProto(float64(character.Metrics.dps.n))
+	}
 
 	metrics.Pets = make([]*proto.UnitMetrics, len(character.Pets))
 	for i, pet := range character.Pets {
@@ -0,0 +1,114 @@
+package core
+
+import (
+	"github.com/wowsims/mop/sim/core/proto"
+)
+
+// Every emitter below must be guarded by a `sim.CombatLog != nil` check, the
+// same way text logs are guarded by `sim.Log != nil`.
+
+func (unit *Unit) combatLogReference() *proto.UnitReference {
+	switch unit.Type {
+	case PlayerUnit:
+		return &proto.UnitReference{Type: proto.UnitReference_Player, Index: unit.Index}
+	case EnemyUnit:
+		return &proto.UnitReference{Type: proto.UnitReference_Target, Index: unit.Index}
+	}
+
+	// Pets are referenced by their index among their owner's pets, which is
+	// what Environment.GetUnit expects.
+	for _, party := range unit.Env.Raid.Parties {
+		for _, player := range party.Players {
+			character := player.GetCharacter()
+			for petIdx, pet := range character.Pets {
+				if &pet.Unit == unit {
+					return &proto.UnitReference{
+						Type:  proto.UnitReference_Pet,
+						Index: int32(petIdx),
+						Owner: character.combatLogReference(),
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (sim *Simulation) logCombatEvent(source *Unit, target *Unit, event *proto.CombatLogEvent) {
+	event.Timestamp = sim.CurrentTime.Seconds()
+	if source != nil {
+		event.Source = source.combatLogReference()
+	}
+	if target != nil {
+		event.Target = target.combatLogReference()
+	}
+	sim.CombatLog(event)
+}
+
+func (spell *Spell) logCastStart(sim *Simulation, target *Unit, cast Cast) {
+	sim.logCombatEvent(spell.Unit, target, &proto.CombatLogEvent{
+		Type:     proto.CombatLogEventType_CombatLogEventCastStart,
+		ActionId: spell.ActionID.ToProto(),
+		CastTime: cast.CastTime.Seconds(),
+		Cost:     max(0, cast.Cost),
+	})
+}
+
+func (spell *Spell) logCastComplete(sim *Simulation, target *Unit) {
+	sim.logCombatEvent(spell.Unit, target, &proto.CombatLogEvent{
+		Type:     proto.CombatLogEventType_CombatLogEventCastComplete,
+		ActionId: spell.ActionID.ToProto(),
+	})
+}
+
+// Casts that skip the cast time are logged as a start immediately followed by a completion.
+func (spell *Spell) logInstantCast(sim *Simulation, target *Unit, cast Cast) {
+	spell.logCastStart(sim, target, cast)
+	spell.logCastComplete(sim, target)
+}
+
+func (spell *Spell) logCastFailed(sim *Simulation, message string) {
+	sim.logCombatEvent(spell.Unit, nil, &proto.CombatLogEvent{
+		Type:     proto.CombatLogEventType_CombatLogEventCastFailed,
+		ActionId: spell.ActionID.ToProto(),
+		Message:  message,
+	})
+}
+
+func (spell *Spell) logSpellResult(sim *Simulation, result *SpellResult, eventType proto.CombatLogEventType, isPeriodic bool) {
+	sim.logCombatEvent(spell.Unit, result.Target, &proto.CombatLogEvent{
+		Type:        eventType,
+		ActionId:    spell.ActionID.ToProto(),
+		Outcome:     result.Outcome.String(),
+		Amount:      result.Damage,
+		Threat:      result.Threat,
+		Periodic:    isPeriodic,
+		SpellSchool: int32(spell.SpellSchool),
+	})
+}
+
+func (aura *Aura) logCombatEvent(sim *Simulation, eventType proto.CombatLogEventType) {
+	sim.logCombatEvent(aura.Unit, nil, &proto.CombatLogEvent{
+		Type:     eventType,
+		ActionId: aura.ActionID.ToProto(),
+		Stacks:   aura.stacks,
+	})
+}
+
+func (unit *Unit) LogResourceChange(sim *Simulation, metrics *ResourceMetrics, amount float64, before float64, after float64) {
+	sim.logCombatEvent(unit, nil, &proto.CombatLogEvent{
+		Type:           proto.CombatLogEventType_CombatLogEventResourceChange,
+		ActionId:       metrics.ActionID.ToProto(),
+		Amount:         amount,
+		ResourceType:   metrics.Type,
+		ResourceBefore: before,
+		ResourceAfter:  after,
+	})
+}
+
+func (unit *Unit) logUnitEvent(sim *Simulation, eventType proto.CombatLogEventType) {
+	sim.logCombatEvent(unit, nil, &proto.CombatLogEvent{
+		Type:     eventType,
+		Position: unit.DistanceFromTarget,
+	})
+}
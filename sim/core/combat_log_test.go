@@ -0,0 +1,59 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/wowsims/mop/sim/core/proto"
+	"github.com/wowsims/mop/sim/core/stats"
+)
+
+func TestCombatLogAuraEvents(t *testing.T) {
+	var events []*proto.CombatLogEvent
+	sim := Simulation{
+		CombatLog: func(event *proto.CombatLogEvent) {
+			events = append(events, event)
+		},
+	}
+	target := Unit{
+		Type:         EnemyUnit,
+		Index:        1,
+		Level:        93,
+		auraTracker:  newAuraTracker(),
+		initialStats: stats.Stats{stats.Armor: 24835},
+		PseudoStats:  stats.NewPseudoStats(),
+		Metrics:      NewUnitMetrics(),
+	}
+	target.stats = target.initialStats
+
+	aura := WeakenedArmorAura(&target)
+	sim.CurrentTime = time.Second
+	aura.Activate(&sim)
+	aura.SetStacks(&sim, 2)
+	sim.CurrentTime = time.Second * 2
+	aura.Deactivate(&sim)
+
+	expected := []proto.CombatLogEventType{
+		proto.CombatLogEventType_CombatLogEventAuraGained,
+		proto.CombatLogEventType_CombatLogEventAuraStacks,
+		proto.CombatLogEventType_CombatLogEventAuraFaded,
+		proto.CombatLogEventType_CombatLogEventAuraStacks,
+	}
+	if len(events) != len(expected) {
+		t.Fatalf("Expected %d events but got %d: %v", len(expected), len(events), events)
+	}
+	for i, event := range events {
+		if event.Type != expected[i] {
+			t.Fatalf("Event %d should be %s but was %s", i, expected[i], event.Type)
+		}
+		if event.Source.GetType() != proto.UnitReference_Target || event.Source.GetIndex() != 1 {
+			t.Fatalf("Event %d has source %v, expected target 1", i, event.Source)
+		}
+		if event.ActionId.GetSpellId() != aura.ActionID.SpellID {
+			t.Fatalf("Event %d has action %v, expected %s", i, event.ActionId, aura.ActionID)
+		}
+	}
+	if events[1].Stacks != 2 || events[2].Timestamp != 2 || events[3].Stacks != 0 {
+		t.Fatalf("Unexpected stacks or fade time: %v", events)
+	}
+}
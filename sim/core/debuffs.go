@@ -9,6 +9,8 @@ import (
 
 // applyRaidDebuffEffects applies all raid-level debuffs based on the provided Debuffs proto.
 func applyDebuffEffects(target *Unit, targetIdx int, debuffs *proto.Debuffs, raid *proto.Raid) {
+	target.setBuffUptimes(debuffs.Uptimes)
+
 	// –10% Physical damage dealt for 30s
 	if debuffs.WeakenedBlows {
 		MakePermanent(WeakenedBlowsAura(target))
@@ -23,21 +25,28 @@ func applyDebuffEffects(target *Unit, targetIdx int, debuffs *proto.Debuffs, rai
 	if debuffs.WeakenedArmor {
 		aura := MakePermanent(WeakenedArmorAura(target))
 
-		aura.OnReset = func(aura *Aura, sim *Simulation) {
-			// Ferals can require a global to put this up on pull.
-			pa := sim.GetConsumedPendingActionFromPool()
-			pa.NextActionAt = sim.CurrentTime + GCDMin
-			pa.Priority = ActionPriorityDOT
+		if !target.hasPartialBuffUptime(aura.ActionID.SpellID) {
+			aura.OnReset = func(aura *Aura, sim *Simulation) {
+				// Ferals can require a global to put this up on pull.
+				pa := sim.GetConsumedPendingActionFromPool()
+				pa.NextActionAt = sim.CurrentTime + GCDMin
+				pa.Priority = ActionPriorityDOT
 
-			pa.OnAction = func(sim *Simulation) {
-				aura.Activate(sim)
-				aura.SetStacks(sim, 3)
-			}
+				pa.OnAction = func(sim *Simulation) {
+					aura.Activate(sim)
+					aura.SetStacks(sim, 3)
+				}
 
-			sim.AddPendingAction(pa)
+				sim.AddPendingAction(pa)
+			}
 		}
 	}
 
+	// –25% Healing received
+	if debuffs.MortalWounds {
+		MakePermanent(MortalWoundsAura(target))
+	}
+
 	// Spell‐damage‐taken sources
 	if debuffs.FireBreath {
 		MakePermanent(FireBreathDebuff(target))
@@ -71,6 +80,8 @@ func applyDebuffEffects(target *Unit, targetIdx int, debuffs *proto.Debuffs, rai
 	if debuffs.CurseOfEnfeeblement {
 		MakePermanent(CurseOfEnfeeblement(target))
 	}
+
+	applyBuffUptimes(target, debuffs.Uptimes, nil)
 }
 
 const WeakenedBlowsDuration = time.Second * 30
@@ -140,7 +151,7 @@ func WeakenedArmorAura(target *Unit) *Aura {
 }
 
 func MortalWoundsAura(target *Unit) *Aura {
-	return majorHealingReductionAura(target, "Mortal Wounds", 115804, 0.25)
+	return majorHealingReductionAura(target, "Mortal Wounds", 115804, 0.75)
 }
 
 // Spell‐damage‐taken sources
@@ -0,0 +1,28 @@
+package core
+
+import (
+	"testing"
+)
+
+func TestMortalWoundsHealingReduction(t *testing.T) {
+	sim := &Simulation{}
+
+	target := Unit{
+		Type:        EnemyUnit,
+		Index:       0,
+		Level:       93,
+		auraTracker: newAuraTracker(),
+	}
+	target.PseudoStats.HealingTakenMultiplier = 1
+	mortalWounds := MakePermanent(MortalWoundsAura(&target))
+
+	mortalWounds.Activate(sim)
+	if target.PseudoStats.HealingTakenMultiplier != 0.75 {
+		t.Fatalf("Expected Mortal Wounds to reduce healing taken to 75%%, got %0.2f", target.PseudoStats.HealingTakenMultiplier)
+	}
+
+	mortalWounds.Deactivate(sim)
+	if target.PseudoStats.HealingTakenMultiplier != 1 {
+		t.Fatalf("Expected healing taken to be restored to 100%%, got %0.2f", target.PseudoStats.HealingTakenMultiplier)
+	}
+}
@@ -121,6 +121,9 @@ func (eb *energyBar) AddEnergy(sim *Simulation, amount float64, metrics *Resourc
 	if sim.Log != nil {
 		eb.unit.Log(sim, "Gained %0.3f energy from %s (%0.3f --> %0.3f) of %0.0f total.", amount, metrics.ActionID, eb.currentEnergy, newEnergy, eb.maxEnergy)
 	}
+	if sim.CombatLog != nil {
+		eb.unit.LogResourceChange(sim, metrics, amount, eb.currentEnergy, newEnergy)
+	}
 
 	eb.currentEnergy = newEnergy
 }
@@ -136,6 +139,9 @@ func (eb *energyBar) SpendEnergy(sim *Simulation, amount float64, metrics *Resou
 	if sim.Log != nil {
 		eb.unit.Log(sim, "Spent %0.3f energy from %s (%0.3f --> %0.3f) of %0.0f total.", amount, metrics.ActionID, eb.currentEnergy, newEnergy, eb.maxEnergy)
 	}
+	if sim.CombatLog != nil {
+		eb.unit.LogResourceChange(sim, metrics, -amount, eb.currentEnergy, newEnergy)
+	}
 
 	eb.currentEnergy = newEnergy
 }
@@ -224,6 +230,9 @@ func (eb *energyBar) AddComboPoints(sim *Simulation, pointsToAdd int32, target *
 	if sim.Log != nil {
 		eb.unit.Log(sim, "Gained %d %s from %s (%d --> %d) of %0.0f total.", pointsToAdd, eb.comboPointsResourceName, metrics.ActionID, eb.comboPoints, newComboPoints, eb.maxComboPoints)
 	}
+	if sim.CombatLog != nil {
+		eb.unit.LogResourceChange(sim, metrics, float64(pointsToAdd), float64(eb.comboPoints), float64(newComboPoints))
+	}
 
 	eb.comboPoints = newComboPoints
 }
@@ -243,6 +252,9 @@ func (eb *energyBar) spendComboPointsInternal(sim *Simulation, pointsToSpend int
 		eb.unit.Log(sim, "Spent %d %s from %s (%d --> %d) of %0.0f total.", pointsToSpend, eb.comboPointsResourceName, metrics.ActionID, eb.comboPoints, newComboPoints, eb.maxComboPoints)
 	}
 	metrics.AddEvent(float64(-pointsToSpend), float64(-pointsToSpend))
+	if sim.CombatLog != nil {
+		eb.unit.LogResourceChange(sim, metrics, float64(-pointsToSpend), float64(eb.comboPoints), float64(newComboPoints))
+	}
 	eb.comboPoints = newComboPoints
 }
 
@@ -93,10 +93,9 @@ func (env *Environment) construct(raidProto *proto.Raid, encounterProto *proto.E
 	}
 
 	// Apply extra debuffs from raid.
-	if raidProto.Debuffs != nil && len(env.Encounter.AllTargetUnits) > 0 {
-		for targetIdx, targetUnit := range env.Encounter.AllTargetUnits {
-			applyDebuffEffects(targetUnit, targetIdx, raidProto.Debuffs, raidProto)
-		}
+	debuffs := env.Raid.GetDebuffs(raidProto.Debuffs)
+	for targetIdx, targetUnit := range env.Encounter.AllTargetUnits {
+		applyDebuffEffects(targetUnit, targetIdx, debuffs, raidProto)
 	}
 
 	tankTargetSet := map[*Unit]bool{}
@@ -149,6 +148,8 @@ func (env *Environment) initialize(raidProto *proto.Raid, encounterProto *proto.
 		}
 	}
 
+	env.applyRaidDamageProfile(raidProto, encounterProto.RaidDamage)
+
 	env.State = Initialized
 	return raidStats
 }
@@ -180,13 +181,14 @@ func (env *Environment) finalize(raidProto *proto.Raid, _ *proto.Encounter, raid
 
 	for partyIdx, party := range env.Raid.Parties {
 		partyProto := raidProto.Parties[partyIdx]
-		for playerIdx, player := range party.Players {
-			if playerIdx >= len(partyProto.Players) {
-				// This happens for target dummies.
+		for _, player := range party.Players {
+			if _, isDummy := player.(*TargetDummy); isDummy {
 				continue
 			}
-			playerProto := partyProto.Players[playerIdx]
+			// Players are indexed by their slot in the party, which can differ
+			// from their index in party.Players when there are empty slots.
 			char := player.GetCharacter()
+			playerProto := partyProto.Players[char.PartyIndex]
 			char.Rotation = char.newAPLRotation(playerProto.Rotation)
 		}
 	}
@@ -432,9 +434,11 @@ func (env *Environment) PrepullStartTime(sim *Simulation) time.Duration {
 		panic("Env not yet finalized")
 	}
 
-	if len(env.prepullActions) == 0 {
-		return 0
-	} else {
-		return min(env.prepullActions[0].doAtTime, 0)
+	// Prepull actions are only sorted once the first iteration has started, so
+	// don't rely on their order here.
+	startTime := time.Duration(0)
+	for _, action := range env.prepullActions {
+		startTime = min(startTime, action.doAtTime)
 	}
+	return startTime
 }
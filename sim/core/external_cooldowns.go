@@ -0,0 +1,93 @@
+package core
+
+import (
+	"time"
+
+	"github.com/wowsims/mop/sim/core/proto"
+)
+
+// How long after one of the player's own cooldowns is cast an aligned external
+// cooldown may still be used alongside it, for cooldowns without a buff.
+const externalCooldownAlignmentWindow = time.Second * 2
+
+// Applies user-specified schedules to external cooldowns, so they are cast at
+// fixed timings or together with one of the player's own cooldowns instead of
+// whenever they are available.
+func applyExternalCooldownSchedules(character *Character, schedules []*proto.ExternalCooldownSchedule) {
+	for _, schedule := range schedules {
+		mcd := character.getInitialMajorCooldownBySpellID(schedule.SpellId)
+		if mcd == nil {
+			continue
+		}
+
+		if len(schedule.Timings) > 0 {
+			// Timings are matched to the cooldown when it is finalized, in the same
+			// way as the user's timings for their own cooldowns. These take
+			// precedence over any existing timings.
+			character.cooldownConfigs.Cooldowns = append([]*proto.Cooldown{{
+				Id:      mcd.Spell.ActionID.ToProto(),
+				Timings: schedule.Timings,
+			}}, character.cooldownConfigs.Cooldowns...)
+			mcd.ShouldActivate = func(_ *Simulation, _ *Character) bool {
+				return false
+			}
+		} else if schedule.AlignWith != nil {
+			alignExternalCooldown(character, mcd, ProtoToActionID(schedule.AlignWith))
+		}
+	}
+}
+
+func alignExternalCooldown(character *Character, mcd *MajorCooldown, alignWith ActionID) {
+	var aligned *Spell
+	character.Env.RegisterPostFinalizeEffect(func() {
+		aligned = character.GetSpell(alignWith)
+	})
+
+	mcd.alignWith = alignWith
+	mcd.ShouldActivate = func(sim *Simulation, _ *Character) bool {
+		if aligned == nil {
+			return false
+		}
+		if aligned.RelatedSelfBuff != nil {
+			return aligned.RelatedSelfBuff.IsActive()
+		}
+		// Without a buff to check, look for a cooldown that was started recently.
+		return aligned.CD.Timer != nil && !aligned.CD.IsReady(sim) &&
+			aligned.CD.Duration-aligned.CD.TimeToReady(sim) <= externalCooldownAlignmentWindow
+	}
+}
+
+func (character *Character) getInitialMajorCooldownBySpellID(spellID int32) *MajorCooldown {
+	for i := range character.initialMajorCooldowns {
+		if character.initialMajorCooldowns[i].Spell.ActionID.SpellID == spellID {
+			return &character.initialMajorCooldowns[i]
+		}
+	}
+	return nil
+}
+
+// Roughly how long until the buff from an external cooldown will next be
+// active, or 0 if it is currently active.
+func (mcd *MajorCooldown) timeToNextExternalWindow(sim *Simulation, character *Character) time.Duration {
+	if buff := mcd.Spell.RelatedSelfBuff; buff != nil && buff.IsActive() {
+		return 0
+	}
+
+	if len(mcd.timings) > 0 && mcd.numUsages >= len(mcd.timings) {
+		// All scheduled casts have been used.
+		return NeverExpires
+	}
+
+	timeToNext := mcd.TimeToNextCast(sim)
+	if mcd.alignWith.IsEmptyAction() {
+		return timeToNext
+	}
+
+	if aligned := character.GetMajorCooldown(mcd.alignWith); aligned != nil {
+		return max(timeToNext, aligned.TimeToNextCast(sim))
+	}
+	if aligned := character.GetSpell(mcd.alignWith); aligned != nil {
+		return max(timeToNext, aligned.TimeToReady(sim))
+	}
+	return NeverExpires
+}
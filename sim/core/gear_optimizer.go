@@ -0,0 +1,677 @@
+package core
+
+import (
+	"fmt"
+	"math"
+	"runtime/debug"
+	"slices"
+	"sort"
+
+	"github.com/wowsims/mop/sim/core/proto"
+	"github.com/wowsims/mop/sim/core/simsignals"
+	"github.com/wowsims/mop/sim/core/stats"
+	googleProto "google.golang.org/protobuf/proto"
+)
+
+// Each capped stat adds a dimension to the solver state, so only a couple are allowed.
+const MaxGearOptimizerCaps = 2
+
+// Size of the bonus stat used to measure how gear stats convert into capped stats.
+const gearOptimizerProbeAmount = 1000.0
+
+type gearOptimizerCap struct {
+	stat          stats.Stat
+	cap           int32
+	weight        float64
+	postCapWeight float64
+
+	// Change to the capped stat per point of each gear stat, which picks up
+	// conversions like Spirit to Hit.
+	conversions stats.Stats
+}
+
+// A single reforge and gem choice for one item.
+type gearOptimizerConfig struct {
+	reforging int32
+	gems      []int32
+
+	delta  stats.Stats                 // Stat change relative to the stripped item.
+	score  float64                     // Value of the uncapped stats in delta.
+	capped [MaxGearOptimizerCaps]int32 // Change to each capped stat.
+}
+
+type gearOptimizerSlot struct {
+	slot    proto.ItemSlot
+	configs []gearOptimizerConfig
+}
+
+type gearOptimizer struct {
+	weights stats.Stats // Weights of uncapped stats. Capped stats are 0 here.
+	caps    []gearOptimizerCap
+	gems    []Gem
+	frozen  []proto.ItemSlot
+}
+
+func newGearOptimizer(request *proto.GearOptimizationRequest, weights stats.Stats) (*gearOptimizer, error) {
+	opt := &gearOptimizer{
+		weights: weights,
+		frozen:  request.FrozenSlots,
+	}
+
+	capValues := stats.FromProtoArray(request.StatCaps.GetStats())
+	postCapWeights := stats.FromProtoArray(request.PostCapWeights.GetStats())
+	for stat, capValue := range capValues {
+		if capValue <= 0 {
+			continue
+		}
+		if len(opt.caps) == MaxGearOptimizerCaps {
+			return nil, fmt.Errorf("at most %d stats can be capped", MaxGearOptimizerCaps)
+		}
+		if weights[stat] < 0 || postCapWeights[stat] < 0 || postCapWeights[stat] > weights[stat] {
+			return nil, fmt.Errorf("weights for capped stat %s must satisfy 0 <= post-cap weight <= weight", stats.Stat(stat).StatName())
+		}
+		opt.caps = append(opt.caps, gearOptimizerCap{
+			stat:          stats.Stat(stat),
+			cap:           int32(math.Round(capValue)),
+			weight:        weights[stat],
+			postCapWeight: postCapWeights[stat],
+		})
+		opt.weights[stat] = 0
+	}
+
+	for _, gemID := range request.GemIds {
+		gem, ok := GemsByID[gemID]
+		if !ok {
+			return nil, fmt.Errorf("no gem with id: %d", gemID)
+		}
+		if gem.Color == proto.GemColor_GemColorMeta || gem.Color == proto.GemColor_GemColorCogwheel || gem.Color == proto.GemColor_GemColorShaTouched {
+			return nil, fmt.Errorf("gem %d does not fit in a regular socket", gemID)
+		}
+		if request.Player.ChallengeMode && gem.DisabledInChallengeMode {
+			continue
+		}
+		opt.gems = append(opt.gems, gem)
+	}
+
+	return opt, nil
+}
+
+func (opt *gearOptimizer) isOptimizedSlot(slot proto.ItemSlot, spec *proto.ItemSpec) bool {
+	return spec != nil && spec.Id != 0 && !slices.Contains(opt.frozen, slot)
+}
+
+// Indices of the sockets whose gems are chosen by the optimizer. Meta, cogwheel
+// and sha-touched sockets keep their gems.
+func (opt *gearOptimizer) optimizedSockets(item *Item, numGems int) []int {
+	if len(opt.gems) == 0 {
+		return nil
+	}
+
+	numSockets := max(len(item.GemSockets), numGems)
+	sockets := make([]int, 0, numSockets)
+	for socketIdx := 0; socketIdx < numSockets; socketIdx++ {
+		if socketIdx < len(item.GemSockets) {
+			switch item.GemSockets[socketIdx] {
+			case proto.GemColor_GemColorMeta, proto.GemColor_GemColorCogwheel, proto.GemColor_GemColorShaTouched:
+				continue
+			}
+		}
+		sockets = append(sockets, socketIdx)
+	}
+	return sockets
+}
+
+// Returns the equipment with reforges and optimized gems removed from every slot being optimized.
+func (opt *gearOptimizer) stripEquipment(equipment *proto.EquipmentSpec) *proto.EquipmentSpec {
+	stripped := googleProto.Clone(equipment).(*proto.EquipmentSpec)
+	for len(stripped.Items) < int(NumItemSlots) {
+		stripped.Items = append(stripped.Items, &proto.ItemSpec{})
+	}
+
+	for slotIdx, spec := range stripped.Items {
+		if !opt.isOptimizedSlot(proto.ItemSlot(slotIdx), spec) {
+			continue
+		}
+		spec.Reforging = 0
+
+		item := GetItemByID(spec.Id)
+		if item == nil {
+			continue
+		}
+		sockets := opt.optimizedSockets(item, len(spec.Gems))
+		if len(sockets) > 0 && len(spec.Gems) <= sockets[len(sockets)-1] {
+			spec.Gems = append(spec.Gems, make([]int32, sockets[len(sockets)-1]+1-len(spec.Gems))...)
+		}
+		for _, socketIdx := range sockets {
+			spec.Gems[socketIdx] = 0
+		}
+	}
+	return stripped
+}
+
+// Stats which can be changed by a reforge, gem or socket bonus.
+func (opt *gearOptimizer) affectedStats(equipment *proto.EquipmentSpec) []stats.Stat {
+	affected := make([]bool, stats.SimStatsLen)
+	for _, reforge := range ReforgeStatsByID {
+		affected[reforge.FromStat] = true
+		affected[reforge.ToStat] = true
+	}
+	for _, gem := range opt.gems {
+		for stat, value := range gem.Stats {
+			affected[stat] = affected[stat] || value != 0
+		}
+	}
+	for slotIdx, spec := range equipment.Items {
+		if !opt.isOptimizedSlot(proto.ItemSlot(slotIdx), spec) || len(opt.gems) == 0 {
+			continue
+		}
+		if item := GetItemByID(spec.Id); item != nil {
+			for stat, value := range item.SocketBonus {
+				affected[stat] = affected[stat] || value != 0
+			}
+		}
+	}
+
+	var result []stats.Stat
+	for stat, isAffected := range affected {
+		if isAffected {
+			result = append(result, stats.Stat(stat))
+		}
+	}
+	return result
+}
+
+func (opt *gearOptimizer) evaluate(config *gearOptimizerConfig) {
+	config.score = 0
+	for stat, value := range config.delta {
+		config.score += value * opt.weights[stat]
+	}
+	for capIdx, capInfo := range opt.caps {
+		total := 0.0
+		for stat, value := range config.delta {
+			total += value * capInfo.conversions[stat]
+		}
+		config.capped[capIdx] = int32(math.Round(total))
+	}
+}
+
+// Returns whether a is at least as good as b in every way the solver cares about.
+func (opt *gearOptimizer) dominates(a, b *gearOptimizerConfig) bool {
+	if a.score < b.score {
+		return false
+	}
+	for capIdx := range opt.caps {
+		if a.capped[capIdx] < b.capped[capIdx] {
+			return false
+		}
+	}
+	return true
+}
+
+// Removes options which can never be part of an optimal solution. Ties keep the earliest option.
+func (opt *gearOptimizer) pruneConfigs(configs []gearOptimizerConfig) []gearOptimizerConfig {
+	sort.SliceStable(configs, func(i, j int) bool {
+		return configs[i].score > configs[j].score
+	})
+
+	kept := make([]gearOptimizerConfig, 0, len(configs))
+	for i := range configs {
+		if !slices.ContainsFunc(kept, func(other gearOptimizerConfig) bool {
+			return opt.dominates(&other, &configs[i])
+		}) {
+			kept = append(kept, configs[i])
+		}
+	}
+	return kept
+}
+
+// Builds every worthwhile reforge and gem option for a single stripped item.
+func (opt *gearOptimizer) slotConfigs(spec *proto.ItemSpec) []gearOptimizerConfig {
+	item := NewItem(ItemSpec{
+		ID:            spec.Id,
+		RandomSuffix:  spec.RandomSuffix,
+		Enchant:       spec.Enchant,
+		Tinker:        spec.Tinker,
+		Gems:          spec.Gems,
+		UpgradeStep:   spec.UpgradeStep,
+		ChallengeMode: spec.ChallengeMode,
+	})
+	baseStats := ItemEquipmentBaseStats(item)
+	baseGemStats := ItemEquipmentGemAndEnchantStats(item)
+
+	reforges := []gearOptimizerConfig{{}}
+	reforgeIDs := make([]int32, 0, len(ReforgeStatsByID))
+	for id := range ReforgeStatsByID {
+		reforgeIDs = append(reforgeIDs, id)
+	}
+	slices.Sort(reforgeIDs)
+	for _, id := range reforgeIDs {
+		reforge := ReforgeStatsByID[id]
+		if !validateReforging(&item, reforge) {
+			continue
+		}
+		reforgedItem := item
+		reforgedItem.Reforging = &reforge
+		reforges = append(reforges, gearOptimizerConfig{
+			reforging: id,
+			delta:     ItemEquipmentBaseStats(reforgedItem).Subtract(baseStats),
+		})
+	}
+
+	gemOptions := []gearOptimizerConfig{{gems: spec.Gems}}
+	if sockets := opt.optimizedSockets(&item, len(spec.Gems)); len(sockets) > 0 {
+		gemOptions = opt.gemConfigs(item, spec.Gems, sockets, baseGemStats)
+	}
+
+	configs := make([]gearOptimizerConfig, 0, len(reforges)*len(gemOptions))
+	for _, reforge := range reforges {
+		for _, gemOption := range gemOptions {
+			config := gearOptimizerConfig{
+				reforging: reforge.reforging,
+				gems:      gemOption.gems,
+				delta:     reforge.delta.Add(gemOption.delta),
+			}
+			opt.evaluate(&config)
+			configs = append(configs, config)
+		}
+	}
+	return opt.pruneConfigs(configs)
+}
+
+// Enumerates gem assignments for the given sockets. Each socket only considers
+// gems which aren't dominated by another gem with the same socket color match.
+func (opt *gearOptimizer) gemConfigs(item Item, gems []int32, sockets []int, baseGemStats stats.Stats) []gearOptimizerConfig {
+	candidates := make([][]Gem, len(sockets))
+	for i, socketIdx := range sockets {
+		socketColor := proto.GemColor_GemColorPrismatic
+		if socketIdx < len(item.GemSockets) {
+			socketColor = item.GemSockets[socketIdx]
+		}
+
+		var matching, other []gearOptimizerConfig
+		for _, gem := range opt.gems {
+			config := gearOptimizerConfig{gems: []int32{gem.ID}, delta: gem.Stats}
+			opt.evaluate(&config)
+			if ColorIntersects(socketColor, gem.Color) {
+				matching = append(matching, config)
+			} else {
+				other = append(other, config)
+			}
+		}
+		for _, config := range append(opt.pruneConfigs(matching), opt.pruneConfigs(other)...) {
+			candidates[i] = append(candidates[i], GemsByID[config.gems[0]])
+		}
+	}
+
+	var configs []gearOptimizerConfig
+	choice := make([]int, len(sockets))
+	for {
+		gemItem := item
+		gemItem.Gems = slices.Clone(item.Gems)
+		config := gearOptimizerConfig{gems: slices.Clone(gems)}
+		for i, socketIdx := range sockets {
+			gemItem.Gems[socketIdx] = candidates[i][choice[i]]
+			config.gems[socketIdx] = candidates[i][choice[i]].ID
+		}
+		config.delta = ItemEquipmentGemAndEnchantStats(gemItem).Subtract(baseGemStats)
+		configs = append(configs, config)
+
+		// Advance to the next assignment, mixed-radix style.
+		i := 0
+		for ; i < len(choice); i++ {
+			choice[i]++
+			if choice[i] < len(candidates[i]) {
+				break
+			}
+			choice[i] = 0
+		}
+		if i == len(choice) {
+			break
+		}
+	}
+	return configs
+}
+
+type gearOptimizerState struct {
+	capped [MaxGearOptimizerCaps]int32
+	score  float64
+	parent int32
+	config int32
+}
+
+// Adds the value of any capped stat beyond limit to the score and clamps it.
+// Past the limit every point is guaranteed to end up above the cap, so only
+// its post-cap value matters.
+func (opt *gearOptimizer) clampState(state *gearOptimizerState, limits [MaxGearOptimizerCaps]int32) {
+	for capIdx, capInfo := range opt.caps {
+		if excess := state.capped[capIdx] - limits[capIdx]; excess > 0 {
+			state.score += float64(excess) * capInfo.postCapWeight
+			state.capped[capIdx] = limits[capIdx]
+		}
+	}
+}
+
+func (opt *gearOptimizer) stateValue(state *gearOptimizerState) float64 {
+	value := state.score
+	for capIdx, capInfo := range opt.caps {
+		amount := state.capped[capIdx]
+		value += float64(min(amount, capInfo.cap)) * capInfo.weight
+		value += float64(max(amount-capInfo.cap, 0)) * capInfo.postCapWeight
+	}
+	return value
+}
+
+// Keeps only the states which aren't dominated by another state, i.e. one with
+// at least the same score and at least as much of every capped stat. The first
+// capped stat is indexed by a Fenwick tree holding the max of the second.
+func pruneGearOptimizerStates(states []gearOptimizerState) []gearOptimizerState {
+	if len(states) == 0 {
+		return states
+	}
+
+	sort.Slice(states, func(i, j int) bool {
+		if states[i].score != states[j].score {
+			return states[i].score > states[j].score
+		}
+		if states[i].capped[0] != states[j].capped[0] {
+			return states[i].capped[0] > states[j].capped[0]
+		}
+		return states[i].capped[1] > states[j].capped[1]
+	})
+
+	minCapped, maxCapped := states[0].capped[0], states[0].capped[0]
+	for _, state := range states {
+		minCapped = min(minCapped, state.capped[0])
+		maxCapped = max(maxCapped, state.capped[0])
+	}
+
+	// Index 1 holds the largest value of the first capped stat.
+	tree := make([]int32, maxCapped-minCapped+2)
+	for i := range tree {
+		tree[i] = math.MinInt32
+	}
+	query := func(capped int32) int32 {
+		best := int32(math.MinInt32)
+		for i := maxCapped - capped + 1; i > 0; i -= i & -i {
+			best = max(best, tree[i])
+		}
+		return best
+	}
+	update := func(capped int32, value int32) {
+		for i := maxCapped - capped + 1; i < int32(len(tree)); i += i & -i {
+			tree[i] = max(tree[i], value)
+		}
+	}
+
+	kept := states[:0]
+	for _, state := range states {
+		if query(state.capped[0]) >= state.capped[1] {
+			continue
+		}
+		update(state.capped[0], state.capped[1])
+		kept = append(kept, state)
+	}
+	return kept
+}
+
+// Picks one config per slot, maximizing the total value with capped stats.
+// Returns the chosen config index for each slot and the value gained relative
+// to base, which holds the capped stat totals before any configs are applied.
+func (opt *gearOptimizer) solve(slots []gearOptimizerSlot, base [MaxGearOptimizerCaps]int32) ([]int, float64) {
+	// How far each capped stat can still drop from the remaining slots.
+	remainingLoss := make([][MaxGearOptimizerCaps]int32, len(slots)+1)
+	for slotIdx := len(slots) - 1; slotIdx >= 0; slotIdx-- {
+		remainingLoss[slotIdx] = remainingLoss[slotIdx+1]
+		for capIdx := range opt.caps {
+			maxLoss := int32(0)
+			for _, config := range slots[slotIdx].configs {
+				maxLoss = max(maxLoss, -config.capped[capIdx])
+			}
+			remainingLoss[slotIdx][capIdx] += maxLoss
+		}
+	}
+	limits := func(slotIdx int) [MaxGearOptimizerCaps]int32 {
+		var result [MaxGearOptimizerCaps]int32
+		for capIdx, capInfo := range opt.caps {
+			result[capIdx] = capInfo.cap + remainingLoss[slotIdx][capIdx]
+		}
+		return result
+	}
+
+	initial := gearOptimizerState{capped: base, parent: -1, config: -1}
+	baseValue := opt.stateValue(&initial)
+	opt.clampState(&initial, limits(0))
+
+	layers := make([][]gearOptimizerState, len(slots)+1)
+	layers[0] = []gearOptimizerState{initial}
+	for slotIdx, slot := range slots {
+		slotLimits := limits(slotIdx + 1)
+		next := make([]gearOptimizerState, 0, len(layers[slotIdx])*len(slot.configs))
+		for parentIdx, parent := range layers[slotIdx] {
+			for configIdx, config := range slot.configs {
+				state := gearOptimizerState{
+					score:  parent.score + config.score,
+					parent: int32(parentIdx),
+					config: int32(configIdx),
+				}
+				for capIdx := range opt.caps {
+					state.capped[capIdx] = parent.capped[capIdx] + config.capped[capIdx]
+				}
+				opt.clampState(&state, slotLimits)
+				next = append(next, state)
+			}
+		}
+		layers[slotIdx+1] = pruneGearOptimizerStates(next)
+	}
+
+	final := layers[len(slots)]
+	bestIdx := 0
+	bestValue := math.Inf(-1)
+	for stateIdx := range final {
+		if value := opt.stateValue(&final[stateIdx]); value > bestValue {
+			bestIdx = stateIdx
+			bestValue = value
+		}
+	}
+
+	choices := make([]int, len(slots))
+	stateIdx := int32(bestIdx)
+	for slotIdx := len(slots); slotIdx > 0; slotIdx-- {
+		state := layers[slotIdx][stateIdx]
+		choices[slotIdx-1] = int(state.config)
+		stateIdx = state.parent
+	}
+	return choices, bestValue - baseValue
+}
+
+func gearOptimizerFinalStats(request *proto.GearOptimizationRequest, player *proto.Player) stats.Stats {
+	raidProto := SinglePlayerRaidProto(googleProto.Clone(player).(*proto.Player), request.PartyBuffs, request.RaidBuffs, request.Debuffs)
+	raidProto.Tanks = request.Tanks
+
+	encounter := request.Encounter
+	if encounter == nil {
+		encounter = &proto.Encounter{}
+	}
+
+	_, raidStats, _ := NewEnvironment(raidProto, encounter, true)
+	return stats.FromProtoArray(raidStats.Parties[0].Players[0].FinalStats.Stats)
+}
+
+// Runs a single optimization pass with fixed weights, returning the new equipment and its score.
+func (opt *gearOptimizer) optimize(request *proto.GearOptimizationRequest, equipment *proto.EquipmentSpec) (*proto.EquipmentSpec, float64) {
+	stripped := opt.stripEquipment(equipment)
+	player := googleProto.Clone(request.Player).(*proto.Player)
+	player.Equipment = stripped
+	baseStats := gearOptimizerFinalStats(request, player)
+
+	if len(opt.caps) > 0 {
+		if player.BonusStats == nil {
+			player.BonusStats = &proto.UnitStats{}
+		}
+		if len(player.BonusStats.Stats) < stats.ProtoStatsLen {
+			player.BonusStats.Stats = append(player.BonusStats.Stats, make([]float64, stats.ProtoStatsLen-len(player.BonusStats.Stats))...)
+		}
+
+		for _, stat := range opt.affectedStats(stripped) {
+			if int(stat) >= stats.ProtoStatsLen {
+				continue
+			}
+			player.BonusStats.Stats[stat] += gearOptimizerProbeAmount
+			probeStats := gearOptimizerFinalStats(request, player)
+			player.BonusStats.Stats[stat] -= gearOptimizerProbeAmount
+
+			for capIdx := range opt.caps {
+				capStat := opt.caps[capIdx].stat
+				opt.caps[capIdx].conversions[stat] = (probeStats[capStat] - baseStats[capStat]) / gearOptimizerProbeAmount
+			}
+		}
+	}
+
+	var base [MaxGearOptimizerCaps]int32
+	for capIdx, capInfo := range opt.caps {
+		base[capIdx] = int32(math.Round(baseStats[capInfo.stat]))
+	}
+
+	var slots []gearOptimizerSlot
+	for slotIdx, spec := range stripped.Items {
+		if opt.isOptimizedSlot(proto.ItemSlot(slotIdx), spec) {
+			slots = append(slots, gearOptimizerSlot{
+				slot:    proto.ItemSlot(slotIdx),
+				configs: opt.slotConfigs(spec),
+			})
+		}
+	}
+
+	choices, score := opt.solve(slots, base)
+	for slotIdx, slot := range slots {
+		config := slot.configs[choices[slotIdx]]
+		spec := stripped.Items[slot.slot]
+		spec.Reforging = config.reforging
+		spec.Gems = slices.Clone(config.gems)
+	}
+	return stripped, score
+}
+
+func gearOptimizerStatWeightsRequest(request *proto.GearOptimizationRequest, equipment *proto.EquipmentSpec, weights stats.Stats) *proto.StatWeightsRequest {
+	player := googleProto.Clone(request.Player).(*proto.Player)
+	player.Equipment = equipment
+
+	swr := &proto.StatWeightsRequest{
+		Player:          player,
+		RaidBuffs:       request.RaidBuffs,
+		PartyBuffs:      request.PartyBuffs,
+		Debuffs:         request.Debuffs,
+		Encounter:       request.Encounter,
+		SimOptions:      googleProto.Clone(request.SimOptions).(*proto.SimOptions),
+		Tanks:           request.Tanks,
+		EpReferenceStat: request.EpReferenceStat,
+	}
+	for stat, weight := range weights {
+		if weight != 0 && stat < stats.ProtoStatsLen {
+			swr.StatsToWeigh = append(swr.StatsToWeigh, proto.Stat(stat))
+		}
+	}
+	return swr
+}
+
+func gearOptimizerWeightValues(result *proto.StatWeightsResult, metric proto.StatWeightMetric) *proto.StatWeightValues {
+	switch metric {
+	case proto.StatWeightMetric_StatWeightMetricHps:
+		return result.Hps
+	case proto.StatWeightMetric_StatWeightMetricTps:
+		return result.Tps
+	case proto.StatWeightMetric_StatWeightMetricDtps:
+		return result.Dtps
+	case proto.StatWeightMetric_StatWeightMetricTmi:
+		return result.Tmi
+	default:
+		return result.Dps
+	}
+}
+
+// Finds the best reforges and gems for the request's equipment, optionally
+// re-simming stat weights on the result and optimizing again.
+func runOptimizeGear(request *proto.GearOptimizationRequest, progress chan *proto.ProgressMetrics, signals simsignals.Signals) (result *proto.GearOptimizationResult) {
+	errorResult := func(message string) *proto.GearOptimizationResult {
+		return &proto.GearOptimizationResult{Error: &proto.ErrorOutcome{Message: message}}
+	}
+
+	defer func() {
+		if err := recover(); err != nil {
+			errStr := ""
+			switch errt := err.(type) {
+			case string:
+				errStr = errt
+			case error:
+				errStr = errt.Error()
+			}
+
+			errStr += "\nStack Trace:\n" + string(debug.Stack())
+			result = errorResult(errStr)
+		}
+	}()
+
+	if request.Player == nil || request.Player.Equipment == nil {
+		return errorResult("Gear optimization requires a player with equipment!")
+	}
+	if request.WeightIterations > 0 && request.SimOptions == nil {
+		return errorResult("Weight iterations require sim options!")
+	}
+	if request.Player.Database != nil {
+		addToDatabase(request.Player.Database)
+	}
+
+	weights := stats.FromProtoArray(request.StatWeights.GetStats())
+	capValues := stats.FromProtoArray(request.StatCaps.GetStats())
+
+	opt, err := newGearOptimizer(request, weights)
+	if err != nil {
+		return errorResult(err.Error())
+	}
+	equipment, score := opt.optimize(request, request.Player.Equipment)
+
+	var iterations int32
+	for iterations < request.WeightIterations {
+		if signals.Abort.IsTriggered() {
+			return &proto.GearOptimizationResult{Error: &proto.ErrorOutcome{Type: proto.ErrorOutcomeType_ErrorOutcomeAborted}}
+		}
+
+		swr := gearOptimizerStatWeightsRequest(request, equipment, weights)
+		swResult := runStatWeights(swr, progress, signals)
+		if swResult.Error != nil {
+			return &proto.GearOptimizationResult{Error: swResult.Error}
+		}
+		iterations++
+
+		// Capped stats keep their requested weight, since the sim can only
+		// measure their value on one side of the cap.
+		epValues := stats.FromProtoArray(gearOptimizerWeightValues(swResult, request.StatWeightMetric).EpValues.Stats)
+		for _, stat := range swr.StatsToWeigh {
+			if capValues[stat] <= 0 {
+				weights[stat] = epValues[stat]
+			}
+		}
+
+		if opt, err = newGearOptimizer(request, weights); err != nil {
+			return errorResult(err.Error())
+		}
+		newEquipment, newScore := opt.optimize(request, equipment)
+		score = newScore
+		if googleProto.Equal(newEquipment, equipment) {
+			break
+		}
+		equipment = newEquipment
+	}
+
+	player := googleProto.Clone(request.Player).(*proto.Player)
+	player.Equipment = equipment
+	finalStats := gearOptimizerFinalStats(request, player)
+
+	return &proto.GearOptimizationResult{
+		Equipment:        equipment,
+		FinalStats:       &proto.UnitStats{Stats: finalStats.ToProtoArray()},
+		StatWeights:      &proto.UnitStats{Stats: weights.ToProtoArray()},
+		Score:            score,
+		WeightIterations: iterations,
+	}
+}
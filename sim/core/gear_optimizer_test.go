@@ -0,0 +1,337 @@
+package core
+
+import (
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/wowsims/mop/sim/core/proto"
+	"github.com/wowsims/mop/sim/core/stats"
+)
+
+func init() {
+	agiStats := make([]float64, stats.ProtoStatsLen)
+	agiStats[stats.Agility] = 80
+	hitStats := make([]float64, stats.ProtoStatsLen)
+	hitStats[stats.HitRating] = 160
+	hybridStats := make([]float64, stats.ProtoStatsLen)
+	hybridStats[stats.Agility] = 40
+	hybridStats[stats.HitRating] = 70
+	bonusStats := make([]float64, stats.ProtoStatsLen)
+	bonusStats[stats.Agility] = 60
+	expertiseStats := make([]float64, stats.ProtoStatsLen)
+	expertiseStats[stats.ExpertiseRating] = 160
+	critStats := make([]float64, stats.ProtoStatsLen)
+	critStats[stats.CritRating] = 160
+
+	addToDatabase(&proto.SimDatabase{
+		Items: []*proto.SimItem{
+			{
+				Id:          990101,
+				Name:        "Optimizer Test Belt",
+				Type:        proto.ItemType_ItemTypeWaist,
+				GemSockets:  []proto.GemColor{proto.GemColor_GemColorRed, proto.GemColor_GemColorYellow},
+				SocketBonus: bonusStats,
+				ScalingOptions: map[int32]*proto.ScalingItemProperties{
+					0: {Stats: map[int32]float64{int32(stats.Agility): 500}},
+				},
+			},
+			{
+				Id:          990102,
+				Name:        "Optimizer Test Helm",
+				Type:        proto.ItemType_ItemTypeHead,
+				GemSockets:  []proto.GemColor{proto.GemColor_GemColorBlue},
+				SocketBonus: critStats,
+				ScalingOptions: map[int32]*proto.ScalingItemProperties{
+					0: {Stats: map[int32]float64{int32(stats.CritRating): 310, int32(stats.HasteRating): 205}},
+				},
+			},
+			{
+				Id:   990103,
+				Name: "Optimizer Test Ring",
+				Type: proto.ItemType_ItemTypeFinger,
+				ScalingOptions: map[int32]*proto.ScalingItemProperties{
+					0: {Stats: map[int32]float64{int32(stats.ExpertiseRating): 190, int32(stats.MasteryRating): 145}},
+				},
+			},
+			{
+				Id:         990104,
+				Name:       "Optimizer Test Boots",
+				Type:       proto.ItemType_ItemTypeFeet,
+				GemSockets: []proto.GemColor{proto.GemColor_GemColorYellow},
+				ScalingOptions: map[int32]*proto.ScalingItemProperties{
+					0: {Stats: map[int32]float64{int32(stats.HitRating): 230, int32(stats.CritRating): 120}},
+				},
+			},
+		},
+		Gems: []*proto.SimGem{
+			{Id: 990111, Name: "Optimizer Test Red", Color: proto.GemColor_GemColorRed, Stats: agiStats},
+			{Id: 990112, Name: "Optimizer Test Yellow", Color: proto.GemColor_GemColorYellow, Stats: hitStats},
+			{Id: 990113, Name: "Optimizer Test Orange", Color: proto.GemColor_GemColorOrange, Stats: hybridStats},
+			{Id: 990114, Name: "Optimizer Test Blue", Color: proto.GemColor_GemColorBlue, Stats: expertiseStats},
+			{Id: 990115, Name: "Optimizer Test Crit", Color: proto.GemColor_GemColorYellow, Stats: critStats},
+		},
+		ReforgeStats: []*proto.ReforgeStat{
+			{Id: 990201, FromStat: proto.Stat_StatCritRating, ToStat: proto.Stat_StatHitRating, Multiplier: 0.4},
+			{Id: 990202, FromStat: proto.Stat_StatCritRating, ToStat: proto.Stat_StatExpertiseRating, Multiplier: 0.4},
+			{Id: 990203, FromStat: proto.Stat_StatHasteRating, ToStat: proto.Stat_StatHitRating, Multiplier: 0.4},
+			{Id: 990204, FromStat: proto.Stat_StatMasteryRating, ToStat: proto.Stat_StatHitRating, Multiplier: 0.4},
+			{Id: 990205, FromStat: proto.Stat_StatExpertiseRating, ToStat: proto.Stat_StatCritRating, Multiplier: 0.4},
+			{Id: 990206, FromStat: proto.Stat_StatHitRating, ToStat: proto.Stat_StatHasteRating, Multiplier: 0.4},
+			{Id: 990207, FromStat: proto.Stat_StatHitRating, ToStat: proto.Stat_StatExpertiseRating, Multiplier: 0.4},
+		},
+	})
+}
+
+func newTestGearOptimizer(weights stats.Stats, caps stats.Stats, gemIDs ...int32) *gearOptimizer {
+	opt, err := newGearOptimizer(&proto.GearOptimizationRequest{
+		Player:   &proto.Player{},
+		StatCaps: &proto.UnitStats{Stats: caps.ToProtoArray()},
+		GemIds:   gemIDs,
+	}, weights)
+	if err != nil {
+		panic(err)
+	}
+	for capIdx := range opt.caps {
+		opt.caps[capIdx].conversions[opt.caps[capIdx].stat] = 1
+	}
+	return opt
+}
+
+func TestGearOptimizerSolveCap(t *testing.T) {
+	var weights, caps stats.Stats
+	weights[stats.HitRating] = 2
+	weights[stats.CritRating] = 1
+	caps[stats.HitRating] = 100
+	opt := newTestGearOptimizer(weights, caps)
+
+	// Each slot can trade 60 crit for 60 hit. Two trades get closest to the cap.
+	slot := gearOptimizerSlot{configs: []gearOptimizerConfig{
+		{},
+		{reforging: 1, score: -60, capped: [MaxGearOptimizerCaps]int32{60}},
+	}}
+	choices, score := opt.solve([]gearOptimizerSlot{slot, slot, slot}, [MaxGearOptimizerCaps]int32{})
+	if numReforged := len(slices.DeleteFunc(choices, func(c int) bool { return c == 0 })); numReforged != 2 {
+		t.Fatalf("Expected 2 reforged slots, got %d", numReforged)
+	}
+	if score != 80 {
+		t.Fatalf("Expected score 80, got %f", score)
+	}
+}
+
+func TestGearOptimizerSolveOverCap(t *testing.T) {
+	var weights, caps stats.Stats
+	weights[stats.HitRating] = 2
+	weights[stats.CritRating] = 1
+	caps[stats.HitRating] = 100
+	opt := newTestGearOptimizer(weights, caps)
+
+	// Starting 50 over the cap, only one slot should reforge away from hit.
+	slot := gearOptimizerSlot{configs: []gearOptimizerConfig{
+		{},
+		{reforging: 1, score: 40, capped: [MaxGearOptimizerCaps]int32{-40}},
+	}}
+	choices, score := opt.solve([]gearOptimizerSlot{slot, slot}, [MaxGearOptimizerCaps]int32{150})
+	if numReforged := len(slices.DeleteFunc(choices, func(c int) bool { return c == 0 })); numReforged != 1 {
+		t.Fatalf("Expected 1 reforged slot, got %d", numReforged)
+	}
+	if score != 40 {
+		t.Fatalf("Expected score 40, got %f", score)
+	}
+}
+
+func TestGearOptimizerGems(t *testing.T) {
+	var weights, caps stats.Stats
+	weights[stats.Agility] = 1
+	weights[stats.HitRating] = 0.4
+	spec := &proto.ItemSpec{Id: 990101, Gems: []int32{0, 0}}
+
+	// Without a cap, matching the yellow socket with an orange gem is worth it for the bonus.
+	opt := newTestGearOptimizer(weights, caps, 990111, 990112, 990113)
+	configs := opt.slotConfigs(spec)
+	if !slices.Equal(configs[0].gems, []int32{990111, 990113}) {
+		t.Fatalf("Expected red and orange gems, got %v", configs[0].gems)
+	}
+
+	// Hit is worth more than agility up to the cap, which a single yellow gem reaches.
+	weights[stats.HitRating] = 2
+	caps[stats.HitRating] = 160
+	opt = newTestGearOptimizer(weights, caps, 990111, 990112, 990113)
+	slot := gearOptimizerSlot{configs: opt.slotConfigs(spec)}
+	choices, score := opt.solve([]gearOptimizerSlot{slot}, [MaxGearOptimizerCaps]int32{})
+	if gems := slot.configs[choices[0]].gems; !slices.Equal(gems, []int32{990111, 990112}) {
+		t.Fatalf("Expected red and yellow gems, got %v", gems)
+	}
+	if score != 460 {
+		t.Fatalf("Expected score 460, got %f", score)
+	}
+}
+
+func TestGearOptimizerWeightMetric(t *testing.T) {
+	result := &proto.StatWeightsResult{
+		Dps:  &proto.StatWeightValues{EpValues: &proto.UnitStats{Stats: []float64{1}}},
+		Hps:  &proto.StatWeightValues{EpValues: &proto.UnitStats{Stats: []float64{2}}},
+		Tps:  &proto.StatWeightValues{EpValues: &proto.UnitStats{Stats: []float64{3}}},
+		Dtps: &proto.StatWeightValues{EpValues: &proto.UnitStats{Stats: []float64{4}}},
+		Tmi:  &proto.StatWeightValues{EpValues: &proto.UnitStats{Stats: []float64{5}}},
+	}
+
+	testCases := []struct {
+		metric   proto.StatWeightMetric
+		expected *proto.StatWeightValues
+	}{
+		{proto.StatWeightMetric_StatWeightMetricDps, result.Dps},
+		{proto.StatWeightMetric_StatWeightMetricHps, result.Hps},
+		{proto.StatWeightMetric_StatWeightMetricTps, result.Tps},
+		{proto.StatWeightMetric_StatWeightMetricDtps, result.Dtps},
+		{proto.StatWeightMetric_StatWeightMetricTmi, result.Tmi},
+	}
+	if len(testCases) != len(proto.StatWeightMetric_name) {
+		t.Fatalf("Expected a test case for each of the %d stat weight metrics", len(proto.StatWeightMetric_name))
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.metric.String(), func(t *testing.T) {
+			if actual := gearOptimizerWeightValues(result, testCase.metric); actual != testCase.expected {
+				t.Errorf("Expected EP values %v but got %v", testCase.expected.EpValues.Stats, actual.EpValues.Stats)
+			}
+		})
+	}
+}
+
+// Finds the best reforges and gems by trying every combination, returning the
+// value gained relative to the stripped items.
+func bruteForceGearOptimizer(opt *gearOptimizer, specs []*proto.ItemSpec, base stats.Stats) float64 {
+	var itemOptions [][]stats.Stats
+	for _, spec := range specs {
+		stripped := NewItem(ItemSpec{ID: spec.Id, Gems: spec.Gems})
+		strippedStats := ItemEquipmentBaseStats(stripped).Add(ItemEquipmentGemAndEnchantStats(stripped))
+
+		reforgeIDs := []int32{0}
+		for id, reforge := range ReforgeStatsByID {
+			if validateReforging(&stripped, reforge) {
+				reforgeIDs = append(reforgeIDs, id)
+			}
+		}
+
+		gemCombos := [][]int32{slices.Clone(spec.Gems)}
+		for socketIdx := range opt.optimizedSockets(&stripped, len(spec.Gems)) {
+			var next [][]int32
+			for _, combo := range gemCombos {
+				for _, gem := range opt.gems {
+					combo := slices.Clone(combo)
+					combo[socketIdx] = gem.ID
+					next = append(next, combo)
+				}
+			}
+			gemCombos = next
+		}
+
+		var options []stats.Stats
+		for _, reforgeID := range reforgeIDs {
+			for _, gems := range gemCombos {
+				item := NewItem(ItemSpec{ID: spec.Id, Gems: gems, Reforging: reforgeID})
+				options = append(options, ItemEquipmentBaseStats(item).Add(ItemEquipmentGemAndEnchantStats(item)).Subtract(strippedStats))
+			}
+		}
+		itemOptions = append(itemOptions, options)
+	}
+
+	value := func(delta stats.Stats) float64 {
+		total := 0.0
+		for stat, amount := range delta {
+			total += amount * opt.weights[stat]
+		}
+		for _, capInfo := range opt.caps {
+			amount := base[capInfo.stat] + delta[capInfo.stat]
+			total += min(amount, float64(capInfo.cap)) * capInfo.weight
+			total += max(amount-float64(capInfo.cap), 0) * capInfo.postCapWeight
+		}
+		return total
+	}
+
+	best := math.Inf(-1)
+	var search func(itemIdx int, delta stats.Stats)
+	search = func(itemIdx int, delta stats.Stats) {
+		if itemIdx == len(itemOptions) {
+			best = max(best, value(delta))
+			return
+		}
+		for _, option := range itemOptions[itemIdx] {
+			search(itemIdx+1, delta.Add(option))
+		}
+	}
+	search(0, stats.Stats{})
+	return best - value(stats.Stats{})
+}
+
+func TestGearOptimizerMatchesBruteForce(t *testing.T) {
+	var weights stats.Stats
+	weights[stats.Agility] = 1
+	weights[stats.HitRating] = 1.6
+	weights[stats.ExpertiseRating] = 1.3
+	weights[stats.CritRating] = 0.7
+	weights[stats.HasteRating] = 0.5
+	weights[stats.MasteryRating] = 0.4
+
+	specs := []*proto.ItemSpec{
+		{Id: 990101, Gems: []int32{0, 0}},
+		{Id: 990102, Gems: []int32{0}},
+		{Id: 990103},
+		{Id: 990104, Gems: []int32{0}},
+	}
+	gemIDs := []int32{990111, 990112, 990113, 990114, 990115}
+
+	testCases := []struct {
+		name           string
+		caps           stats.Stats
+		postCapWeights stats.Stats
+		base           stats.Stats
+	}{
+		{
+			name: "NoCaps",
+		},
+		{
+			name:           "HitCap",
+			caps:           stats.Stats{stats.HitRating: 300},
+			postCapWeights: stats.Stats{stats.HitRating: 0.2},
+		},
+		{
+			name:           "HitAndExpertiseCaps",
+			caps:           stats.Stats{stats.HitRating: 300, stats.ExpertiseRating: 350},
+			postCapWeights: stats.Stats{stats.HitRating: 0.2, stats.ExpertiseRating: 0.1},
+		},
+		{
+			name:           "StartingNearCaps",
+			caps:           stats.Stats{stats.HitRating: 300, stats.ExpertiseRating: 350},
+			postCapWeights: stats.Stats{stats.ExpertiseRating: 0.9},
+			base:           stats.Stats{stats.HitRating: 250, stats.ExpertiseRating: 300},
+		},
+		{
+			name: "StartingOverCap",
+			caps: stats.Stats{stats.HitRating: 300},
+			base: stats.Stats{stats.HitRating: 400},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			opt := newTestGearOptimizer(weights, testCase.caps, gemIDs...)
+			var base [MaxGearOptimizerCaps]int32
+			for capIdx := range opt.caps {
+				opt.caps[capIdx].postCapWeight = testCase.postCapWeights[opt.caps[capIdx].stat]
+				base[capIdx] = int32(testCase.base[opt.caps[capIdx].stat])
+			}
+
+			var slots []gearOptimizerSlot
+			for _, spec := range specs {
+				slots = append(slots, gearOptimizerSlot{configs: opt.slotConfigs(spec)})
+			}
+			_, score := opt.solve(slots, base)
+
+			if expected := bruteForceGearOptimizer(opt, specs, testCase.base); math.Abs(score-expected) > 1e-6 {
+				t.Errorf("Expected score %0.2f from brute force, but the solver found %0.2f", expected, score)
+			}
+		})
+	}
+}
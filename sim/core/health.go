@@ -57,6 +57,9 @@ func (hb *healthBar) GainHealth(sim *Simulation, amount float64, metrics *Resour
 	if sim.Log != nil {
 		hb.unit.Log(sim, "Gained %0.3f health from %s (%0.3f --> %0.3f) of %0.0f total.", amount, metrics.ActionID, oldHealth, newHealth, hb.MaxHealth())
 	}
+	if sim.CombatLog != nil {
+		hb.unit.LogResourceChange(sim, metrics, amount, oldHealth, newHealth)
+	}
 
 	hb.currentHealth = newHealth
 }
@@ -83,6 +86,9 @@ func (hb *healthBar) RemoveHealth(sim *Simulation, amount float64) {
 	if sim.Log != nil {
 		hb.unit.Log(sim, "Spent %0.3f health from %s (%0.3f --> %0.3f) of %0.0f total.", amount, metrics.ActionID, oldHealth, newHealth, hb.MaxHealth())
 	}
+	if sim.CombatLog != nil {
+		hb.unit.LogResourceChange(sim, metrics, -amount, oldHealth, newHealth)
+	}
 
 	hb.currentHealth = newHealth
 }
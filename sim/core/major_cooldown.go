@@ -67,6 +67,10 @@ type MajorCooldown struct {
 	// are used instead of ShouldActivate.
 	timings []time.Duration
 
+	// For external cooldowns, one of the player's own cooldowns to cast this
+	// alongside.
+	alignWith ActionID
+
 	// Number of times this MCD was used so far in the current iteration.
 	numUsages int
 
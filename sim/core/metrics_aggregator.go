@@ -89,6 +89,7 @@ type UnitMetrics struct {
 	dtps   DistributionMetrics
 	tmi    DistributionMetrics
 	hps    DistributionMetrics
+	aps    DistributionMetrics
 	tto    DistributionMetrics
 
 	tmiList   []tmiListItem
@@ -175,6 +176,7 @@ type SpellMetrics struct {
 	TotalThreat            float64 // Threat generated by all casts of this spell.
 	TotalHealing           float64 // Healing done by all casts of this spell.
 	TotalCritHealing       float64 // Healing done by all critical casts of this spell.
+	TotalOverhealing       float64 // Healing done by all casts of this spell in excess of the target's missing health.
 	TotalShielding         float64 // Shielding done by all casts of this spell.
 	TotalCastTime          time.Duration
 }
@@ -206,6 +208,7 @@ type TargetedActionMetrics struct {
 	Threat            float64
 	Healing           float64
 	CritHealing       float64
+	Overhealing       float64
 	Shielding         float64
 	CastTime          time.Duration
 }
@@ -237,6 +240,7 @@ func (tam *TargetedActionMetrics) ToProto() *proto.TargetedActionMetrics {
 		Threat:            tam.Threat,
 		Healing:           tam.Healing,
 		CritHealing:       tam.CritHealing,
+		Overhealing:       tam.Overhealing,
 		Shielding:         tam.Shielding,
 		CastTimeMs:        float64(tam.CastTime.Milliseconds()),
 	}
@@ -249,6 +253,7 @@ func NewUnitMetrics() UnitMetrics {
 		dtps:    NewDistributionMetrics(),
 		tmi:     NewDistributionMetrics(),
 		hps:     NewDistributionMetrics(),
+		aps:     NewDistributionMetrics(),
 		tto:     NewDistributionMetrics(),
 		actions: make(map[ActionID]*ActionMetrics),
 	}
@@ -398,6 +403,7 @@ func (unitMetrics *UnitMetrics) addSpellMetrics(spell *Spell, actionID ActionID,
 		tam.Threat += spellTargetMetrics.TotalThreat
 		tam.Healing += spellTargetMetrics.TotalHealing
 		tam.CritHealing += spellTargetMetrics.TotalCritHealing
+		tam.Overhealing += spellTargetMetrics.TotalOverhealing
 		tam.Shielding += spellTargetMetrics.TotalShielding
 		if !spell.Flags.Matches(SpellFlagPassiveSpell) {
 			tam.CastTime += spellTargetMetrics.TotalCastTime
@@ -411,6 +417,7 @@ func (unitMetrics *UnitMetrics) addSpellMetrics(spell *Spell, actionID ActionID,
 			unitMetrics.threat.Total += spellTargetMetrics.TotalThreat
 		} else {
 			unitMetrics.hps.Total += spellTargetMetrics.TotalHealing + spellTargetMetrics.TotalShielding
+			unitMetrics.aps.Total += spellTargetMetrics.TotalShielding
 		}
 	}
 }
@@ -445,6 +452,7 @@ func (unitMetrics *UnitMetrics) reset() {
 	unitMetrics.tmi.reset()
 	unitMetrics.tmiList = nil
 	unitMetrics.hps.reset()
+	unitMetrics.aps.reset()
 	unitMetrics.tto.reset()
 	unitMetrics.CharacterIterationMetrics = CharacterIterationMetrics{}
 
@@ -493,6 +501,7 @@ func (unitMetrics *UnitMetrics) doneIteration(unit *Unit, sim *Simulation) {
 	unitMetrics.dtps.doneIteration(sim)
 	unitMetrics.tmi.doneIteration(sim)
 	unitMetrics.hps.doneIteration(sim)
+	unitMetrics.aps.doneIteration(sim)
 	unitMetrics.tto.doneIteration(sim)
 
 	unitMetrics.oomTimeSum += unitMetrics.OOMTime.Seconds()
@@ -587,6 +596,7 @@ func (unitMetrics *UnitMetrics) ToProto() *proto.UnitMetrics {
 		Dtps:          unitMetrics.dtps.ToProto(),
 		Tmi:           unitMetrics.tmi.ToProto(),
 		Hps:           unitMetrics.hps.ToProto(),
+		Aps:           unitMetrics.aps.ToProto(),
 		Tto:           unitMetrics.tto.ToProto(),
 		SecondsOomAvg: unitMetrics.oomTimeSum / n,
 		ChanceOfDeath: float64(unitMetrics.numItersDead) / n,
@@ -110,6 +110,9 @@ func (unit *Unit) FinalizeMovement(sim *Simulation) {
 	unit.moveAura.Deactivate(sim)
 
 	unit.OnMovement(sim, unit.DistanceFromTarget, MovementEnd)
+	if sim.CombatLog != nil {
+		unit.logUnitEvent(sim, proto.CombatLogEventType_CombatLogEventMovementEnd)
+	}
 }
 
 func registerMovementAction(unit *Unit, sim *Simulation, speed float64, endTime time.Duration) {
@@ -131,6 +134,9 @@ func registerMovementAction(unit *Unit, sim *Simulation, speed float64, endTime
 	}
 
 	unit.OnMovement(sim, unit.DistanceFromTarget, MovementStart)
+	if sim.CombatLog != nil {
+		unit.logUnitEvent(sim, proto.CombatLogEventType_CombatLogEventMovementStart)
+	}
 	unit.movementAction = &movementAction
 	sim.AddPendingAction(&movementAction.PendingAction)
 }
@@ -282,6 +282,9 @@ func (pet *Pet) Enable(sim *Simulation, petAgent PetAgent) {
 		pet.Log(sim, "Pet inherited stats: %s", pet.ApplyStatDependencies(pet.inheritedStats).FlatString())
 		pet.Log(sim, "Pet summoned")
 	}
+	if sim.CombatLog != nil {
+		pet.logUnitEvent(sim, proto.CombatLogEventType_CombatLogEventPetSummoned)
+	}
 
 	sim.addTracker(&pet.auraTracker)
 
@@ -439,6 +442,9 @@ func (pet *Pet) Disable(sim *Simulation) {
 		pet.Log(sim, "Pet dismissed")
 		pet.Log(sim, pet.GetStats().FlatString())
 	}
+	if sim.CombatLog != nil {
+		pet.logUnitEvent(sim, proto.CombatLogEventType_CombatLogEventPetDismissed)
+	}
 }
 
 func (pet *Pet) ChangeStatInheritance(nonHitExpStatInheritance PetStatInheritance) {
@@ -457,10 +463,12 @@ func (pet *Pet) DisableOnStart() {
 func (pet *Pet) GetCharacter() *Character {
 	return &pet.Character
 }
-func (pet *Pet) AddRaidBuffs(_ *proto.RaidBuffs)   {}
-func (pet *Pet) AddPartyBuffs(_ *proto.PartyBuffs) {}
-func (pet *Pet) ApplyTalents()                     {}
-func (pet *Pet) OnGCDReady(_ *Simulation)          {}
+func (pet *Pet) AddRaidBuffs(_ *proto.RaidBuffs)       {}
+func (pet *Pet) AddRaidMemberBuffs(_ *proto.RaidBuffs) {}
+func (pet *Pet) AddPartyBuffs(_ *proto.PartyBuffs)     {}
+func (pet *Pet) AddDebuffs(_ *proto.Debuffs)           {}
+func (pet *Pet) ApplyTalents()                         {}
+func (pet *Pet) OnGCDReady(_ *Simulation)              {}
 
 func (env *Environment) TriggerDelayedPetInheritance(sim *Simulation, dynamicPets []*Pet, inheritanceFunc func(*Simulation, *Pet)) {
 	for _, pet := range dynamicPets {
@@ -1,6 +1,7 @@
 package core
 
 import (
+	"cmp"
 	"slices"
 
 	"github.com/wowsims/mop/sim/core/proto"
@@ -160,6 +161,23 @@ func (raid *Raid) GetLowestHealthAllyUnit() *Unit {
 	return lowestHealthUnit
 }
 
+// Returns up to n active allies ordered by health percent, lowest first. Units
+// without a health bar, such as target dummies, count as full health and keep
+// their raid order.
+func (raid *Raid) GetLowestHealthAllyUnits(n int) []*Unit {
+	allyUnits := raid.GetActiveAllyUnits()
+	healthPercent := func(unit *Unit) float64 {
+		if !unit.HasHealthBar() {
+			return 1
+		}
+		return unit.CurrentHealthPercent()
+	}
+	slices.SortStableFunc(allyUnits, func(a, b *Unit) int {
+		return cmp.Compare(healthPercent(a), healthPercent(b))
+	})
+	return allyUnits[:min(n, len(allyUnits))]
+}
+
 // Makes a new raid.
 func NewRaid(raidConfig *proto.Raid) *Raid {
 	numParties := int(raidConfig.NumActiveParties)
@@ -263,9 +281,47 @@ func (raid *Raid) GetRaidBuffs(baseRaidBuffs *proto.RaidBuffs) *proto.RaidBuffs
 			player.GetCharacter().AddRaidBuffs(raidBuffs)
 		}
 	}
+	if raid.hasMultiplePlayers() {
+		for _, party := range raid.Parties {
+			for _, player := range party.Players {
+				player.AddRaidMemberBuffs(raidBuffs)
+			}
+		}
+	}
 	return raidBuffs
 }
 
+func (raid *Raid) GetDebuffs(baseDebuffs *proto.Debuffs) *proto.Debuffs {
+	// Compute the full debuffs from the raid.
+	debuffs := &proto.Debuffs{}
+	if baseDebuffs != nil {
+		debuffs = googleProto.Clone(baseDebuffs).(*proto.Debuffs)
+	}
+	if raid.hasMultiplePlayers() {
+		for _, party := range raid.Parties {
+			for _, player := range party.Players {
+				player.AddDebuffs(debuffs)
+			}
+		}
+	}
+	return debuffs
+}
+
+// Whether the raid has more than one player, not counting target dummies.
+// Buffs and debuffs are only derived from the raid composition in that case,
+// so that individual sims keep using their buff settings as they are.
+func (raid *Raid) hasMultiplePlayers() bool {
+	numPlayers := 0
+	for _, party := range raid.Parties {
+		for _, player := range party.Players {
+			if _, isDummy := player.(*TargetDummy); !isDummy {
+				numPlayers++
+			}
+		}
+	}
+	return numPlayers > 1
+}
+
 // Precompute the playersAndPets array for each party.
 func (raid *Raid) updatePlayersAndPets() {
 	var raidPlayers []*Unit
@@ -312,18 +368,17 @@ func (raid *Raid) applyCharacterEffects(raidConfig *proto.Raid) *proto.RaidStats
 		}
 
 		// Apply all buffs to the players in this party.
-		for playerIdx, player := range party.Players {
-			if playerIdx >= len(partyConfig.Players) {
-				// This happens for target dummies.
+		for _, player := range party.Players {
+			if _, isDummy := player.(*TargetDummy); isDummy {
 				continue
 			}
-			playerConfig := partyConfig.Players[playerIdx]
+			char := player.GetCharacter()
+			playerConfig := partyConfig.Players[char.PartyIndex]
 			individualBuffs := &proto.IndividualBuffs{}
 			if playerConfig.Buffs != nil {
 				individualBuffs = playerConfig.Buffs
 			}
 
-			char := player.GetCharacter()
 			char.EnableHealthBar()
 			char.trackChanceOfDeath(playerConfig.HealingModel)
 			partyStats.Players[char.PartyIndex] = char.applyAllEffects(player, raidBuffs, partyBuffs, individualBuffs)
@@ -425,9 +480,24 @@ func (raid *Raid) GetMetrics() *proto.RaidMetrics {
 	for _, party := range raid.Parties {
 		metrics.Parties = append(metrics.Parties, party.GetMetrics())
 	}
+	setRaidDpsShares(metrics)
 	return metrics
 }
 
+// Breaks the raid's DPS down into the share done by each player.
+func setRaidDpsShares(metrics *proto.RaidMetrics) {
+	if metrics.Dps.Avg <= 0 {
+		return
+	}
+	for _, party := range metrics.Parties {
+		for _, player := range party.Players {
+			if player.Dps != nil {
+				player.RaidDpsShare = player.Dps.Avg / metrics.Dps.Avg
+			}
+		}
+	}
+}
+
 func SinglePlayerRaidProto(player *proto.Player, partyBuffs *proto.PartyBuffs, raidBuffs *proto.RaidBuffs, debuffs *proto.Debuffs) *proto.Raid {
 	return &proto.Raid{
 		Parties: []*proto.Party{
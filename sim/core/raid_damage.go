@@ -0,0 +1,145 @@
+package core
+
+import (
+	"time"
+
+	"github.com/wowsims/mop/sim/core/proto"
+	"github.com/wowsims/mop/sim/core/stats"
+)
+
+// Sets up the incoming damage described by the encounter's raid damage
+// profile, so that healers have a realistic damage pattern to heal through.
+// Target dummies are given health so they can be damaged and healed like
+// real players.
+func (env *Environment) applyRaidDamageProfile(raidProto *proto.Raid, profile *proto.RaidDamageProfile) {
+	if profile == nil || len(env.Encounter.AllTargetUnits) == 0 || len(env.Raid.AllPlayerUnits) == 0 {
+		return
+	}
+
+	dummyHealth := profile.TargetDummyHealth
+	for _, dummy := range env.Raid.GetTargetDummies() {
+		if dummyHealth <= 0 {
+			dummyHealth = dummy.baseStats[stats.Health]
+		}
+		dummy.AddStat(stats.Health, dummyHealth)
+		dummy.EnableHealthBar()
+		dummy.trackChanceOfDeath(nil)
+	}
+
+	source := env.Encounter.AllTargetUnits[0]
+	raidUnits := env.Raid.AllPlayerUnits
+
+	rollDamage := func(sim *Simulation, damage float64) float64 {
+		if profile.DamageVariance <= 0 {
+			return damage
+		}
+		return damage * (1 + profile.DamageVariance*(2*sim.RandomFloat("Raid Damage Variance")-1))
+	}
+
+	var pulseSpell, spikeSpell, tankSpell *Spell
+	var tank *Unit
+
+	if profile.PulseDamage > 0 && profile.PulseInterval > 0 {
+		pulseSpell = source.RegisterSpell(SpellConfig{
+			ActionID:    ActionID{OtherID: proto.OtherAction_OtherActionRaidDamage, Tag: 1},
+			SpellSchool: SpellSchoolShadow,
+			ProcMask:    ProcMaskSpellDamage,
+			Flags:       SpellFlagAoE | SpellFlagIgnoreAttackerModifiers | SpellFlagNoOnCastComplete,
+
+			DamageMultiplier: 1,
+
+			ApplyEffects: func(sim *Simulation, _ *Unit, spell *Spell) {
+				for _, unit := range raidUnits {
+					spell.CalcAndDealDamage(sim, unit, rollDamage(sim, profile.PulseDamage), spell.OutcomeAlwaysHit)
+				}
+			},
+		})
+	}
+
+	if profile.SpikeDamage > 0 && profile.SpikeInterval > 0 {
+		spikeSpell = source.RegisterSpell(SpellConfig{
+			ActionID:    ActionID{OtherID: proto.OtherAction_OtherActionRaidDamage, Tag: 2},
+			SpellSchool: SpellSchoolShadow,
+			ProcMask:    ProcMaskSpellDamage,
+			Flags:       SpellFlagIgnoreAttackerModifiers | SpellFlagNoOnCastComplete,
+
+			DamageMultiplier: 1,
+
+			ApplyEffects: func(sim *Simulation, target *Unit, spell *Spell) {
+				spell.CalcAndDealDamage(sim, target, rollDamage(sim, profile.SpikeDamage), spell.OutcomeAlwaysHit)
+			},
+		})
+	}
+
+	if profile.TankSwingDamage > 0 && profile.TankSwingSpeed > 0 {
+		if len(raidProto.Tanks) > 0 {
+			tank = env.GetUnit(raidProto.Tanks[0], nil)
+		}
+		if tank == nil {
+			if dummy := env.Raid.GetFirstTargetDummy(); dummy != nil {
+				tank = &dummy.Unit
+			} else {
+				tank = raidUnits[0]
+			}
+		}
+
+		tankSpell = source.RegisterSpell(SpellConfig{
+			ActionID:    ActionID{OtherID: proto.OtherAction_OtherActionRaidDamage, Tag: 3},
+			SpellSchool: SpellSchoolPhysical,
+			ProcMask:    ProcMaskMeleeMHAuto,
+			Flags:       SpellFlagMeleeMetrics | SpellFlagIgnoreAttackerModifiers | SpellFlagNoOnCastComplete,
+
+			DamageMultiplier: 1,
+
+			ApplyEffects: func(sim *Simulation, target *Unit, spell *Spell) {
+				spell.CalcAndDealDamage(sim, target, rollDamage(sim, profile.TankSwingDamage), spell.OutcomeAlwaysHit)
+			},
+		})
+	}
+
+	if pulseSpell == nil && spikeSpell == nil && tankSpell == nil {
+		return
+	}
+
+	var scheduleSpike func(sim *Simulation)
+	scheduleSpike = func(sim *Simulation) {
+		gap := DurationFromSeconds(2 * profile.SpikeInterval * sim.RandomFloat("Raid Damage Spike"))
+		pa := sim.GetConsumedPendingActionFromPool()
+		pa.NextActionAt = sim.CurrentTime + max(gap, time.Millisecond)
+		pa.OnAction = func(sim *Simulation) {
+			target := raidUnits[int(sim.RandomFloat("Raid Damage Spike Target")*float64(len(raidUnits)))]
+			spikeSpell.SkipCastAndApplyEffects(sim, target)
+			scheduleSpike(sim)
+		}
+		sim.AddPendingAction(pa)
+	}
+
+	source.RegisterAura(Aura{
+		Label:    "Raid Damage Profile",
+		Duration: NeverExpires,
+		OnReset: func(aura *Aura, sim *Simulation) {
+			aura.Activate(sim)
+		},
+		OnEncounterStart: func(_ *Aura, sim *Simulation) {
+			if pulseSpell != nil {
+				StartPeriodicAction(sim, PeriodicActionOptions{
+					Period: DurationFromSeconds(profile.PulseInterval),
+					OnAction: func(sim *Simulation) {
+						pulseSpell.SkipCastAndApplyEffects(sim, raidUnits[0])
+					},
+				})
+			}
+			if spikeSpell != nil {
+				scheduleSpike(sim)
+			}
+			if tankSpell != nil {
+				StartPeriodicAction(sim, PeriodicActionOptions{
+					Period: DurationFromSeconds(profile.TankSwingSpeed),
+					OnAction: func(sim *Simulation) {
+						tankSpell.SkipCastAndApplyEffects(sim, tank)
+					},
+				})
+			}
+		},
+	})
+}
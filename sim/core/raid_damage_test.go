@@ -0,0 +1,95 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/wowsims/mop/sim/core/proto"
+)
+
+type raidDamageHit struct {
+	timestamp float64
+	target    int32
+	amount    float64
+}
+
+func runRaidDamageProfile(t *testing.T, profile *proto.RaidDamageProfile) []raidDamageHit {
+	raid := SinglePlayerRaidProto(&proto.Player{
+		Name:      "Healer",
+		Class:     proto.Class_ClassShaman,
+		Spec:      &proto.Player_ElementalShaman{},
+		Equipment: &proto.EquipmentSpec{},
+	}, &proto.PartyBuffs{}, &proto.RaidBuffs{}, &proto.Debuffs{})
+	raid.TargetDummies = 1
+
+	result := RunRaidSim(&proto.RaidSimRequest{
+		Raid: raid,
+		Encounter: &proto.Encounter{
+			Duration:   12,
+			Targets:    []*proto.Target{{Name: "target", Level: 93, MobType: proto.MobType_MobTypeDemon}},
+			RaidDamage: profile,
+		},
+		SimOptions: &proto.SimOptions{
+			Iterations:          1,
+			RandomSeed:          101,
+			DebugFirstIteration: true,
+			CombatLog:           true,
+		},
+	})
+	if result.Error != nil {
+		t.Fatal(result.Error.Message)
+	}
+
+	var hits []raidDamageHit
+	for _, event := range result.CombatLog {
+		if event.Type == proto.CombatLogEventType_CombatLogEventDamage && event.ActionId.GetOtherId() == proto.OtherAction_OtherActionRaidDamage {
+			hits = append(hits, raidDamageHit{event.Timestamp, event.Target.GetIndex(), event.Amount})
+		}
+	}
+	return hits
+}
+
+func TestRaidDamageProfile(t *testing.T) {
+	hits := runRaidDamageProfile(t, &proto.RaidDamageProfile{
+		PulseDamage:     1000,
+		PulseInterval:   5,
+		TankSwingDamage: 2000,
+		TankSwingSpeed:  3,
+	})
+
+	// Without a raid tank, the target dummy (raid index 1) takes the tank swings.
+	expected := []raidDamageHit{
+		{3, 1, 2000},
+		{5, 0, 1000},
+		{5, 1, 1000},
+		{6, 1, 2000},
+		{9, 1, 2000},
+		{10, 0, 1000},
+		{10, 1, 1000},
+		{12, 1, 2000},
+	}
+	if len(hits) != len(expected) {
+		t.Fatalf("Expected %d raid damage hits, got %v", len(expected), hits)
+	}
+	for i, hit := range hits {
+		if hit != expected[i] {
+			t.Errorf("Expected hit %d to be %v, got %v", i, expected[i], hit)
+		}
+	}
+}
+
+func TestRaidDamageProfileSpikes(t *testing.T) {
+	hits := runRaidDamageProfile(t, &proto.RaidDamageProfile{
+		SpikeDamage:    5000,
+		SpikeInterval:  2,
+		DamageVariance: 0.2,
+	})
+
+	if len(hits) == 0 {
+		t.Fatalf("Expected spikes")
+	}
+	for _, hit := range hits {
+		if hit.amount < 4000 || hit.amount > 6000 {
+			t.Errorf("Expected spike damage within 20%% of 5000, got %v", hit)
+		}
+	}
+}
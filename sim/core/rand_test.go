@@ -3,6 +3,10 @@ package core
 import (
 	"math"
 	"testing"
+	"time"
+
+	"github.com/wowsims/mop/sim/core/proto"
+	"github.com/wowsims/mop/sim/core/simsignals"
 )
 
 func TestSplitMix64(t *testing.T) {
@@ -34,6 +38,31 @@ func TestSplitMix64(t *testing.T) {
 	t.Logf("chiSquare = %.1f", chiSquare)
 }
 
+func TestCommonRandomNumbersResync(t *testing.T) {
+	rollAfterExtraRoll := func(commonRandomNumbers bool) (float64, float64) {
+		options := &proto.SimOptions{RandomSeed: 101, UseLabeledRands: true, CommonRandomNumbers: commonRandomNumbers}
+		base := newSimWithEnv(nil, options, simsignals.CreateSignals())
+		perturbed := newSimWithEnv(nil, options, simsignals.CreateSignals())
+
+		base.CurrentTime = time.Millisecond * 500
+		perturbed.CurrentTime = time.Millisecond * 500
+		base.RandomFloat("Test Roll")
+		perturbed.RandomFloat("Test Roll")
+		perturbed.RandomFloat("Test Roll")
+
+		base.CurrentTime = CommonRandomNumbersWindow + time.Millisecond*500
+		perturbed.CurrentTime = CommonRandomNumbersWindow + time.Millisecond*500
+		return base.RandomFloat("Test Roll"), perturbed.RandomFloat("Test Roll")
+	}
+
+	if base, perturbed := rollAfterExtraRoll(false); base == perturbed {
+		t.Fatalf("Expected an extra roll to shift later rolls without common random numbers")
+	}
+	if base, perturbed := rollAfterExtraRoll(true); base != perturbed {
+		t.Fatalf("Expected common random numbers to resync rolls in the next window, got %f and %f", base, perturbed)
+	}
+}
+
 var result float64
 
 func BenchmarkRnds(b *testing.B) {
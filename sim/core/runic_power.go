@@ -219,6 +219,9 @@ func (rp *runicPowerBar) addRunicPowerInternal(sim *Simulation, amount float64,
 	if sim.Log != nil {
 		rp.character.Log(sim, "Gained %0.3f runic power from %s (%0.3f --> %0.3f) of %0.0f total.", amount, metrics.ActionID, rp.currentRunicPower, newRunicPower, rp.maxRunicPower)
 	}
+	if sim.CombatLog != nil {
+		rp.character.LogResourceChange(sim, metrics, amount, rp.currentRunicPower, newRunicPower)
+	}
 
 	rp.currentRunicPower = newRunicPower
 }
@@ -253,6 +256,9 @@ func (rp *runicPowerBar) spendRunicPower(sim *Simulation, amount float64, metric
 	if sim.Log != nil {
 		rp.character.Log(sim, "Spent %0.3f runic power from %s (%0.3f --> %0.3f) of %0.0f total.", amount, metrics.ActionID, rp.currentRunicPower, newRunicPower, rp.maxRunicPower)
 	}
+	if sim.CombatLog != nil {
+		rp.character.LogResourceChange(sim, metrics, -amount, rp.currentRunicPower, newRunicPower)
+	}
 
 	rp.currentRunicPower = newRunicPower
 }
@@ -616,6 +622,10 @@ func (rp *runicPowerBar) gainRuneMetrics(sim *Simulation, metrics *ResourceMetri
 		name, currRunes := rp.typeAmount(metrics)
 		rp.character.Log(sim, "Gained %0.3f %s rune from %s (%d --> %d).", float64(gainAmount), name, metrics.ActionID, currRunes-gainAmount, currRunes)
 	}
+	if sim.CombatLog != nil {
+		_, currRunes := rp.typeAmount(metrics)
+		rp.character.LogResourceChange(sim, metrics, float64(gainAmount), float64(currRunes-gainAmount), float64(currRunes))
+	}
 }
 
 // spendRuneMetrics should be called after spending the rune
@@ -626,6 +636,10 @@ func (rp *runicPowerBar) spendRuneMetrics(sim *Simulation, metrics *ResourceMetr
 		name, currRunes := rp.typeAmount(metrics)
 		rp.character.Log(sim, "Spent 1.000 %s rune from %s (%d --> %d).", name, metrics.ActionID, currRunes+spendAmount, currRunes)
 	}
+	if sim.CombatLog != nil {
+		_, currRunes := rp.typeAmount(metrics)
+		rp.character.LogResourceChange(sim, metrics, -float64(spendAmount), float64(currRunes+spendAmount), float64(currRunes))
+	}
 }
 
 func (rp *runicPowerBar) regenRune(sim *Simulation, regenAt time.Duration, slot int8) {
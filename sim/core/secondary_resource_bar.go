@@ -66,6 +66,9 @@ func (bar *DefaultSecondaryResourceBarImpl) Gain(sim *Simulation, amount float64
 			bar.config.Max,
 		)
 	}
+	if sim.CombatLog != nil {
+		bar.unit.LogResourceChange(sim, metrics, amount, oldValue, bar.value)
+	}
 
 	bar.invokeOnGain(sim, amount, amountGained, action)
 }
@@ -113,6 +116,9 @@ func (bar *DefaultSecondaryResourceBarImpl) Spend(sim *Simulation, amount float6
 	}
 
 	metrics.AddEvent(float64(-amount), float64(-amount))
+	if sim.CombatLog != nil {
+		bar.unit.LogResourceChange(sim, metrics, -amount, bar.value, bar.value-amount)
+	}
 	bar.invokeOnSpend(sim, amount, action)
 	bar.value -= amount
 }
@@ -19,10 +19,6 @@ type Shield struct {
 }
 
 func (shield *Shield) Apply(sim *Simulation, shieldAmount float64) {
-	caster := shield.Spell.Unit
-	target := shield.Aura.Unit
-	//attackTable := caster.AttackTables[target.UnitIndex]
-
 	// Shields are not affected by healing pseudostats the same way heals are.
 	// So we only apply the spell-specific multiplier.
 	shieldAmount *= shield.Spell.DamageMultiplier
@@ -33,6 +29,41 @@ func (shield *Shield) Apply(sim *Simulation, shieldAmount float64) {
 		shield.Aura.SetStacks(sim, int32(shieldAmount))
 	}
 
+	shield.recordShielding(sim, shieldAmount)
+}
+
+// Adds to the remaining absorb of the shield instead of replacing it, e.g. for
+// Divine Aegis. The remaining absorb is tracked in the aura stacks, so the aura
+// needs a MaxStacks high enough to hold it. A maxAmount of 0 means no cap.
+func (shield *Shield) Stack(sim *Simulation, shieldAmount float64, maxAmount float64) {
+	shieldAmount *= shield.Spell.DamageMultiplier
+
+	currentAmount := 0.0
+	if shield.Aura.IsActive() {
+		currentAmount = float64(shield.Aura.GetStacks())
+	}
+	if maxAmount > 0 {
+		shieldAmount = max(0, min(shieldAmount, maxAmount-currentAmount))
+	}
+
+	shield.Aura.Activate(sim)
+	shield.Aura.SetStacks(sim, int32(currentAmount+shieldAmount))
+
+	shield.recordShielding(sim, shieldAmount)
+}
+
+// Remaining absorb of a shield which tracks it in its aura stacks.
+func (shield *Shield) RemainingAbsorb() float64 {
+	if !shield.Aura.IsActive() {
+		return 0
+	}
+	return float64(shield.Aura.GetStacks())
+}
+
+func (shield *Shield) recordShielding(sim *Simulation, shieldAmount float64) {
+	caster := shield.Spell.Unit
+	target := shield.Aura.Unit
+
 	threat := 0.0 // TODO
 	shield.Spell.SpellMetrics[target.UnitIndex].TotalThreat += threat
 	shield.Spell.SpellMetrics[target.UnitIndex].TotalShielding += shieldAmount
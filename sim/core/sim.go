@@ -34,6 +34,10 @@ type Simulation struct {
 	isTest    bool
 	testRands map[string]Rand
 
+	// Used for SimOptions.CommonRandomNumbers, see labelRand().
+	commonRandomNumbers bool
+	testRandWindows     map[string]int64
+
 	// Current Simulation State
 	pendingActions    []*PendingAction
 	pendingActionPool *sync.Pool
@@ -46,6 +50,12 @@ type Simulation struct {
 
 	Log func(string, ...interface{})
 
+	// Structured counterpart of Log, set when SimOptions.CombatLog is enabled.
+	CombatLog func(*proto.CombatLogEvent)
+
+	// Decisions of APL rotations in logged iterations, when SimOptions.AplTrace is enabled.
+	aplTrace []*proto.APLDecision
+
 	executePhase int32 // 20, 25, 35, 45 or 90 for the respective execute range, 100 otherwise
 
 	executePhaseCallbacks []func(*Simulation, int32) // 2nd parameter is 90 for 90%, 45 for 45%, 35 for 35%, 25 for 25% and 20 for 20%
@@ -115,6 +125,14 @@ func RunSim(rsr *proto.RaidSimRequest, progress chan *proto.ProgressMetrics, sig
 	return runSim(rsr, progress, false, signals)
 }
 
+// Rejects requests with settings that can't be simmed, before setting up the sim.
+func validateRaidSimRequest(rsr *proto.RaidSimRequest) *proto.RaidSimResult {
+	if err := validateBuffUptimes(rsr.Raid); err != nil {
+		return &proto.RaidSimResult{Error: &proto.ErrorOutcome{Message: err.Error()}}
+	}
+	return nil
+}
+
 func runSim(rsr *proto.RaidSimRequest, progress chan *proto.ProgressMetrics, skipPresim bool, signals simsignals.Signals) (result *proto.RaidSimResult) {
 	if !rsr.SimOptions.IsTest {
 		defer func() {
@@ -143,6 +161,13 @@ func runSim(rsr *proto.RaidSimRequest, progress chan *proto.ProgressMetrics, ski
 		}()
 	}
 
+	if errorResult := validateRaidSimRequest(rsr); errorResult != nil {
+		if progress != nil {
+			progress <- &proto.ProgressMetrics{FinalRaidResult: errorResult}
+		}
+		return errorResult
+	}
+
 	sim := NewSim(rsr, signals)
 
 	if !skipPresim {
@@ -206,9 +231,12 @@ func newSimWithEnv(env *Environment, simOptions *proto.SimOptions, signals simsi
 		rseed:       rseed,
 		currentSeed: rseed,
 
-		isTest:    simOptions.IsTest || simOptions.UseLabeledRands,
+		isTest:    simOptions.IsTest || simOptions.UseLabeledRands || simOptions.CommonRandomNumbers,
 		testRands: make(map[string]Rand),
 
+		commonRandomNumbers: simOptions.CommonRandomNumbers,
+		testRandWindows:     make(map[string]int64),
+
 		Signals: signals,
 
 		pendingActionPool: &sync.Pool{
@@ -231,6 +259,11 @@ func (sim *Simulation) RandomFloat(label string) float64 {
 	return sim.labelRand(label).NextFloat64()
 }
 
+// Length of the fight time windows that labeled RNG streams are resynchronized
+// on, when using SimOptions.CommonRandomNumbers. Shorter windows resync sooner,
+// but line rolls up with the wrong events once haste has shifted their timing.
+const CommonRandomNumbersWindow = time.Second * 5
+
 func (sim *Simulation) labelRand(label string) Rand {
 	if !sim.isTest {
 		return sim.rand
@@ -242,6 +275,18 @@ func (sim *Simulation) labelRand(label string) Rand {
 		labelRng = NewSplitMix(uint64(makeTestRandSeed(sim.rand.GetSeed(), label)))
 		sim.testRands[label] = labelRng
 	}
+
+	// With common random numbers, each label is also reseeded whenever it is first
+	// used in a new window of fight time. Otherwise one extra roll (e.g. from a
+	// bit more haste) shifts every later roll with the same label, and paired sims
+	// drift apart for the rest of the iteration.
+	if sim.commonRandomNumbers {
+		window := int64(math.Floor(float64(sim.CurrentTime) / float64(CommonRandomNumbersWindow)))
+		if lastWindow, ok := sim.testRandWindows[label]; !ok || lastWindow != window {
+			labelRng.Seed(makeTestRandSeed(sim.currentSeed, label+"@"+strconv.FormatInt(window, 10)))
+			sim.testRandWindows[label] = window
+		}
+	}
 	return labelRng
 }
 
@@ -250,6 +295,7 @@ func (sim *Simulation) reseedRands(i int64) {
 	sim.currentSeed = rseed
 	sim.rand.Seed(rseed)
 
+	clear(sim.testRandWindows)
 	if sim.isTest {
 		for label, rng := range sim.testRands {
 			rng.Seed(makeTestRandSeed(rseed, label))
@@ -305,6 +351,15 @@ func (sim *Simulation) run() *proto.RaidSimResult {
 		}
 	}
 
+	var combatLog []*proto.CombatLogEvent
+	if sim.Options.CombatLog && (sim.Options.Debug || sim.Options.DebugFirstIteration) {
+		sim.CombatLog = func(event *proto.CombatLogEvent) {
+			combatLog = append(combatLog, event)
+		}
+	}
+
+	sim.aplTrace = nil
+
 	// Uncomment this to print logs directly to console.
 	// sim.Options.Debug = true
 	// sim.Log = func(message string, vals ...interface{}) {
@@ -317,9 +372,11 @@ func (sim *Simulation) run() *proto.RaidSimResult {
 		firstIterationDuration = sim.CurrentTime
 	}
 	totalDuration := firstIterationDuration
+	iterationsDone := int32(1)
 
 	if !sim.Options.Debug {
 		sim.Log = nil
+		sim.CombatLog = nil
 	}
 
 	var st time.Time
@@ -353,23 +410,31 @@ func (sim *Simulation) run() *proto.RaidSimResult {
 			iterDuration = sim.CurrentTime
 		}
 		totalDuration += iterDuration
+		iterationsDone++
+
+		if usesTargetPrecision(sim.Options) && iterationsDone == nextPrecisionCheckpoint(sim.Options, iterationsDone-1) &&
+			sim.Raid.reachedTargetPrecision(sim.Options.TargetPrecision) {
+			break
+		}
 	}
 	result := &proto.RaidSimResult{
 		RaidMetrics:      sim.Raid.GetMetrics(),
 		EncounterMetrics: sim.Encounter.GetMetricsProto(),
 
 		Logs:                   logsBuffer.String(),
+		CombatLog:              combatLog,
+		AplTrace:               sim.aplTrace,
 		FirstIterationDuration: firstIterationDuration.Seconds(),
-		AvgIterationDuration:   totalDuration.Seconds() / float64(sim.Options.Iterations),
-		IterationsDone:         sim.Options.Iterations,
+		AvgIterationDuration:   totalDuration.Seconds() / float64(iterationsDone),
+		IterationsDone:         iterationsDone,
 	}
 
 	// Final progress report
 	if sim.ProgressReport != nil {
-		sim.ProgressReport(&proto.ProgressMetrics{TotalIterations: sim.Options.Iterations, CompletedIterations: sim.Options.Iterations, Dps: result.RaidMetrics.Dps.Avg, FinalRaidResult: result})
+		sim.ProgressReport(&proto.ProgressMetrics{TotalIterations: sim.Options.Iterations, CompletedIterations: iterationsDone, Dps: result.RaidMetrics.Dps.Avg, FinalRaidResult: result})
 	}
 
-	if d := sim.Options.Iterations; d > 3000 {
+	if d := iterationsDone; d > 3000 {
 		log.Printf("running %d iterations took %s", d, time.Since(t0))
 	}
 
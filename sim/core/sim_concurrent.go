@@ -8,6 +8,7 @@ import (
 	"runtime"
 	"runtime/debug"
 	"slices"
+	"time"
 
 	"github.com/wowsims/mop/sim/core/proto"
 	"github.com/wowsims/mop/sim/core/simsignals"
@@ -76,6 +77,7 @@ func (rsrc *raidSimResultCombiner) newUnitMetrics(baseUnit *proto.UnitMetrics) *
 		Dtps:      rsrc.newDistMetrics(),
 		Tmi:       rsrc.newDistMetrics(),
 		Hps:       rsrc.newDistMetrics(),
+		Aps:       rsrc.newDistMetrics(),
 		Tto:       rsrc.newDistMetrics(),
 		Actions:   make([]*proto.ActionMetrics, 0, len(baseUnit.Actions)),
 		Auras:     make([]*proto.AuraMetrics, len(baseUnit.Auras)),
@@ -83,6 +85,13 @@ func (rsrc *raidSimResultCombiner) newUnitMetrics(baseUnit *proto.UnitMetrics) *
 		Pets:      make([]*proto.UnitMetrics, len(baseUnit.Pets)),
 	}
 
+	for _, profile := range baseUnit.AplProfile {
+		newUm.AplProfile = append(newUm.AplProfile, &proto.APLActionProfile{
+			Location: profile.Location,
+			Uuid:     profile.Uuid,
+		})
+	}
+
 	for i, aura := range baseUnit.Auras {
 		newUm.Auras[i] = &proto.AuraMetrics{
 			Id:             aura.Id,
@@ -105,6 +114,11 @@ func (rsrc *raidSimResultCombiner) newPartyMetrics(baseParty *proto.PartyMetrics
 	}
 
 	for i, player := range baseParty.Players {
+		if player.Dps == nil {
+			// Empty raid slot.
+			newPm.Players[i] = &proto.UnitMetrics{}
+			continue
+		}
 		newPm.Players[i] = rsrc.newUnitMetrics(player)
 	}
 
@@ -195,6 +209,7 @@ func (rsrc *raidSimResultCombiner) addActionMetrics(unit *proto.UnitMetrics, add
 		baseTgt.Healing += addTgt.Healing
 		baseTgt.CritHealing += addTgt.CritHealing
 		baseTgt.Shielding += addTgt.Shielding
+		baseTgt.Overhealing += addTgt.Overhealing
 		baseTgt.CastTimeMs += addTgt.CastTimeMs
 	}
 }
@@ -243,6 +258,7 @@ func (rsrc *raidSimResultCombiner) combineUnitMetrics(base *proto.UnitMetrics, a
 	rsrc.combineDistMetrics(base.Dtps, add.Dtps, isLast, weight)
 	rsrc.combineDistMetrics(base.Tmi, add.Tmi, isLast, weight)
 	rsrc.combineDistMetrics(base.Hps, add.Hps, isLast, weight)
+	rsrc.combineDistMetrics(base.Aps, add.Aps, isLast, weight)
 	rsrc.combineDistMetrics(base.Tto, add.Tto, isLast, weight)
 
 	base.SecondsOomAvg += add.SecondsOomAvg * weight
@@ -265,6 +281,12 @@ func (rsrc *raidSimResultCombiner) combineUnitMetrics(base *proto.UnitMetrics, a
 		rsrc.addResourceMetrics(base, addResource)
 	}
 
+	for i, addProfile := range add.AplProfile {
+		base.AplProfile[i].EvaluationsAvg += addProfile.EvaluationsAvg * weight
+		base.AplProfile[i].ConditionTrueAvg += addProfile.ConditionTrueAvg * weight
+		base.AplProfile[i].ExecutionsAvg += addProfile.ExecutionsAvg * weight
+	}
+
 	for i, addPet := range add.Pets {
 		rsrc.combineUnitMetrics(base.Pets[i], addPet, isLast, weight)
 	}
@@ -279,6 +301,9 @@ func (rsrc *raidSimResultCombiner) AddResult(result *proto.RaidSimResult, isLast
 		rsrc.combineDistMetrics(baseParty.Dps, party.Dps, isLast, weight)
 		rsrc.combineDistMetrics(baseParty.Hps, party.Hps, isLast, weight)
 		for playerIdx, player := range party.Players {
+			if player.Dps == nil {
+				continue
+			}
 			rsrc.combineUnitMetrics(baseParty.Players[playerIdx], player, isLast, weight)
 		}
 	}
@@ -290,8 +315,14 @@ func (rsrc *raidSimResultCombiner) AddResult(result *proto.RaidSimResult, isLast
 	rsrc.Combined.AvgIterationDuration += result.AvgIterationDuration * weight
 	rsrc.Combined.IterationsDone += result.IterationsDone
 
+	if isLast {
+		setRaidDpsShares(rsrc.Combined.RaidMetrics)
+	}
+
 	if rsrc.Debug {
 		rsrc.Combined.Logs += "-SIMSTART-\n" + result.Logs
+		rsrc.Combined.CombatLog = append(rsrc.Combined.CombatLog, result.CombatLog...)
+		rsrc.Combined.AplTrace = append(rsrc.Combined.AplTrace, result.AplTrace...)
 	}
 }
 
@@ -310,6 +341,8 @@ func (rsrc *raidSimResultCombiner) SetBaseResult(baseRsr *proto.RaidSimResult) {
 
 	if !rsrc.Debug {
 		newRsr.Logs = baseRsr.Logs
+		newRsr.CombatLog = baseRsr.CombatLog
+		newRsr.AplTrace = baseRsr.AplTrace
 	}
 
 	for i, party := range baseRsr.RaidMetrics.Parties {
@@ -352,7 +385,9 @@ func CombineConcurrentSimResults(results []*proto.RaidSimResult, isDebug bool) *
 type concurrentSimData struct {
 	Concurrency     int32
 	IterationsTotal int32
-	IterationsDone  []int32
+	// Iterations completed by earlier batches, when stopping on target precision.
+	IterationsBefore int32
+	IterationsDone   []int32
 
 	DpsValues []float64
 	HpsValues []float64
@@ -361,7 +396,7 @@ type concurrentSimData struct {
 }
 
 func (csd *concurrentSimData) GetIterationsDone() int32 {
-	var total int32 = 0
+	total := csd.IterationsBefore
 	for _, done := range csd.IterationsDone {
 		total += done
 	}
@@ -435,6 +470,77 @@ func runSimConcurrent(request *proto.RaidSimRequest, progress chan *proto.Progre
 		}
 	}()
 
+	if result = validateRaidSimRequest(request); result != nil {
+		if progress != nil {
+			progress <- &proto.ProgressMetrics{FinalRaidResult: result}
+		}
+		return result
+	}
+
+	if !usesTargetPrecision(request.SimOptions) {
+		result = runSimConcurrentBatch(request, progress, signals, 0, request.SimOptions.Iterations)
+		if result.Error == nil && progress != nil {
+			progress <- &proto.ProgressMetrics{
+				TotalIterations:     request.SimOptions.Iterations,
+				CompletedIterations: result.IterationsDone,
+				Dps:                 result.RaidMetrics.Dps.Avg,
+				Hps:                 result.RaidMetrics.Hps.Avg,
+				FinalRaidResult:     result,
+			}
+		}
+		return result
+	}
+
+	// Run in batches up to each precision checkpoint, continuing the seeds where
+	// the previous batch left off, so the results match a single-threaded sim.
+	options := request.SimOptions
+	if options.RandomSeed == 0 {
+		request = googleProto.Clone(request).(*proto.RaidSimRequest)
+		request.SimOptions.RandomSeed = time.Now().UnixNano()
+		options = request.SimOptions
+	}
+
+	var iterationsDone int32
+	for iterationsDone < options.Iterations {
+		batchRequest := googleProto.Clone(request).(*proto.RaidSimRequest)
+		batchRequest.SimOptions.Iterations = nextPrecisionCheckpoint(options, iterationsDone) - iterationsDone
+		batchRequest.SimOptions.RandomSeed += int64(iterationsDone)
+		batchRequest.SimOptions.TargetPrecision = 0
+
+		batchResult := runSimConcurrentBatch(batchRequest, progress, signals, iterationsDone, options.Iterations)
+		if batchResult.Error != nil {
+			return batchResult
+		}
+
+		if result == nil {
+			result = batchResult
+		} else {
+			result = CombineConcurrentSimResults([]*proto.RaidSimResult{result, batchResult}, options.Debug)
+		}
+		iterationsDone = result.IterationsDone
+
+		if reachedTargetPrecision(options.TargetPrecision, result.RaidMetrics) {
+			break
+		}
+	}
+
+	if progress != nil {
+		progress <- &proto.ProgressMetrics{
+			TotalIterations:     options.Iterations,
+			CompletedIterations: result.IterationsDone,
+			Dps:                 result.RaidMetrics.Dps.Avg,
+			Hps:                 result.RaidMetrics.Hps.Avg,
+			FinalRaidResult:     result,
+		}
+	}
+
+	return result
+}
+
+// Runs a single batch of iterations split over multiple sims. Progress is reported
+// relative to iterationsBefore out of iterationsTotal, but the final result is left
+// to the caller.
+func runSimConcurrentBatch(request *proto.RaidSimRequest, progress chan *proto.ProgressMetrics, signals simsignals.Signals, iterationsBefore int32, iterationsTotal int32) *proto.RaidSimResult {
 	splitRes := SplitSimRequestForConcurrency(request, TernaryInt32(request.SimOptions.IsTest, 3, int32(runtime.NumCPU())))
 
 	if splitRes.ErrorResult != "" {
@@ -447,12 +553,13 @@ func runSimConcurrent(request *proto.RaidSimRequest, progress chan *proto.Progre
 	running := threads
 
 	csd := concurrentSimData{
-		Concurrency:     threads,
-		IterationsTotal: request.SimOptions.Iterations,
-		IterationsDone:  make([]int32, threads),
-		DpsValues:       make([]float64, threads),
-		HpsValues:       make([]float64, threads),
-		FinalResults:    make([]*proto.RaidSimResult, threads),
+		Concurrency:      threads,
+		IterationsTotal:  iterationsTotal,
+		IterationsBefore: iterationsBefore,
+		IterationsDone:   make([]int32, threads),
+		DpsValues:        make([]float64, threads),
+		HpsValues:        make([]float64, threads),
+		FinalResults:     make([]*proto.RaidSimResult, threads),
 	}
 
 	for i := 0; i < int(threads); i++ {
@@ -520,13 +627,5 @@ func runSimConcurrent(request *proto.RaidSimRequest, progress chan *proto.Progre
 		log.Printf("All %d sims finished successfully.", csd.Concurrency)
 	}
 
-	result = CombineConcurrentSimResults(csd.FinalResults, request.SimOptions.Debug)
-
-	if progress != nil {
-		pm := csd.MakeProgressMetrics()
-		pm.FinalRaidResult = result
-		progress <- pm
-	}
-
-	return result
+	return CombineConcurrentSimResults(csd.FinalResults, request.SimOptions.Debug)
 }
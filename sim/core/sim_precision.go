@@ -0,0 +1,63 @@
+package core
+
+import (
+	"math"
+
+	"github.com/wowsims/mop/sim/core/proto"
+)
+
+// How often, in iterations, SimOptions.TargetPrecision is checked once
+// the minimum iterations have been run.
+const TargetPrecisionCheckInterval = 500
+
+// z-score for a two-sided 95% confidence interval.
+const confidenceInterval95Z = 1.96
+
+func usesTargetPrecision(options *proto.SimOptions) bool {
+	return options.TargetPrecision > 0
+}
+
+// Returns the number of iterations at which target precision is next checked,
+// given the number of iterations already done. Never exceeds options.Iterations.
+//
+// Both the single-threaded and concurrent sims check at exactly these points, so
+// they stop after the same number of iterations.
+func nextPrecisionCheckpoint(options *proto.SimOptions, iterationsDone int32) int32 {
+	minIterations := options.MinIterations
+	if minIterations <= 0 {
+		minIterations = TargetPrecisionCheckInterval
+	}
+
+	next := minIterations
+	if iterationsDone >= minIterations {
+		next += ((iterationsDone-minIterations)/TargetPrecisionCheckInterval + 1) * TargetPrecisionCheckInterval
+	}
+	return min(next, options.Iterations)
+}
+
+// Whether the 95% confidence interval of the mean is within target * mean.
+func withinTargetPrecision(target float64, mean float64, stdev float64, n int) bool {
+	if n <= 1 {
+		return false
+	}
+	return confidenceInterval95Z*stdev/math.Sqrt(float64(n)) <= target*math.Abs(mean)
+}
+
+// Checks the precision of the raid's running DPS, or HPS for sims without damage.
+func (raid *Raid) reachedTargetPrecision(target float64) bool {
+	metrics := &raid.dpsMetrics
+	if metrics.sum == 0 {
+		metrics = &raid.hpsMetrics
+	}
+	mean, stdev := metrics.meanAndStdDev()
+	return withinTargetPrecision(target, mean, stdev, metrics.n)
+}
+
+// Same as Raid.reachedTargetPrecision, but for (combined) sim results.
+func reachedTargetPrecision(target float64, metrics *proto.RaidMetrics) bool {
+	dist := metrics.Dps
+	if dist.Avg == 0 {
+		dist = metrics.Hps
+	}
+	return withinTargetPrecision(target, dist.Avg, dist.Stdev, int(dist.AggregatorData.GetN()))
+}
@@ -0,0 +1,45 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/wowsims/mop/sim/core/proto"
+)
+
+func TestNextPrecisionCheckpoint(t *testing.T) {
+	options := &proto.SimOptions{Iterations: 2000, TargetPrecision: 0.001, MinIterations: 200}
+
+	for _, tc := range []struct {
+		done int32
+		next int32
+	}{
+		{0, 200},
+		{199, 200},
+		{200, 700},
+		{699, 700},
+		{700, 1200},
+		{1700, 2000},
+	} {
+		if next := nextPrecisionCheckpoint(options, tc.done); next != tc.next {
+			t.Errorf("After %d iterations, expected the next checkpoint at %d but got %d", tc.done, tc.next, next)
+		}
+	}
+
+	options.MinIterations = 0
+	if next := nextPrecisionCheckpoint(options, 0); next != TargetPrecisionCheckInterval {
+		t.Errorf("Expected the first checkpoint to default to %d but got %d", TargetPrecisionCheckInterval, next)
+	}
+}
+
+func TestWithinTargetPrecision(t *testing.T) {
+	// 1.96 * 1000 / sqrt(10000) = 19.6, which is just under 0.1% of 20000 DPS.
+	if !withinTargetPrecision(0.001, 20000, 1000, 10000) {
+		t.Errorf("Expected 10000 iterations to reach 0.1%% precision")
+	}
+	if withinTargetPrecision(0.001, 20000, 1000, 9000) {
+		t.Errorf("Expected 9000 iterations to not reach 0.1%% precision")
+	}
+	if withinTargetPrecision(0.001, 20000, 0, 1) {
+		t.Errorf("Expected a single iteration to never reach the target precision")
+	}
+}
@@ -713,6 +713,9 @@ func (spell *Spell) SkipCastAndApplyEffects(sim *Simulation, target *Unit) {
 			spell.ActionID, spell.DefaultCast.Cost, time.Duration(0))
 		spell.Unit.Log(sim, "Completed cast %s", spell.ActionID)
 	}
+	if sim.CombatLog != nil && !spell.Flags.Matches(SpellFlagNoLogs) {
+		spell.logInstantCast(sim, target, Cast{Cost: spell.DefaultCast.Cost})
+	}
 	spell.applyEffects(sim, target)
 }
 
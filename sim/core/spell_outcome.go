@@ -206,17 +206,22 @@ func (spell *Spell) outcomeHealing(_ *Simulation, result *SpellResult, _ *Attack
 	}
 }
 
-func (spell *Spell) OutcomeHealingCrit(sim *Simulation, result *SpellResult, _ *AttackTable) {
-	spell.outcomeHealingCrit(sim, result, nil, true)
+func (spell *Spell) OutcomeHealingCrit(sim *Simulation, result *SpellResult, attackTable *AttackTable) {
+	spell.outcomeHealingCrit(sim, result, attackTable, true)
 }
-func (spell *Spell) OutcomeHealingCritNoHitCounter(sim *Simulation, result *SpellResult, _ *AttackTable) {
-	spell.outcomeHealingCrit(sim, result, nil, false)
+func (spell *Spell) OutcomeHealingCritNoHitCounter(sim *Simulation, result *SpellResult, attackTable *AttackTable) {
+	spell.outcomeHealingCrit(sim, result, attackTable, false)
 }
-func (spell *Spell) outcomeHealingCrit(sim *Simulation, result *SpellResult, _ *AttackTable, countHits bool) {
+func (spell *Spell) outcomeHealingCrit(sim *Simulation, result *SpellResult, attackTable *AttackTable, countHits bool) {
 	if spell.CritMultiplier == 0 {
 		panic("Spell " + spell.ActionID.String() + " missing CritMultiplier")
 	}
-	if spell.HealingCritCheck(sim) {
+	critChance := spell.HealingCritChance()
+	if attackTable != nil {
+		// Buffs on the target which only affect heals from this caster.
+		critChance += attackTable.BonusSpellCritPercent / 100
+	}
+	if sim.RandomFloat("Healing Crit Roll") < critChance {
 		result.Outcome = OutcomeCrit
 		result.Damage *= spell.CritDamageMultiplier()
 		if countHits {
@@ -0,0 +1,32 @@
+package core
+
+import (
+	"testing"
+)
+
+func TestHealingCritAttackTableBonus(t *testing.T) {
+	sim := SetupFakeSim()
+	fa := sim.Raid.Parties[0].Players[0].(*FakeAgent)
+	spell := &Spell{
+		Unit:             &fa.Unit,
+		CritMultiplier:   2,
+		BonusCritPercent: -1000,
+	}
+	attackTable := &AttackTable{}
+
+	for _, test := range []struct {
+		bonusCritPercent float64
+		outcome          HitOutcome
+		damage           float64
+	}{
+		{0, OutcomeHit, 100},
+		{2000, OutcomeCrit, 200},
+	} {
+		attackTable.BonusSpellCritPercent = test.bonusCritPercent
+		result := &SpellResult{Target: &fa.Unit, Damage: 100}
+		spell.OutcomeHealingCritNoHitCounter(sim, result, attackTable)
+		if result.Outcome != test.outcome || result.Damage != test.damage {
+			t.Errorf("Expected %s for %f with %f%% bonus crit, got %s for %f", test.outcome, test.damage, test.bonusCritPercent, result.Outcome, result.Damage)
+		}
+	}
+}
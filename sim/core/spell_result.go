@@ -5,6 +5,7 @@ import (
 	"math"
 	"time"
 
+	"github.com/wowsims/mop/sim/core/proto"
 	"github.com/wowsims/mop/sim/core/stats"
 )
 
@@ -377,6 +378,9 @@ func (spell *Spell) dealDamageInternal(sim *Simulation, isPeriodic bool, result
 			spell.Unit.Log(sim, "%s %s %s (SpellSchool: %d). (Threat: %0.3f)", result.Target.LogLabel(), spell.ActionID, result.DamageString(), spell.SpellSchool, result.Threat)
 		}
 	}
+	if sim.CombatLog != nil && !spell.Flags.Matches(SpellFlagNoLogs) {
+		spell.logSpellResult(sim, result, proto.CombatLogEventType_CombatLogEventDamage, isPeriodic)
+	}
 
 	if !spell.Flags.Matches(SpellFlagNoOnDamageDealt) {
 		if isPeriodic {
@@ -585,6 +589,8 @@ func (spell *Spell) dealHealingInternal(sim *Simulation, isPeriodic bool, result
 	spell.SpellMetrics[result.Target.UnitIndex].TotalHealing += result.Damage
 	spell.SpellMetrics[result.Target.UnitIndex].TotalThreat += result.Threat
 	if result.Target.HasHealthBar() {
+		missingHealth := result.Target.MaxHealth() - result.Target.CurrentHealth()
+		spell.SpellMetrics[result.Target.UnitIndex].TotalOverhealing += max(0, result.Damage-missingHealth)
 		result.Target.GainHealth(sim, result.Damage, spell.HealthMetrics(result.Target))
 	}
 
@@ -595,6 +601,9 @@ func (spell *Spell) dealHealingInternal(sim *Simulation, isPeriodic bool, result
 			spell.Unit.Log(sim, "%s %s %s. (Threat: %0.3f)", result.Target.LogLabel(), spell.ActionID, result.HealingString(), result.Threat)
 		}
 	}
+	if sim.CombatLog != nil && !spell.Flags.Matches(SpellFlagNoLogs) {
+		spell.logSpellResult(sim, result, proto.CombatLogEventType_CombatLogEventHeal, isPeriodic)
+	}
 
 	if isPeriodic {
 		spell.Unit.OnPeriodicHealDealt(sim, spell, result)
@@ -1,8 +1,10 @@
 package core
 
 import (
+	"cmp"
 	"fmt"
 	"math"
+	"slices"
 	"strings"
 	"time"
 
@@ -62,30 +64,78 @@ func (s *UnitStats) ExportWeights() *proto.UnitStats {
 }
 
 type StatWeightValues struct {
-	Weights       UnitStats
-	WeightsStdev  UnitStats
-	EpValues      UnitStats
-	EpValuesStdev UnitStats
+	Weights        UnitStats
+	WeightsStdev   UnitStats
+	WeightsStderr  UnitStats
+	EpValues       UnitStats
+	EpValuesStdev  UnitStats
+	EpValuesStderr UnitStats
+	ScalingCurves  []*proto.StatScalingCurve
+
+	// Per-iteration weight of each stat, paired with the baseline iteration that
+	// used the same random numbers.
+	pairedSamples map[stats.UnitStat][]float64
 }
 
 func NewStatWeightValues() StatWeightValues {
 	return StatWeightValues{
-		Weights:       NewUnitStats(),
-		WeightsStdev:  NewUnitStats(),
-		EpValues:      NewUnitStats(),
-		EpValuesStdev: NewUnitStats(),
+		Weights:        NewUnitStats(),
+		WeightsStdev:   NewUnitStats(),
+		WeightsStderr:  NewUnitStats(),
+		EpValues:       NewUnitStats(),
+		EpValuesStdev:  NewUnitStats(),
+		EpValuesStderr: NewUnitStats(),
+		pairedSamples:  make(map[stats.UnitStat][]float64),
 	}
 }
 
 func (swv *StatWeightValues) ToProto() *proto.StatWeightValues {
 	return &proto.StatWeightValues{
-		Weights:       swv.Weights.ExportWeights(),
-		WeightsStdev:  swv.WeightsStdev.ExportWeights(),
-		EpValues:      swv.EpValues.ExportWeights(),
-		EpValuesStdev: swv.EpValuesStdev.ExportWeights(),
+		Weights:        swv.Weights.ExportWeights(),
+		WeightsStdev:   swv.WeightsStdev.ExportWeights(),
+		WeightsStderr:  swv.WeightsStderr.ExportWeights(),
+		EpValues:       swv.EpValues.ExportWeights(),
+		EpValuesStdev:  swv.EpValuesStdev.ExportWeights(),
+		EpValuesStderr: swv.EpValuesStderr.ExportWeights(),
+		ScalingCurves:  swv.ScalingCurves,
 	}
 }
 
+// Standard error of the ratio of two paired sample means, using the delta method
+// so that the covariance between the samples (from sharing random numbers with
+// the same baseline iterations) is taken into account.
+func pairedRatioStderr(x []float64, y []float64) float64 {
+	n := float64(len(x))
+	if len(x) < 2 || len(x) != len(y) {
+		return 0
+	}
+
+	var meanX, meanY float64
+	for i := range x {
+		meanX += x[i]
+		meanY += y[i]
+	}
+	meanX /= n
+	meanY /= n
+	if meanY == 0 {
+		return 0
+	}
+
+	var varX, varY, covXY float64
+	for i := range x {
+		varX += (x[i] - meanX) * (x[i] - meanX)
+		varY += (y[i] - meanY) * (y[i] - meanY)
+		covXY += (x[i] - meanX) * (y[i] - meanY)
+	}
+	varX /= n - 1
+	varY /= n - 1
+	covXY /= n - 1
+
+	ratio := meanX / meanY
+	variance := (varX + ratio*ratio*varY - 2*ratio*covXY) / (n * meanY * meanY)
+	return math.Sqrt(max(variance, 0))
+}
+
 type StatWeightsResult struct {
 	Dps    StatWeightValues
 	Hps    StatWeightValues
@@ -106,6 +156,131 @@ func NewStatWeightsResult() *StatWeightsResult {
 	}
 }
 
+// Fits a curve through the baseline and scaling sim results of a stat, for each metric.
+func (swr *StatWeightsResult) addScalingCurves(stat stats.UnitStat, baselinePlayer *proto.UnitMetrics, statResult *proto.StatWeightsStatResultData, quadratic bool) {
+	metrics := []struct {
+		values *StatWeightValues
+		get    func(player *proto.UnitMetrics) float64
+	}{
+		{&swr.Dps, func(player *proto.UnitMetrics) float64 { return player.Dps.Avg }},
+		{&swr.Hps, func(player *proto.UnitMetrics) float64 { return player.Hps.Avg }},
+		{&swr.Tps, func(player *proto.UnitMetrics) float64 { return player.Threat.Avg }},
+		{&swr.Dtps, func(player *proto.UnitMetrics) float64 { return player.Dtps.Avg }},
+		{&swr.Tmi, func(player *proto.UnitMetrics) float64 { return player.Tmi.Avg }},
+	}
+
+	type point struct {
+		delta  float64
+		player *proto.UnitMetrics
+	}
+	points := []point{{0, baselinePlayer}}
+	for i, scalingResult := range statResult.ScalingResults {
+		points = append(points, point{statResult.StatData.ScalingDeltas[i], scalingResult.RaidMetrics.Parties[0].Players[0]})
+	}
+	slices.SortFunc(points, func(p1, p2 point) int {
+		return cmp.Compare(p1.delta, p2.delta)
+	})
+
+	deltas := MapSlice(points, func(p point) float64 { return p.delta })
+	for _, metric := range metrics {
+		values := MapSlice(points, func(p point) float64 { return metric.get(p.player) })
+		coefficients, rSquared := fitPolynomial(deltas, values, TernaryInt(quadratic, 2, 1))
+		metric.values.ScalingCurves = append(metric.values.ScalingCurves, &proto.StatScalingCurve{
+			UnitStat:     int32(stat),
+			Deltas:       deltas,
+			Values:       values,
+			Coefficients: coefficients,
+			Weight:       coefficients[1],
+			RSquared:     rSquared,
+		})
+	}
+}
+
+// Least squares fit of a polynomial of the given degree. Returns its coefficients,
+// lowest order first, and the coefficient of determination (R squared) of the fit.
+func fitPolynomial(xs []float64, ys []float64, degree int) ([]float64, float64) {
+	numCoeffs := degree + 1
+	coefficients := make([]float64, numCoeffs)
+	if len(xs) < numCoeffs {
+		return coefficients, 0
+	}
+
+	// Normalize x to [-1, 1] so the normal equations stay well conditioned.
+	xScale := 0.0
+	for _, x := range xs {
+		xScale = max(xScale, math.Abs(x))
+	}
+	if xScale == 0 {
+		return coefficients, 0
+	}
+
+	// Augmented matrix of the normal equations (X^T X | X^T y).
+	matrix := make([][]float64, numCoeffs)
+	for row := range matrix {
+		matrix[row] = make([]float64, numCoeffs+1)
+	}
+	for i, x := range xs {
+		u := x / xScale
+		for row := 0; row < numCoeffs; row++ {
+			for col := 0; col < numCoeffs; col++ {
+				matrix[row][col] += math.Pow(u, float64(row+col))
+			}
+			matrix[row][numCoeffs] += math.Pow(u, float64(row)) * ys[i]
+		}
+	}
+
+	// Gaussian elimination with partial pivoting.
+	for col := 0; col < numCoeffs; col++ {
+		pivot := col
+		for row := col + 1; row < numCoeffs; row++ {
+			if math.Abs(matrix[row][col]) > math.Abs(matrix[pivot][col]) {
+				pivot = row
+			}
+		}
+		if matrix[pivot][col] == 0 {
+			return coefficients, 0
+		}
+		matrix[col], matrix[pivot] = matrix[pivot], matrix[col]
+
+		for row := col + 1; row < numCoeffs; row++ {
+			factor := matrix[row][col] / matrix[col][col]
+			for k := col; k <= numCoeffs; k++ {
+				matrix[row][k] -= factor * matrix[col][k]
+			}
+		}
+	}
+	for row := numCoeffs - 1; row >= 0; row-- {
+		sum := matrix[row][numCoeffs]
+		for col := row + 1; col < numCoeffs; col++ {
+			sum -= matrix[row][col] * coefficients[col]
+		}
+		coefficients[row] = sum / matrix[row][row]
+	}
+
+	// Undo the normalization.
+	for i := range coefficients {
+		coefficients[i] /= math.Pow(xScale, float64(i))
+	}
+
+	var mean, ssTot, ssRes float64
+	for _, y := range ys {
+		mean += y
+	}
+	mean /= float64(len(ys))
+	for i, x := range xs {
+		fit := 0.0
+		for j, c := range coefficients {
+			fit += c * math.Pow(x, float64(j))
+		}
+		ssRes += (ys[i] - fit) * (ys[i] - fit)
+		ssTot += (ys[i] - mean) * (ys[i] - mean)
+	}
+	if ssTot == 0 {
+		return coefficients, 1
+	}
+	return coefficients, 1 - ssRes/ssTot
+}
+
 func (swr *StatWeightsResult) ToProto() *proto.StatWeightsResult {
 	return &proto.StatWeightsResult{
 		Dps:    swr.Dps.ToProto(),
@@ -136,6 +311,7 @@ func buildStatWeightRequests(swr *proto.StatWeightsRequest) *proto.StatWeightReq
 	// Cut in half since we're doing above and below separately.
 	// This number needs to be the same for the baseline sim too, so that RNG lines up perfectly.
 	swr.SimOptions.Iterations /= 2
+	swr.SimOptions.MinIterations /= 2
 
 	// Make sure an RNG seed is always set because it gives more consistent results.
 	// When there is no user-supplied seed it needs to be a randomly-selected seed
@@ -155,6 +331,7 @@ func buildStatWeightRequests(swr *proto.StatWeightsRequest) *proto.StatWeightReq
 		},
 		EpReferenceStat: swr.EpReferenceStat,
 		StatSimRequests: []*proto.StatWeightsStatRequestData{},
+		Scaling:         swr.Scaling,
 	}
 
 	// Do half the iterations with a positive, and half with a negative value for better accuracy.
@@ -227,7 +404,7 @@ func buildStatWeightRequests(swr *proto.StatWeightsRequest) *proto.StatWeightReq
 		highSimRequest := googleProto.Clone(swBaseResponse.BaseRequest).(*proto.RaidSimRequest)
 		stat.AddToStatsProto(highSimRequest.Raid.Parties[0].Players[0].BonusStats, statModsHigh[stat])
 
-		swBaseResponse.StatSimRequests = append(swBaseResponse.StatSimRequests, &proto.StatWeightsStatRequestData{
+		statRequestData := &proto.StatWeightsStatRequestData{
 			StatData: &proto.StatWeightsStatData{
 				UnitStat: int32(stat),
 				ModLow:   statModsLow[stat],
@@ -235,7 +412,26 @@ func buildStatWeightRequests(swr *proto.StatWeightsRequest) *proto.StatWeightReq
 			},
 			RequestLow:  lowSimRequest,
 			RequestHigh: highSimRequest,
-		})
+		}
+
+		if scaling := swr.Scaling; scaling != nil && scaling.Steps > 0 && scaling.MaxDelta != 0 {
+			// Pseudo stats are converted from rating the same way as their single-step mods.
+			deltaScale := TernaryFloat64(stat.IsStat(), 1, statModsHigh[stat]/defaultStatMod)
+			for step := -scaling.Steps; step <= scaling.Steps; step++ {
+				if step == 0 {
+					continue
+				}
+				delta := float64(step) * scaling.MaxDelta / float64(scaling.Steps) * deltaScale
+
+				scalingSimRequest := googleProto.Clone(swBaseResponse.BaseRequest).(*proto.RaidSimRequest)
+				stat.AddToStatsProto(scalingSimRequest.Raid.Parties[0].Players[0].BonusStats, delta)
+
+				statRequestData.StatData.ScalingDeltas = append(statRequestData.StatData.ScalingDeltas, delta)
+				statRequestData.ScalingRequests = append(statRequestData.ScalingRequests, scalingSimRequest)
+			}
+		}
+
+		swBaseResponse.StatSimRequests = append(swBaseResponse.StatSimRequests, statRequestData)
 	}
 
 	return swBaseResponse
@@ -261,26 +457,48 @@ func computeStatWeights(swcr *proto.StatWeightsCalcRequest) *proto.StatWeightsRe
 		modPlayerLow := statResult.ResultLow.RaidMetrics.Parties[0].Players[0]
 		modPlayerHigh := statResult.ResultHigh.RaidMetrics.Parties[0].Players[0]
 
+		// Scaling curves are most useful around caps, so add them before skipping those.
+		if len(statResult.ScalingResults) > 0 {
+			result.addScalingCurves(stat, baselinePlayer, statResult, swcr.Scaling.GetQuadratic())
+		}
+
 		// Check for hard caps. Hard caps will have results identical to the baseline because RNG is fixed.
 		// When we find a hard-capped stat, just skip it (will return 0).
 		if modPlayerHigh.Dps.Avg == baselinePlayer.Dps.Avg && modPlayerHigh.Hps.Avg == baselinePlayer.Hps.Avg && modPlayerHigh.Tmi.Avg == baselinePlayer.Tmi.Avg {
 			continue
 		}
 
+		numIterations := len(baselinePlayer.Dps.AllValues)
+		if len(modPlayerLow.Dps.AllValues) != numIterations || len(modPlayerHigh.Dps.AllValues) != numIterations {
+			return &proto.StatWeightsResult{Error: &proto.ErrorOutcome{Message: "Stat weight sims must run the same iterations as the baseline!"}}
+		}
+
 		calcWeightResults := func(baselineMetrics *proto.DistributionMetrics, modLowMetrics *proto.DistributionMetrics, modHighMetrics *proto.DistributionMetrics, weightResults *StatWeightValues) {
-			var lo, hi aggregator
+			var lo, hi, paired aggregator
+			samples := make([]float64, len(baselineMetrics.AllValues))
 			for i := range baselineMetrics.AllValues {
-				lo.add(modLowMetrics.AllValues[i] - baselineMetrics.AllValues[i])
+				lowDiff := modLowMetrics.AllValues[i] - baselineMetrics.AllValues[i]
+				highDiff := modHighMetrics.AllValues[i] - baselineMetrics.AllValues[i]
+				lo.add(lowDiff)
+				hi.add(highDiff)
+
+				// Both mods share the baseline's random numbers for this iteration, so
+				// their average is a single paired sample.
+				samples[i] = (lowDiff/statResult.StatData.ModLow + highDiff/statResult.StatData.ModHigh) / 2
+				paired.add(samples[i])
 			}
 			lo.scale(1 / statResult.StatData.ModLow)
-			for i := range baselineMetrics.AllValues {
-				hi.add(modHighMetrics.AllValues[i] - baselineMetrics.AllValues[i])
-			}
 			hi.scale(1 / statResult.StatData.ModHigh)
 
 			mean, stdev := lo.merge(&hi).meanAndStdDev()
 			weightResults.Weights.AddStat(stat, mean)
 			weightResults.WeightsStdev.AddStat(stat, stdev)
+
+			if paired.n > 1 {
+				_, pairedStdev := paired.meanAndStdDev()
+				weightResults.WeightsStderr.AddStat(stat, pairedStdev/math.Sqrt(float64(paired.n-1)))
+			}
+			weightResults.pairedSamples[stat] = samples
 		}
 
 		calcWeightResults(baselinePlayer.Dps, modPlayerLow.Dps, modPlayerHigh.Dps, &result.Dps)
@@ -308,6 +526,11 @@ func computeStatWeights(swcr *proto.StatWeightsCalcRequest) *proto.StatWeightsRe
 			stdev := weightResults.WeightsStdev.Get(stat) / math.Abs(weightResults.Weights.Stats[refStat])
 			weightResults.EpValues.AddStat(stat, mean)
 			weightResults.EpValuesStdev.AddStat(stat, stdev)
+
+			refUnitStat := stats.UnitStatFromStat(refStat)
+			if stat != refUnitStat {
+				weightResults.EpValuesStderr.AddStat(stat, pairedRatioStderr(weightResults.pairedSamples[stat], weightResults.pairedSamples[refUnitStat]))
+			}
 		}
 
 		calcEpResults(&result.Dps, referenceStat)
@@ -334,6 +557,10 @@ func runStatWeights(request *proto.StatWeightsRequest, progress chan *proto.Prog
 		iterationsTotal += reqData.RequestLow.SimOptions.Iterations
 		iterationsTotal += reqData.RequestHigh.SimOptions.Iterations
 		simsTotal += 2
+		for _, scalingRequest := range reqData.ScalingRequests {
+			iterationsTotal += scalingRequest.SimOptions.Iterations
+			simsTotal++
+		}
 	}
 
 	waitForResult := func(srcProgressChannel chan *proto.ProgressMetrics) *proto.RaidSimResult {
@@ -372,6 +599,19 @@ func runStatWeights(request *proto.StatWeightsRequest, progress chan *proto.Prog
 		return &proto.StatWeightsResult{Error: baselineResult.Error}
 	}
 
+	// When the baseline stopped on target precision, run exactly as many iterations
+	// for each stat so the per-iteration values still line up with the baseline.
+	if usesTargetPrecision(requestData.BaseRequest.SimOptions) {
+		iterationsTotal = baselineResult.IterationsDone
+		for _, reqData := range requestData.StatSimRequests {
+			for _, statRequest := range append([]*proto.RaidSimRequest{reqData.RequestLow, reqData.RequestHigh}, reqData.ScalingRequests...) {
+				statRequest.SimOptions.Iterations = baselineResult.IterationsDone
+				statRequest.SimOptions.TargetPrecision = 0
+				iterationsTotal += baselineResult.IterationsDone
+			}
+		}
+	}
+
 	statResults := []*proto.StatWeightsStatResultData{}
 
 	for _, reqData := range requestData.StatSimRequests {
@@ -389,10 +629,22 @@ func runStatWeights(request *proto.StatWeightsRequest, progress chan *proto.Prog
 			return &proto.StatWeightsResult{Error: highRes.Error}
 		}
 
+		var scalingResults []*proto.RaidSimResult
+		for _, scalingRequest := range reqData.ScalingRequests {
+			scalingProgress := make(chan *proto.ProgressMetrics, 100)
+			go simFunc(scalingRequest, scalingProgress, signals)
+			scalingRes := waitForResult(scalingProgress)
+			if scalingRes.Error != nil {
+				return &proto.StatWeightsResult{Error: scalingRes.Error}
+			}
+			scalingResults = append(scalingResults, scalingRes)
+		}
+
 		statResults = append(statResults, &proto.StatWeightsStatResultData{
-			StatData:   reqData.StatData,
-			ResultLow:  lowRes,
-			ResultHigh: highRes,
+			StatData:       reqData.StatData,
+			ResultLow:      lowRes,
+			ResultHigh:     highRes,
+			ScalingResults: scalingResults,
 		})
 	}
 
@@ -400,5 +652,6 @@ func runStatWeights(request *proto.StatWeightsRequest, progress chan *proto.Prog
 		BaseResult:      baselineResult,
 		EpReferenceStat: requestData.EpReferenceStat,
 		StatSimResults:  statResults,
+		Scaling:         requestData.Scaling,
 	})
 }
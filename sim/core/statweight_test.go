@@ -0,0 +1,79 @@
+package core
+
+import (
+	"math"
+	"testing"
+
+	"github.com/wowsims/mop/sim/core/proto"
+)
+
+func TestPairedRatioStderr(t *testing.T) {
+	// Perfectly correlated samples have a constant ratio, so no error.
+	x := []float64{2, 4, 6, 8}
+	y := []float64{1, 2, 3, 4}
+	if stderr := pairedRatioStderr(x, y); stderr > 1e-9 {
+		t.Errorf("Expected no error for perfectly correlated samples, got %f", stderr)
+	}
+
+	// Uncorrelated samples only cancel out through the means.
+	x = []float64{1, 3, 1, 3}
+	y = []float64{1, 1, 3, 3}
+	expected := math.Sqrt((4.0/3 + 4.0/3) / (4 * 4))
+	if stderr := pairedRatioStderr(x, y); math.Abs(stderr-expected) > 1e-9 {
+		t.Errorf("Expected stderr %f for uncorrelated samples, got %f", expected, stderr)
+	}
+
+	if stderr := pairedRatioStderr(x, y[:2]); stderr != 0 {
+		t.Errorf("Expected no stderr for mismatched samples, got %f", stderr)
+	}
+}
+
+func TestFitPolynomial(t *testing.T) {
+	xs := []float64{-3000, -1500, 0, 1500, 3000}
+
+	// A line is fit exactly.
+	coefficients, rSquared := fitPolynomial(xs, MapSlice(xs, func(x float64) float64 { return 50000 + 2*x }), 1)
+	if math.Abs(coefficients[0]-50000) > 1e-6 || math.Abs(coefficients[1]-2) > 1e-9 || math.Abs(rSquared-1) > 1e-9 {
+		t.Errorf("Expected an exact linear fit, got %v with R^2 %f", coefficients, rSquared)
+	}
+
+	// A capped stat, flat above the baseline, is poorly described by a line.
+	capped := MapSlice(xs, func(x float64) float64 { return 50000 + 2*min(x, 0) })
+	_, linearRSquared := fitPolynomial(xs, capped, 1)
+	coefficients, quadraticRSquared := fitPolynomial(xs, capped, 2)
+	if linearRSquared >= quadraticRSquared || quadraticRSquared > 1 {
+		t.Errorf("Expected the quadratic fit (R^2 %f) to beat the linear fit (R^2 %f)", quadraticRSquared, linearRSquared)
+	}
+	if coefficients[2] >= 0 {
+		t.Errorf("Expected a negative quadratic term for a capped stat, got %v", coefficients)
+	}
+
+	if _, rSquared := fitPolynomial(xs[:2], capped[:2], 2); rSquared != 0 {
+		t.Errorf("Expected no fit with fewer points than coefficients, got R^2 %f", rSquared)
+	}
+}
+
+func TestStatScalingRequests(t *testing.T) {
+	requestData := buildStatWeightRequests(&proto.StatWeightsRequest{
+		Player:          &proto.Player{},
+		Encounter:       &proto.Encounter{},
+		SimOptions:      &proto.SimOptions{Iterations: 100, RandomSeed: 1},
+		StatsToWeigh:    []proto.Stat{proto.Stat_StatHasteRating},
+		EpReferenceStat: proto.Stat_StatAgility,
+		Scaling:         &proto.StatScalingOptions{MaxDelta: 3000, Steps: 2},
+	})
+
+	for _, statRequest := range requestData.StatSimRequests {
+		deltas := statRequest.StatData.ScalingDeltas
+		if len(deltas) != 4 || len(statRequest.ScalingRequests) != 4 {
+			t.Fatalf("Expected 4 scaling sims for stat %d, got deltas %v", statRequest.StatData.UnitStat, deltas)
+		}
+		if deltas[0] != -3000 || deltas[1] != -1500 || deltas[2] != 1500 || deltas[3] != 3000 {
+			t.Errorf("Unexpected scaling deltas %v", deltas)
+		}
+		bonusStats := statRequest.ScalingRequests[3].Raid.Parties[0].Players[0].BonusStats.Stats
+		if bonusStats[statRequest.StatData.UnitStat] != 3000 {
+			t.Errorf("Expected +3000 bonus stat in the last scaling sim, got %v", bonusStats)
+		}
+	}
+}
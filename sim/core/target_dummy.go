@@ -45,10 +45,12 @@ func NewTargetDummy(dummyIndex int, party *Party, partyIndex int) *TargetDummy {
 func (td *TargetDummy) GetCharacter() *Character {
 	return &td.Character
 }
-func (td *TargetDummy) AddRaidBuffs(raidBuffs *proto.RaidBuffs)    {}
-func (td *TargetDummy) AddPartyBuffs(partyBuffs *proto.PartyBuffs) {}
-func (td *TargetDummy) ApplyTalents()                              {}
-func (td *TargetDummy) Initialize()                                {}
-func (td *TargetDummy) Reset(sim *Simulation)                      {}
-func (td *TargetDummy) ExecuteCustomRotation(sim *Simulation)      {}
-func (td *TargetDummy) OnEncounterStart(sim *Simulation)           {}
+func (td *TargetDummy) AddRaidBuffs(raidBuffs *proto.RaidBuffs)       {}
+func (td *TargetDummy) AddRaidMemberBuffs(raidBuffs *proto.RaidBuffs) {}
+func (td *TargetDummy) AddPartyBuffs(partyBuffs *proto.PartyBuffs)    {}
+func (td *TargetDummy) AddDebuffs(debuffs *proto.Debuffs)             {}
+func (td *TargetDummy) ApplyTalents()                                 {}
+func (td *TargetDummy) Initialize()                                   {}
+func (td *TargetDummy) Reset(sim *Simulation)                         {}
+func (td *TargetDummy) ExecuteCustomRotation(sim *Simulation)         {}
+func (td *TargetDummy) OnEncounterStart(sim *Simulation)              {}
@@ -237,42 +237,7 @@ func RunTestSuite(t *testing.T, suiteName string, generators []TestGenerator) {
 					}
 				} else if rsr != nil && !strings.Contains(testName, "Casts") {
 					simResult := testSuite.TestDPS(fullTestName, rsr)
-					if actualDpsResult, ok := testSuite.testResults.DpsResults[fullTestName]; ok {
-						if expectedDpsResult, ok := expectedResults.DpsResults[fullTestName]; ok {
-							// Check whichever of DPS/HPS is larger first, so we get better test diff printouts.
-							if actualDpsResult.Dps < actualDpsResult.Hps {
-								if actualDpsResult.Hps < expectedDpsResult.Hps-tolerance || actualDpsResult.Hps > expectedDpsResult.Hps+tolerance {
-									t.Logf("HPS expected %0.03f but was %0.03f!.", expectedDpsResult.Hps, actualDpsResult.Hps)
-									t.Fail()
-								}
-							}
-							if actualDpsResult.Dps < expectedDpsResult.Dps-tolerance || actualDpsResult.Dps > expectedDpsResult.Dps+tolerance {
-								t.Logf("DPS expected %0.03f but was %0.03f!.", expectedDpsResult.Dps, actualDpsResult.Dps)
-								t.Fail()
-							}
-							if actualDpsResult.Dps >= actualDpsResult.Hps {
-								if actualDpsResult.Hps < expectedDpsResult.Hps-tolerance || actualDpsResult.Hps > expectedDpsResult.Hps+tolerance {
-									t.Logf("HPS expected %0.03f but was %0.03f!.", expectedDpsResult.Hps, actualDpsResult.Hps)
-									t.Fail()
-								}
-							}
-
-							if actualDpsResult.Tps < expectedDpsResult.Tps-tolerance || actualDpsResult.Tps > expectedDpsResult.Tps+tolerance {
-								t.Logf("TPS expected %0.03f but was %0.03f!.", expectedDpsResult.Tps, actualDpsResult.Tps)
-								t.Fail()
-							}
-							if actualDpsResult.Dtps < expectedDpsResult.Dtps-tolerance || actualDpsResult.Dtps > expectedDpsResult.Dtps+tolerance {
-								t.Logf("DTPS expected %0.03f but was %0.03f!.", expectedDpsResult.Dtps, actualDpsResult.Dtps)
-								t.Fail()
-							}
-						} else {
-							t.Logf("Unexpected test %s with %0.03f DPS!", fullTestName, actualDpsResult.Dps)
-							t.Fail()
-						}
-					} else if !ok {
-						t.Logf("Missing Result for test %s", fullTestName)
-						t.Fail()
-					}
+					checkDpsResult(t, fullTestName, expectedResults, testSuite.testResults)
 
 					// The purpose of this test is not only to confirm concurrency result combination to work,
 					// but also to check if the sim resets everything properly between iterations.
@@ -333,6 +298,134 @@ func RunTestSuite(t *testing.T, suiteName string, generators []TestGenerator) {
 	}
 }
 
+func checkDpsResult(t *testing.T, testName string, expectedResults *proto.TestSuiteResult, actualResults *proto.TestSuiteResult) {
+	actualDpsResult, ok := actualResults.DpsResults[testName]
+	if !ok {
+		t.Logf("Missing Result for test %s", testName)
+		t.Fail()
+		return
+	}
+	expectedDpsResult, ok := expectedResults.DpsResults[testName]
+	if !ok {
+		t.Logf("Unexpected test %s with %0.03f DPS!", testName, actualDpsResult.Dps)
+		t.Fail()
+		return
+	}
+
+	// Check whichever of DPS/HPS is larger first, so we get better test diff printouts.
+	if actualDpsResult.Dps < actualDpsResult.Hps {
+		if actualDpsResult.Hps < expectedDpsResult.Hps-tolerance || actualDpsResult.Hps > expectedDpsResult.Hps+tolerance {
+			t.Logf("HPS expected %0.03f but was %0.03f!.", expectedDpsResult.Hps, actualDpsResult.Hps)
+			t.Fail()
+		}
+	}
+	if actualDpsResult.Dps < expectedDpsResult.Dps-tolerance || actualDpsResult.Dps > expectedDpsResult.Dps+tolerance {
+		t.Logf("DPS expected %0.03f but was %0.03f!.", expectedDpsResult.Dps, actualDpsResult.Dps)
+		t.Fail()
+	}
+	if actualDpsResult.Dps >= actualDpsResult.Hps {
+		if actualDpsResult.Hps < expectedDpsResult.Hps-tolerance || actualDpsResult.Hps > expectedDpsResult.Hps+tolerance {
+			t.Logf("HPS expected %0.03f but was %0.03f!.", expectedDpsResult.Hps, actualDpsResult.Hps)
+			t.Fail()
+		}
+	}
+
+	if actualDpsResult.Tps < expectedDpsResult.Tps-tolerance || actualDpsResult.Tps > expectedDpsResult.Tps+tolerance {
+		t.Logf("TPS expected %0.03f but was %0.03f!.", expectedDpsResult.Tps, actualDpsResult.Tps)
+		t.Fail()
+	}
+	if actualDpsResult.Dtps < expectedDpsResult.Dtps-tolerance || actualDpsResult.Dtps > expectedDpsResult.Dtps+tolerance {
+		t.Logf("DTPS expected %0.03f but was %0.03f!.", expectedDpsResult.Dtps, actualDpsResult.Dtps)
+		t.Fail()
+	}
+}
+
+// Tests full raid comps, rather than a single player. Results are recorded for
+// the raid as a whole and for each player in it.
+type RaidTestSuite struct {
+	IndividualTestSuite
+}
+
+func NewRaidTestSuite(suiteName string) *RaidTestSuite {
+	return &RaidTestSuite{
+		IndividualTestSuite: *NewIndividualTestSuite(suiteName),
+	}
+}
+
+// Returns the names of the per-player results recorded for a raid test.
+func (testSuite *RaidTestSuite) TestRaidDPS(testName string, rsr *proto.RaidSimRequest) (*proto.RaidSimResult, []string) {
+	testSuite.testNames = append(testSuite.testNames, testName)
+
+	result := RunRaidSim(rsr)
+	if result.Logs != "" {
+		fmt.Printf("LOGS: %s\n", result.Logs)
+	}
+	if result.Error != nil {
+		panic("simulation failed to run: " + result.Error.Message)
+	}
+	testSuite.testResults.DpsResults[testName] = &proto.DpsTestResult{
+		Dps: toFixed(result.RaidMetrics.Dps.Avg, storagePrecision),
+		Hps: toFixed(result.RaidMetrics.Hps.Avg, storagePrecision),
+	}
+
+	var playerTestNames []string
+	for partyIdx, party := range rsr.Raid.Parties {
+		for playerIdx, player := range party.Players {
+			if player == nil || player.Class == proto.Class_ClassUnknown {
+				continue
+			}
+			playerMetrics := result.RaidMetrics.Parties[partyIdx].Players[playerIdx]
+			playerTestName := testName + "-" + player.Name
+			testSuite.testResults.DpsResults[playerTestName] = &proto.DpsTestResult{
+				Dps:  toFixed(playerMetrics.Dps.Avg, storagePrecision),
+				Tps:  toFixed(playerMetrics.Threat.Avg, storagePrecision),
+				Dtps: toFixed(playerMetrics.Dtps.Avg, storagePrecision),
+				Hps:  toFixed(playerMetrics.Hps.Avg, storagePrecision),
+			}
+			playerTestNames = append(playerTestNames, playerTestName)
+		}
+	}
+
+	return result, playerTestNames
+}
+
+type RaidTestCase struct {
+	Name    string
+	Request *proto.RaidSimRequest
+}
+
+func RunRaidTestSuite(t *testing.T, suiteName string, testCases []RaidTestCase) {
+	testSuite := NewRaidTestSuite(suiteName)
+
+	expectedResults, err := testSuite.readExpectedResults()
+	if err != nil {
+		t.Logf("\n\n----- FAILURE LOADING RESULTS FILE TESTS WILL FAIL-----\n%s\n-----\n\n", err)
+		t.Fail()
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			fullTestName := suiteName + "-" + testCase.Name
+			simResult, playerTestNames := testSuite.TestRaidDPS(fullTestName, testCase.Request)
+			checkDpsResult(t, fullTestName, expectedResults, testSuite.testResults)
+			for _, playerTestName := range playerTestNames {
+				checkDpsResult(t, playerTestName, expectedResults, testSuite.testResults)
+			}
+
+			t.Run("CompareResults", func(t *testing.T) {
+				mtResult := RunRaidSimConcurrent(testCase.Request)
+				CompareConcurrentSimResultsTest(t, fullTestName, simResult, mtResult, 1e-8, 1e-9)
+			})
+		})
+	}
+
+	testSuite.Done(t)
+
+	if t.Failed() {
+		t.Log("One or more tests failed! If the changes are intentional, update the expected results with 'make test && make update-tests'. Otherwise go fix your bugs!")
+	}
+}
+
 func toFixed(num float64, precision int) float64 {
 	output := math.Pow(10, float64(precision))
 	return float64(math.Round(num*output)) / output
@@ -102,6 +102,10 @@ type Unit struct {
 	// Provides aura tracking behavior.
 	auraTracker
 
+	// Spell IDs of buffs configured with a partial uptime, which MakePermanent
+	// doesn't activate.
+	partialUptimeSpellIDs map[int32]bool
+
 	// Current stats, including temporary effects but not dependencies.
 	statsWithoutDeps stats.Stats
 
@@ -226,7 +230,7 @@ func (unit *Unit) IsEnabled() bool {
 }
 
 func (unit *Unit) IsActive() bool {
-	return unit.IsEnabled() && unit.CurrentHealthPercent() > 0
+	return unit.IsEnabled() && (!unit.HasHealthBar() || unit.CurrentHealthPercent() > 0)
 }
 
 func (unit *Unit) IsOpponent(other *Unit) bool {
@@ -0,0 +1,410 @@
+// Runs sims across several machines by splitting requests into shards and
+// sending them to workers which speak the web server's async API.
+package distributed
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	uuid "github.com/google/uuid"
+	"github.com/wowsims/mop/sim/core"
+	"github.com/wowsims/mop/sim/core/proto"
+	"github.com/wowsims/mop/sim/core/simsignals"
+	googleProto "google.golang.org/protobuf/proto"
+)
+
+const (
+	DefaultShardsPerWorker = 4
+	DefaultMaxAttempts     = 3
+	DefaultPollInterval    = time.Millisecond * 500
+)
+
+type Coordinator struct {
+	// Base URLs of the workers, e.g. http://10.0.0.2:3334.
+	Workers []string
+	Client  *http.Client
+
+	// Each request is split into len(Workers) * ShardsPerWorker shards so
+	// faster machines pick up more of the work.
+	ShardsPerWorker int
+
+	// A shard is given up on after failing this many times. A worker which
+	// fails this many shards in a row is not used again for the request.
+	MaxAttempts int
+
+	PollInterval time.Duration
+}
+
+func NewCoordinator(workers []string) *Coordinator {
+	coordinator := &Coordinator{
+		Client:          &http.Client{Timeout: time.Second * 30},
+		ShardsPerWorker: DefaultShardsPerWorker,
+		MaxAttempts:     DefaultMaxAttempts,
+		PollInterval:    DefaultPollInterval,
+	}
+	for _, worker := range workers {
+		if worker = strings.TrimSpace(worker); worker != "" {
+			coordinator.Workers = append(coordinator.Workers, strings.TrimSuffix(worker, "/"))
+		}
+	}
+	return coordinator
+}
+
+func errorResult(message string) *proto.RaidSimResult {
+	return &proto.RaidSimResult{Error: &proto.ErrorOutcome{Message: message}}
+}
+
+/**
+ * Runs a raid sim on the workers and combines the shard results.
+ */
+func (c *Coordinator) RunRaidSim(request *proto.RaidSimRequest) *proto.RaidSimResult {
+	return c.runRaidSim(request, nil, simsignals.CreateSignals())
+}
+
+func (c *Coordinator) RunRaidSimAsync(request *proto.RaidSimRequest, progress chan *proto.ProgressMetrics, requestId string) {
+	signals, err := simsignals.RegisterWithId(requestId)
+	if err != nil {
+		progress <- &proto.ProgressMetrics{
+			FinalRaidResult: errorResult("Couldn't register for signal API: " + err.Error()),
+		}
+		return
+	}
+	go func() {
+		defer simsignals.UnregisterId(requestId)
+		result := c.runRaidSim(request, progress, signals)
+		progress <- &proto.ProgressMetrics{
+			FinalRaidResult: result,
+		}
+	}()
+}
+
+/**
+ * Calculates stat weights, running every sim needed on the workers.
+ */
+func (c *Coordinator) StatWeights(request *proto.StatWeightsRequest) *proto.StatWeightsResult {
+	return c.runStatWeights(request, nil, simsignals.CreateSignals())
+}
+
+func (c *Coordinator) StatWeightsAsync(request *proto.StatWeightsRequest, progress chan *proto.ProgressMetrics, requestId string) {
+	signals, err := simsignals.RegisterWithId(requestId)
+	if err != nil {
+		progress <- &proto.ProgressMetrics{
+			FinalWeightResult: &proto.StatWeightsResult{
+				Error: &proto.ErrorOutcome{
+					Message: "Couldn't register for signal API: " + err.Error(),
+				},
+			},
+		}
+		return
+	}
+	go func() {
+		defer simsignals.UnregisterId(requestId)
+		result := c.runStatWeights(request, progress, signals)
+		progress <- &proto.ProgressMetrics{
+			FinalWeightResult: result,
+		}
+	}()
+}
+
+func (c *Coordinator) runStatWeights(request *proto.StatWeightsRequest, progress chan *proto.ProgressMetrics, signals simsignals.Signals) *proto.StatWeightsResult {
+	requestData := core.StatWeightRequests(request)
+
+	totalSims := int32(1 + 2*len(requestData.StatSimRequests))
+	for _, statRequest := range requestData.StatSimRequests {
+		totalSims += int32(len(statRequest.ScalingRequests))
+	}
+	totalIterations := totalSims * requestData.BaseRequest.SimOptions.Iterations
+	completedSims := int32(0)
+	completedIterations := int32(0)
+
+	runSim := func(simRequest *proto.RaidSimRequest) *proto.RaidSimResult {
+		var simProgress chan *proto.ProgressMetrics
+		done := make(chan struct{})
+		if progress != nil {
+			simProgress = make(chan *proto.ProgressMetrics, 10)
+			go func() {
+				defer close(done)
+				for msg := range simProgress {
+					progress <- &proto.ProgressMetrics{
+						TotalIterations:     totalIterations,
+						CompletedIterations: completedIterations + msg.CompletedIterations,
+						TotalSims:           totalSims,
+						CompletedSims:       completedSims,
+					}
+				}
+			}()
+		} else {
+			close(done)
+		}
+
+		result := c.runRaidSim(simRequest, simProgress, signals)
+		if simProgress != nil {
+			close(simProgress)
+		}
+		<-done
+
+		completedSims++
+		completedIterations += simRequest.SimOptions.Iterations
+		return result
+	}
+
+	calcRequest := &proto.StatWeightsCalcRequest{
+		EpReferenceStat: requestData.EpReferenceStat,
+		BaseResult:      runSim(requestData.BaseRequest),
+		Scaling:         requestData.Scaling,
+	}
+	if calcRequest.BaseResult.Error != nil {
+		return &proto.StatWeightsResult{Error: calcRequest.BaseResult.Error}
+	}
+
+	for _, statRequest := range requestData.StatSimRequests {
+		statResult := &proto.StatWeightsStatResultData{
+			StatData:  statRequest.StatData,
+			ResultLow: runSim(statRequest.RequestLow),
+		}
+		if statResult.ResultLow.Error != nil {
+			return &proto.StatWeightsResult{Error: statResult.ResultLow.Error}
+		}
+		statResult.ResultHigh = runSim(statRequest.RequestHigh)
+		if statResult.ResultHigh.Error != nil {
+			return &proto.StatWeightsResult{Error: statResult.ResultHigh.Error}
+		}
+		for _, scalingRequest := range statRequest.ScalingRequests {
+			scalingResult := runSim(scalingRequest)
+			if scalingResult.Error != nil {
+				return &proto.StatWeightsResult{Error: scalingResult.Error}
+			}
+			statResult.ScalingResults = append(statResult.ScalingResults, scalingResult)
+		}
+		calcRequest.StatSimResults = append(calcRequest.StatSimResults, statResult)
+	}
+
+	return core.StatWeightCompute(calcRequest)
+}
+
+type shardEvent struct {
+	shard    int
+	worker   int
+	progress *proto.ProgressMetrics
+	result   *proto.RaidSimResult
+	err      error
+}
+
+func (c *Coordinator) runRaidSim(request *proto.RaidSimRequest, progress chan *proto.ProgressMetrics, signals simsignals.Signals) *proto.RaidSimResult {
+	if len(c.Workers) == 0 {
+		return errorResult("No workers configured!")
+	}
+	if request.SimOptions == nil {
+		return errorResult("Request is missing sim options!")
+	}
+
+	splitRes := core.SplitSimRequestForConcurrency(request, int32(len(c.Workers)*max(c.ShardsPerWorker, 1)))
+	if splitRes.ErrorResult != "" {
+		return errorResult(splitRes.ErrorResult)
+	}
+	shards := splitRes.Requests
+	maxAttempts := max(c.MaxAttempts, 1)
+
+	if !request.SimOptions.IsTest {
+		log.Printf("Running %d iterations as %d shards on %d workers.", request.SimOptions.Iterations, len(shards), len(c.Workers))
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events := make(chan shardEvent, len(c.Workers)*2)
+	pending := make([]int, len(shards))
+	for i := range shards {
+		pending[i] = i
+	}
+	idle := make([]int, len(c.Workers))
+	for i := range c.Workers {
+		idle[i] = i
+	}
+
+	attempts := make([]int, len(shards))
+	workerFailures := make([]int, len(c.Workers))
+	iterationsDone := make([]int32, len(shards))
+	dpsValues := make([]float64, len(shards))
+	hpsValues := make([]float64, len(shards))
+	results := make([]*proto.RaidSimResult, len(shards))
+	running := 0
+	completed := 0
+
+	makeProgressMetrics := func() *proto.ProgressMetrics {
+		metrics := &proto.ProgressMetrics{TotalIterations: request.SimOptions.Iterations}
+		for i, iterations := range iterationsDone {
+			metrics.CompletedIterations += iterations
+			metrics.Dps += dpsValues[i] * float64(iterations)
+			metrics.Hps += hpsValues[i] * float64(iterations)
+		}
+		if metrics.CompletedIterations > 0 {
+			metrics.Dps /= float64(metrics.CompletedIterations)
+			metrics.Hps /= float64(metrics.CompletedIterations)
+		}
+		return metrics
+	}
+
+	// Waits for running shards to stop so none of them send on events after we return.
+	stop := func(result *proto.RaidSimResult) *proto.RaidSimResult {
+		cancel()
+		for running > 0 {
+			if event := <-events; event.progress == nil {
+				running--
+			}
+		}
+		return result
+	}
+
+	abortTicker := time.NewTicker(max(c.PollInterval, time.Millisecond*10))
+	defer abortTicker.Stop()
+
+	for completed < len(shards) {
+		for len(pending) > 0 && len(idle) > 0 {
+			shard, worker := pending[0], idle[0]
+			pending, idle = pending[1:], idle[1:]
+			running++
+			go c.runShard(ctx, shard, worker, shards[shard], events)
+		}
+
+		if running == 0 {
+			return stop(errorResult(fmt.Sprintf("All workers failed, %d of %d shards were not completed.", len(shards)-completed, len(shards))))
+		}
+
+		var event shardEvent
+		select {
+		case event = <-events:
+		case <-abortTicker.C:
+			if signals.Abort.IsTriggered() {
+				return stop(&proto.RaidSimResult{Error: &proto.ErrorOutcome{Type: proto.ErrorOutcomeType_ErrorOutcomeAborted}})
+			}
+			continue
+		}
+
+		if event.progress != nil {
+			iterationsDone[event.shard] = event.progress.CompletedIterations
+			dpsValues[event.shard] = event.progress.Dps
+			hpsValues[event.shard] = event.progress.Hps
+			if progress != nil {
+				progress <- makeProgressMetrics()
+			}
+			continue
+		}
+
+		running--
+		if event.err != nil {
+			attempts[event.shard]++
+			workerFailures[event.worker]++
+			iterationsDone[event.shard] = 0
+			log.Printf("Shard %d failed on worker %s (attempt %d of %d): %s", event.shard, c.Workers[event.worker], attempts[event.shard], maxAttempts, event.err.Error())
+
+			if attempts[event.shard] >= maxAttempts {
+				return stop(errorResult(fmt.Sprintf("Shard %d failed %d times, last error: %s", event.shard, attempts[event.shard], event.err.Error())))
+			}
+			pending = append(pending, event.shard)
+			if workerFailures[event.worker] < maxAttempts {
+				idle = append(idle, event.worker)
+			} else {
+				log.Printf("Not using worker %s again after %d failures.", c.Workers[event.worker], workerFailures[event.worker])
+			}
+			continue
+		}
+
+		// Errors from the sim itself would fail again on any worker, so don't retry them.
+		if event.result.Error != nil {
+			return stop(event.result)
+		}
+
+		workerFailures[event.worker] = 0
+		results[event.shard] = event.result
+		iterationsDone[event.shard] = shards[event.shard].SimOptions.Iterations
+		completed++
+		idle = append(idle, event.worker)
+	}
+
+	if !request.SimOptions.IsTest {
+		log.Printf("All %d shards finished successfully.", len(shards))
+	}
+
+	return core.CombineConcurrentSimResults(results, request.SimOptions.Debug)
+}
+
+// Runs one shard on a worker. Sends any number of progress events followed by
+// exactly one event with either a result or an error.
+func (c *Coordinator) runShard(ctx context.Context, shard int, worker int, request *proto.RaidSimRequest, events chan<- shardEvent) {
+	baseUrl := c.Workers[worker]
+	requestId := uuid.NewString()
+
+	result, err := func() (*proto.RaidSimResult, error) {
+		asyncResult := &proto.AsyncAPIResult{}
+		if err := c.post(ctx, baseUrl+"/raidSimAsync?requestId="+requestId, request, asyncResult); err != nil {
+			return nil, err
+		}
+
+		for {
+			select {
+			case <-ctx.Done():
+				// Best effort, the worker will finish the sim anyway if this fails.
+				abortCtx, abortCancel := context.WithTimeout(context.Background(), time.Second*5)
+				c.post(abortCtx, baseUrl+"/abortById", &proto.AbortRequest{RequestId: requestId}, &proto.AbortResponse{})
+				abortCancel()
+				return nil, ctx.Err()
+			case <-time.After(c.PollInterval):
+			}
+
+			progress := &proto.ProgressMetrics{}
+			if err := c.post(ctx, baseUrl+"/asyncProgress", asyncResult, progress); err != nil {
+				return nil, err
+			}
+			if progress.FinalRaidResult != nil {
+				return progress.FinalRaidResult, nil
+			}
+			events <- shardEvent{shard: shard, worker: worker, progress: progress}
+		}
+	}()
+
+	events <- shardEvent{shard: shard, worker: worker, result: result, err: err}
+}
+
+func (c *Coordinator) post(ctx context.Context, url string, msg googleProto.Message, response googleProto.Message) error {
+	body, err := googleProto.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	httpRequest, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	httpRequest.Header.Set("Content-Type", "application/x-protobuf")
+
+	client := c.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	httpResponse, err := client.Do(httpRequest)
+	if err != nil {
+		return err
+	}
+	defer httpResponse.Body.Close()
+
+	if httpResponse.StatusCode == http.StatusNoContent {
+		return errors.New("worker has no sim with this id")
+	}
+	if httpResponse.StatusCode != http.StatusOK {
+		return fmt.Errorf("worker responded with %s", httpResponse.Status)
+	}
+
+	responseBody, err := io.ReadAll(httpResponse.Body)
+	if err != nil {
+		return err
+	}
+	return googleProto.Unmarshal(responseBody, response)
+}
@@ -0,0 +1,89 @@
+package distributed
+
+import (
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/wowsims/mop/sim/core"
+	"github.com/wowsims/mop/sim/core/proto"
+	"github.com/wowsims/mop/sim/warrior/arms"
+)
+
+func testRequest() *proto.RaidSimRequest {
+	player := &proto.Player{
+		Name:      "John",
+		Race:      proto.Race_RaceOrc,
+		Class:     proto.Class_ClassWarrior,
+		Equipment: &proto.EquipmentSpec{},
+		Rotation:  &proto.APLRotation{},
+		Spec: &proto.Player_ArmsWarrior{
+			ArmsWarrior: &proto.ArmsWarrior{
+				Options: &proto.ArmsWarrior_Options{
+					ClassOptions: &proto.WarriorOptions{},
+				},
+			},
+		},
+		Glyphs:        &proto.Glyphs{},
+		TalentsString: "000000",
+		Buffs:         &proto.IndividualBuffs{},
+	}
+
+	return &proto.RaidSimRequest{
+		Raid: core.SinglePlayerRaidProto(player, core.FullPartyBuffs, core.FullRaidBuffs, core.FullDebuffs),
+		Encounter: &proto.Encounter{
+			Duration: 60,
+			Targets: []*proto.Target{
+				core.NewDefaultTarget(),
+			},
+		},
+		SimOptions: &proto.SimOptions{
+			Iterations: 200,
+			IsTest:     true,
+			RandomSeed: 101,
+		},
+	}
+}
+
+func TestCoordinatorRetriesFailedShards(t *testing.T) {
+	arms.RegisterArmsWarrior()
+
+	worker := httptest.NewServer(NewWorkerHandler())
+	defer worker.Close()
+	broken := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer broken.Close()
+
+	coordinator := NewCoordinator([]string{broken.URL, worker.URL})
+	coordinator.PollInterval = time.Millisecond * 10
+
+	result := coordinator.RunRaidSim(testRequest())
+	if result.Error != nil {
+		t.Fatalf("Distributed sim failed: %s", result.Error.Message)
+	}
+
+	expected := core.RunRaidSim(testRequest())
+	actualDps := result.RaidMetrics.Dps.Avg
+	expectedDps := expected.RaidMetrics.Dps.Avg
+	if math.Abs(actualDps-expectedDps) > 0.0001*expectedDps {
+		t.Fatalf("Distributed DPS %0.3f does not match local DPS %0.3f", actualDps, expectedDps)
+	}
+}
+
+func TestCoordinatorAllWorkersFail(t *testing.T) {
+	broken := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer broken.Close()
+
+	coordinator := NewCoordinator([]string{broken.URL})
+	coordinator.PollInterval = time.Millisecond * 10
+
+	result := coordinator.RunRaidSim(testRequest())
+	if result.Error == nil {
+		t.Fatal("Expected an error when every worker fails")
+	}
+}
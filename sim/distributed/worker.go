@@ -0,0 +1,136 @@
+package distributed
+
+import (
+	"io"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	uuid "github.com/google/uuid"
+	"github.com/wowsims/mop/sim/core"
+	"github.com/wowsims/mop/sim/core/proto"
+	"github.com/wowsims/mop/sim/core/simsignals"
+	googleProto "google.golang.org/protobuf/proto"
+)
+
+// Progress of sims that are never polled again is dropped after this long.
+const workerProgressTimeout = time.Minute * 10
+
+type workerSim struct {
+	mut    sync.Mutex
+	latest *proto.ProgressMetrics
+}
+
+type worker struct {
+	mut  sync.Mutex
+	sims map[string]*workerSim
+}
+
+// NewWorkerHandler serves the part of the web server's API used by a
+// Coordinator: /raidSimAsync, /asyncProgress and /abortById. Sims on a worker
+// still use every core of the machine.
+func NewWorkerHandler() http.Handler {
+	w := &worker{sims: map[string]*workerSim{}}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/raidSimAsync", w.handleRaidSimAsync)
+	mux.HandleFunc("/asyncProgress", w.handleAsyncProgress)
+	mux.HandleFunc("/abortById", handleAbortById)
+	return mux
+}
+
+func readProtoBody(r *http.Request, msg googleProto.Message) bool {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return false
+	}
+	if err := googleProto.Unmarshal(body, msg); err != nil {
+		log.Printf("Failed to parse request: %s", err.Error())
+		return false
+	}
+	return true
+}
+
+func writeProto(rw http.ResponseWriter, msg googleProto.Message) {
+	outbytes, err := googleProto.Marshal(msg)
+	if err != nil {
+		log.Printf("[ERROR] Failed to marshal result: %s", err.Error())
+		rw.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	rw.Header().Add("Content-Type", "application/x-protobuf")
+	rw.Write(outbytes)
+}
+
+func (w *worker) handleRaidSimAsync(rw http.ResponseWriter, r *http.Request) {
+	request := &proto.RaidSimRequest{}
+	if !readProtoBody(r, request) {
+		rw.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	reporter := make(chan *proto.ProgressMetrics, 100)
+	core.RunRaidSimConcurrentAsync(request, reporter, r.URL.Query().Get("requestId"))
+
+	id := uuid.NewString()
+	sim := &workerSim{latest: &proto.ProgressMetrics{}}
+	w.mut.Lock()
+	w.sims[id] = sim
+	w.mut.Unlock()
+
+	go func() {
+		defer time.AfterFunc(workerProgressTimeout, func() {
+			w.mut.Lock()
+			delete(w.sims, id)
+			w.mut.Unlock()
+		})
+		for progMetric := range reporter {
+			sim.mut.Lock()
+			sim.latest = progMetric
+			sim.mut.Unlock()
+			if progMetric.FinalRaidResult != nil {
+				return
+			}
+		}
+	}()
+
+	writeProto(rw, &proto.AsyncAPIResult{ProgressId: id})
+}
+
+func (w *worker) handleAsyncProgress(rw http.ResponseWriter, r *http.Request) {
+	msg := &proto.AsyncAPIResult{}
+	if !readProtoBody(r, msg) {
+		rw.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	w.mut.Lock()
+	sim, ok := w.sims[msg.ProgressId]
+	w.mut.Unlock()
+	if !ok {
+		rw.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	sim.mut.Lock()
+	latest := sim.latest
+	sim.mut.Unlock()
+
+	if latest.FinalRaidResult != nil {
+		w.mut.Lock()
+		delete(w.sims, msg.ProgressId)
+		w.mut.Unlock()
+	}
+	writeProto(rw, latest)
+}
+
+func handleAbortById(rw http.ResponseWriter, r *http.Request) {
+	msg := &proto.AbortRequest{}
+	if !readProtoBody(r, msg) {
+		rw.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	triggered := simsignals.AbortById(msg.RequestId)
+	writeProto(rw, &proto.AbortResponse{RequestId: msg.RequestId, WasTriggered: triggered})
+}
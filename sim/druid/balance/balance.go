@@ -84,6 +84,11 @@ func (moonkin *BalanceDruid) GetDruid() *druid.Druid {
 	return moonkin.Druid
 }
 
+func (moonkin *BalanceDruid) AddRaidMemberBuffs(raidBuffs *proto.RaidBuffs) {
+	moonkin.Druid.AddRaidMemberBuffs(raidBuffs)
+	raidBuffs.MoonkinAura = true
+}
+
 func (moonkin *BalanceDruid) Initialize() {
 	moonkin.Druid.Initialize()
 
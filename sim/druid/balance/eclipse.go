@@ -282,6 +282,9 @@ func (eb *eclipseEnergyBar) addLunarEnergy(amount float64, sim *core.Simulation,
 	if sim.Log != nil {
 		eb.moonkin.Log(sim, "Gained %0.0f lunar energy from %s (%0.0f --> %0.0f) of %0.0f total.", gain, metrics.ActionID, old, eb.lunarEnergy, 100.0)
 	}
+	if sim.CombatLog != nil {
+		eb.moonkin.LogResourceChange(sim, metrics, amount, old, eb.lunarEnergy)
+	}
 
 	if eb.lunarEnergy == 100 {
 		eb.SetEclipse(LunarEclipse, sim, spell)
@@ -352,6 +355,9 @@ func (eb *eclipseEnergyBar) addSolarEnergy(amount float64, sim *core.Simulation,
 	if sim.Log != nil {
 		eb.moonkin.Log(sim, "Gained %0.0f solar energy from %s (%0.0f --> %0.0f) of %0.0f total.", gain, metrics.ActionID, old, eb.solarEnergy, 100.0)
 	}
+	if sim.CombatLog != nil {
+		eb.moonkin.LogResourceChange(sim, metrics, amount, old, eb.solarEnergy)
+	}
 
 	if eb.solarEnergy == 100 {
 		eb.SetEclipse(SolarEclipse, sim, spell)
@@ -81,6 +81,7 @@ type Druid struct {
 	StampedePendingAura      *core.Aura
 	TigersFury4PT15Aura      *core.Aura
 	SurvivalInstinctsAura    *core.Aura
+	TreeOfLifeAura           *core.Aura
 	WeakenedBlowsAuras       core.AuraArray
 
 	form DruidForm
@@ -138,6 +139,7 @@ const (
 	DruidSpellWildGrowth
 	DruidSpellCenarionWard
 	DruidSpellCelestialAlignment
+	DruidSpellEfflorescence
 
 	DruidSpellLast
 	DruidSpellsAll               = DruidSpellLast<<1 - 1
@@ -164,17 +166,9 @@ func (druid *Druid) GetCharacter() *core.Character {
 	return &druid.Character
 }
 
-// func (druid *Druid) AddRaidBuffs(raidBuffs *proto.RaidBuffs) {
-// 	if druid.InForm(Cat|Bear) && druid.Talents.LeaderOfThePack {
-// 		raidBuffs.LeaderOfThePack = true
-// 	}
-
-// 	if druid.InForm(Moonkin) {
-// 		raidBuffs.MoonkinForm = true
-// 	}
-
-// 	raidBuffs.MarkOfTheWild = true
-// }
+func (druid *Druid) AddRaidMemberBuffs(raidBuffs *proto.RaidBuffs) {
+	raidBuffs.MarkOfTheWild = true
+}
 
 func (druid *Druid) HasMajorGlyph(glyph proto.DruidMajorGlyph) bool {
 	return druid.HasGlyph(int32(glyph))
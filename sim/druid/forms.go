@@ -44,6 +44,8 @@ func (druid *Druid) ClearForm(sim *core.Simulation) {
 		druid.BearFormAura.Deactivate(sim)
 	} else if druid.InForm(Moonkin) {
 		druid.MoonkinFormAura.Deactivate(sim)
+	} else if druid.InForm(Tree) {
+		druid.TreeOfLifeAura.Deactivate(sim)
 	}
 
 	druid.form = Humanoid
@@ -314,3 +316,26 @@ func (druid *Druid) RegisterMoonkinFormSpell() {
 		},
 	})
 }
+
+// Tree of Life is only available to Restoration through Incarnation, which
+// attaches the healing bonuses and owns the cooldown.
+func (druid *Druid) RegisterTreeOfLifeAura() {
+	druid.TreeOfLifeAura = druid.RegisterAura(core.Aura{
+		Label:    "Incarnation: Tree of Life",
+		ActionID: core.ActionID{SpellID: 33891},
+		Duration: time.Second * 30,
+		OnGain: func(aura *core.Aura, sim *core.Simulation) {
+			if !druid.Env.MeasuringStats && druid.form != Tree {
+				druid.ClearForm(sim)
+			}
+
+			druid.form = Tree
+			druid.SetCurrentPowerBar(core.ManaBar)
+		},
+		OnExpire: func(aura *core.Aura, sim *core.Simulation) {
+			druid.form = Humanoid
+		},
+	}).AttachStatDependency(
+		druid.NewDynamicMultiplyStat(stats.Armor, 2.2),
+	)
+}
@@ -15,7 +15,7 @@ const (
 func (druid *Druid) registerHealingTouchSpell() {
 	actionID := core.ActionID{SpellID: 5185}
 
-	druid.HealingTouch = druid.RegisterSpell(Humanoid|Moonkin, core.SpellConfig{
+	druid.HealingTouch = druid.RegisterSpell(Humanoid|Moonkin|Tree, core.SpellConfig{
 		ActionID:       actionID,
 		SpellSchool:    core.SpellSchoolNature,
 		ProcMask:       core.ProcMaskSpellHealing,
@@ -33,7 +33,7 @@ func (druid *Druid) registerHealingTouchSpell() {
 			},
 
 			ModifyCast: func(sim *core.Simulation, spell *core.Spell, curCast *core.Cast) {
-				if druid.InForm(Cat|Bear) {
+				if druid.InForm(Cat | Bear) {
 					return
 				}
 
@@ -22,7 +22,7 @@ func (druid *Druid) registerNaturesSwiftness() {
 		Duration: core.NeverExpires,
 
 		OnReset: func(_ *core.Aura, _ *core.Simulation) {
-			druid.HealingTouch.FormMask = Humanoid | Moonkin
+			druid.HealingTouch.FormMask = Humanoid | Moonkin | Tree
 		},
 
 		OnGain: func(_ *core.Aura, _ *core.Simulation) {
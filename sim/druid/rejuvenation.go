@@ -14,7 +14,7 @@ const (
 func (druid *Druid) registerRejuvenationSpell() {
 	baseTickDamage := RejuvenationCoeff * druid.ClassSpellScaling
 
-	druid.Rejuvenation = druid.RegisterSpell(Humanoid|Moonkin, core.SpellConfig{
+	druid.Rejuvenation = druid.RegisterSpell(Humanoid|Moonkin|Tree, core.SpellConfig{
 		ActionID:         core.ActionID{SpellID: 774},
 		SpellSchool:      core.SpellSchoolNature,
 		ProcMask:         core.ProcMaskSpellHealing,
@@ -0,0 +1,132 @@
+package restoration
+
+import (
+	"time"
+
+	"github.com/wowsims/mop/sim/core"
+	"github.com/wowsims/mop/sim/core/proto"
+	"github.com/wowsims/mop/sim/druid"
+)
+
+const (
+	LifebloomTickBonusCoeff  = 0.0234
+	LifebloomTickCoeff       = 0.2
+	LifebloomBloomBonusCoeff = 0.752
+	LifebloomBloomCoeff      = 5.795
+)
+
+/*
+Tooltip:
+Heals the target over 15 sec. When Lifebloom completes its duration, the target instantly heals themselves.
+This effect stacks up to 3 times on the same target. Lifebloom can be active only on one target at a time.
+Lifebloom's duration is refreshed by your Healing Touch, Nourish and Regrowth spells.
+*/
+func (resto *RestorationDruid) registerLifebloomSpell() {
+	baseTickHealing := LifebloomTickCoeff * resto.ClassSpellScaling
+	baseBloomHealing := LifebloomBloomCoeff * resto.ClassSpellScaling
+	bloomStacks := int32(0)
+
+	// Glyph of Blooming increases the bloom by 50%, but Healing Touch, Nourish
+	// and Regrowth no longer refresh Lifebloom.
+	hasGlyph := resto.HasMajorGlyph(proto.DruidMajorGlyph_GlyphOfBlooming)
+	bloomMultiplier := core.TernaryFloat64(hasGlyph, 1.5, 1)
+
+	resto.LifebloomBloom = resto.RegisterSpell(druid.Any, core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: 33778},
+		SpellSchool:      core.SpellSchoolNature,
+		ProcMask:         core.ProcMaskSpellHealing,
+		ClassSpellMask:   druid.DruidSpellLifebloom,
+		Flags:            core.SpellFlagHelpful | core.SpellFlagNoOnCastComplete | core.SpellFlagPassiveSpell,
+		DamageMultiplier: 1,
+		ThreatMultiplier: 1,
+		CritMultiplier:   resto.DefaultCritMultiplier(),
+		BonusCoefficient: LifebloomBloomBonusCoeff,
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			multiplier := float64(bloomStacks) * bloomMultiplier
+			spell.DamageMultiplier *= multiplier
+			spell.CalcAndDealHealing(sim, target, baseBloomHealing, spell.OutcomeHealingCrit)
+			spell.DamageMultiplier /= multiplier
+		},
+	})
+
+	resto.Lifebloom = resto.RegisterSpell(druid.Humanoid|druid.Tree, core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: 33763},
+		SpellSchool:      core.SpellSchoolNature,
+		ProcMask:         core.ProcMaskSpellHealing,
+		ClassSpellMask:   druid.DruidSpellLifebloom,
+		Flags:            core.SpellFlagHelpful | core.SpellFlagAPL,
+		MaxRange:         40,
+		DamageMultiplier: 1,
+		ThreatMultiplier: 1,
+		CritMultiplier:   resto.DefaultCritMultiplier(),
+
+		ManaCost: core.ManaCostOptions{
+			BaseCostPercent: 5.9,
+		},
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.GCDDefault,
+			},
+		},
+
+		Hot: core.DotConfig{
+			Aura: core.Aura{
+				Label:     "Lifebloom",
+				MaxStacks: 3,
+			},
+
+			NumberOfTicks:       15,
+			TickLength:          time.Second,
+			AffectedByCastSpeed: true,
+			BonusCoefficient:    LifebloomTickBonusCoeff,
+
+			OnSnapshot: func(_ *core.Simulation, target *core.Unit, dot *core.Dot, _ bool) {
+				dot.SnapshotHeal(target, baseTickHealing)
+				dot.SnapshotBaseDamage *= float64(max(dot.Aura.GetStacks(), 1))
+			},
+
+			OnTick: func(sim *core.Simulation, target *core.Unit, dot *core.Dot) {
+				dot.CalcAndDealPeriodicSnapshotHealing(sim, target, dot.OutcomeSnapshotCrit)
+
+				// Only a Lifebloom which runs its full duration blooms. Refreshing
+				// it or moving it to another target does not.
+				if dot.RemainingTicks() == 0 {
+					bloomStacks = dot.Aura.GetStacks()
+					resto.LifebloomBloom.Cast(sim, target)
+				}
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			target = resto.healTarget(target)
+
+			if prev := resto.lifebloomTarget; prev != nil && prev != target && !resto.InForm(druid.Tree) {
+				spell.Hot(prev).Deactivate(sim)
+			}
+			resto.lifebloomTarget = target
+
+			hot := spell.Hot(target)
+			hot.Apply(sim)
+			hot.AddStack(sim)
+			hot.TakeSnapshot(sim, false)
+		},
+	})
+
+	if hasGlyph {
+		return
+	}
+
+	resto.MakeProcTriggerAura(core.ProcTrigger{
+		Name:           "Lifebloom Refresh",
+		Callback:       core.CallbackOnHealDealt,
+		ClassSpellMask: druid.DruidSpellHealingTouch | druid.DruidSpellNourish | druid.DruidSpellRegrowth,
+
+		Handler: func(sim *core.Simulation, _ *core.Spell, result *core.SpellResult) {
+			if hot := resto.Lifebloom.Hot(result.Target); hot.IsActive() {
+				hot.Apply(sim)
+			}
+		},
+	})
+}
@@ -0,0 +1,116 @@
+package restoration
+
+import (
+	"time"
+
+	"github.com/wowsims/mop/sim/core"
+	"github.com/wowsims/mop/sim/druid"
+)
+
+const harmonyDirectHealingSpells = druid.DruidSpellHealingTouch | druid.DruidSpellNourish | druid.DruidSpellSwiftmend
+
+// Mastery: Harmony. Direct heals are increased, and casting them increases
+// periodic healing for 20 seconds.
+func (resto *RestorationDruid) registerHarmony() {
+	directMod := resto.AddDynamicMod(core.SpellModConfig{
+		Kind:      core.SpellMod_DamageDone_Pct,
+		ClassMask: harmonyDirectHealingSpells,
+	})
+
+	core.MakePermanent(resto.RegisterAura(core.Aura{
+		Label:    "Mastery: Harmony",
+		ActionID: core.ActionID{SpellID: 77495},
+
+		OnGain: func(_ *core.Aura, _ *core.Simulation) {
+			directMod.UpdateFloatValue(resto.harmonyPercent())
+			directMod.Activate()
+		},
+		OnExpire: func(_ *core.Aura, _ *core.Simulation) {
+			directMod.Deactivate()
+		},
+	}))
+
+	periodicMultiplier := 1.0
+	setPeriodicMultiplier := func(multiplier float64) {
+		resto.PseudoStats.PeriodicHealingDealtMultiplier *= multiplier / periodicMultiplier
+		periodicMultiplier = multiplier
+	}
+
+	resto.HarmonyAura = resto.RegisterAura(core.Aura{
+		Label:    "Harmony",
+		ActionID: core.ActionID{SpellID: 100977},
+		Duration: time.Second * 20,
+
+		OnGain: func(_ *core.Aura, _ *core.Simulation) {
+			setPeriodicMultiplier(resto.harmonyDirectMultiplier())
+		},
+		OnExpire: func(_ *core.Aura, _ *core.Simulation) {
+			setPeriodicMultiplier(1)
+		},
+	})
+
+	resto.AddOnMasteryStatChanged(func(_ *core.Simulation, _ float64, _ float64) {
+		directMod.UpdateFloatValue(resto.harmonyPercent())
+		if resto.HarmonyAura.IsActive() {
+			setPeriodicMultiplier(resto.harmonyDirectMultiplier())
+		}
+	})
+
+	resto.MakeProcTriggerAura(core.ProcTrigger{
+		Name:           "Harmony Trigger",
+		Callback:       core.CallbackOnCastComplete,
+		ClassSpellMask: harmonyDirectHealingSpells | druid.DruidSpellRegrowth,
+
+		Handler: func(sim *core.Simulation, _ *core.Spell, _ *core.SpellResult) {
+			resto.HarmonyAura.Activate(sim)
+		},
+	})
+}
+
+func (resto *RestorationDruid) harmonyPercent() float64 {
+	return (8 + resto.GetMasteryPoints()) * 1.25 / 100
+}
+
+func (resto *RestorationDruid) harmonyDirectMultiplier() float64 {
+	return 1 + resto.harmonyPercent()
+}
+
+/*
+Incarnation: Tree of Life. Increases healing done by 15%, makes Regrowth instant, allows Lifebloom to be active on
+multiple targets and causes Wild Growth to heal 2 additional targets.
+*/
+func (resto *RestorationDruid) registerIncarnation() {
+	if !resto.Talents.Incarnation {
+		return
+	}
+
+	resto.RegisterTreeOfLifeAura()
+	resto.TreeOfLifeAura.AttachMultiplicativePseudoStatBuff(
+		&resto.PseudoStats.HealingDealtMultiplier, 1.15,
+	).AttachSpellMod(core.SpellModConfig{
+		Kind:       core.SpellMod_CastTime_Pct,
+		ClassMask:  druid.DruidSpellRegrowth,
+		FloatValue: -1,
+	})
+
+	resto.TreeOfLife = resto.RegisterSpell(druid.Any, core.SpellConfig{
+		ActionID:        resto.TreeOfLifeAura.ActionID,
+		Flags:           core.SpellFlagAPL,
+		RelatedSelfBuff: resto.TreeOfLifeAura,
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.GCDDefault,
+			},
+			CD: core.Cooldown{
+				Timer:    resto.NewTimer(),
+				Duration: time.Minute * 3,
+			},
+			IgnoreHaste: true,
+		},
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			spell.RelatedSelfBuff.Activate(sim)
+		},
+	})
+}
@@ -0,0 +1,92 @@
+package restoration
+
+import (
+	"time"
+
+	"github.com/wowsims/mop/sim/core"
+	"github.com/wowsims/mop/sim/core/proto"
+	"github.com/wowsims/mop/sim/druid"
+)
+
+const (
+	RegrowthBonusCoeff    = 0.958
+	RegrowthCoeff         = 9.23
+	RegrowthVariance      = 0.116
+	RegrowthHotBonusCoeff = 0.0258
+	RegrowthHotCoeff      = 0.25
+	RegrowthBonusCrit     = 60
+)
+
+/*
+Tooltip:
+Heals a friendly target and another amount over 6 sec. Regrowth has a 60% increased chance for a critical effect.
+*/
+func (resto *RestorationDruid) registerRegrowthSpell() {
+	baseTickHealing := RegrowthHotCoeff * resto.ClassSpellScaling
+	hasGlyph := resto.HasMajorGlyph(proto.DruidMajorGlyph_GlyphOfRegrowth)
+
+	// Glyph of Regrowth trades the HoT for an additional 40% critical strike
+	// chance on the direct heal.
+	bonusCrit := float64(RegrowthBonusCrit) + core.TernaryFloat64(hasGlyph, 40, 0)
+
+	resto.Regrowth = resto.RegisterSpell(druid.Humanoid|druid.Tree, core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: 8936},
+		SpellSchool:      core.SpellSchoolNature,
+		ProcMask:         core.ProcMaskSpellHealing,
+		ClassSpellMask:   druid.DruidSpellRegrowth,
+		Flags:            core.SpellFlagHelpful | core.SpellFlagAPL,
+		MaxRange:         40,
+		DamageMultiplier: 1,
+		ThreatMultiplier: 1,
+		CritMultiplier:   resto.DefaultCritMultiplier(),
+		BonusCritPercent: bonusCrit,
+		BonusCoefficient: RegrowthBonusCoeff,
+
+		ManaCost: core.ManaCostOptions{
+			BaseCostPercent: 29.7,
+		},
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD:      core.GCDDefault,
+				CastTime: time.Millisecond * 1500,
+			},
+		},
+
+		Hot: core.DotConfig{
+			Aura: core.Aura{
+				Label: "Regrowth",
+			},
+
+			NumberOfTicks:    3,
+			TickLength:       time.Second * 2,
+			BonusCoefficient: RegrowthHotBonusCoeff,
+
+			OnSnapshot: func(_ *core.Simulation, target *core.Unit, dot *core.Dot, _ bool) {
+				dot.SnapshotHeal(target, baseTickHealing)
+
+				// The increased critical strike chance only applies to the direct heal.
+				dot.SnapshotCritChance = max(dot.SnapshotCritChance-bonusCrit/100, 0)
+			},
+
+			OnTick: func(sim *core.Simulation, target *core.Unit, dot *core.Dot) {
+				dot.CalcAndDealPeriodicSnapshotHealing(sim, target, dot.OutcomeSnapshotCrit)
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			target = resto.healTarget(target)
+
+			baseHealing := resto.CalcAndRollDamageRange(sim, RegrowthCoeff, RegrowthVariance)
+			result := spell.CalcHealing(sim, target, baseHealing, spell.OutcomeHealingCrit)
+
+			// Harmony only increases the direct portion of Regrowth.
+			result.Damage *= resto.harmonyDirectMultiplier()
+			spell.DealHealing(sim, result)
+
+			if !hasGlyph {
+				spell.Hot(target).Apply(sim)
+			}
+		},
+	})
+}
@@ -3,6 +3,7 @@ package restoration
 import (
 	"github.com/wowsims/mop/sim/core"
 	"github.com/wowsims/mop/sim/core/proto"
+	"github.com/wowsims/mop/sim/core/stats"
 	"github.com/wowsims/mop/sim/druid"
 )
 
@@ -28,7 +29,7 @@ func NewRestorationDruid(character *core.Character, options *proto.Player) *Rest
 	selfBuffs := druid.SelfBuffs{}
 
 	resto := &RestorationDruid{
-		Druid: druid.New(character, druid.Tree, selfBuffs, options.TalentsString),
+		Druid: druid.New(character, druid.Humanoid, selfBuffs, options.TalentsString),
 	}
 
 	resto.SelfBuffs.InnervateTarget = &proto.UnitReference{}
@@ -41,6 +42,19 @@ func NewRestorationDruid(character *core.Character, options *proto.Player) *Rest
 
 type RestorationDruid struct {
 	*druid.Druid
+
+	Lifebloom      *druid.DruidSpell
+	LifebloomBloom *druid.DruidSpell
+	Regrowth       *druid.DruidSpell
+	WildGrowth     *druid.DruidSpell
+	Swiftmend      *druid.DruidSpell
+	Efflorescence  *druid.DruidSpell
+	TreeOfLife     *druid.DruidSpell
+
+	HarmonyAura *core.Aura
+
+	// Lifebloom can only be active on one target outside of Tree of Life.
+	lifebloomTarget *core.Unit
 }
 
 func (resto *RestorationDruid) GetDruid() *druid.Druid {
@@ -49,10 +63,38 @@ func (resto *RestorationDruid) GetDruid() *druid.Druid {
 
 func (resto *RestorationDruid) Initialize() {
 	resto.Druid.Initialize()
+
+	resto.registerLifebloomSpell()
+	resto.registerRegrowthSpell()
+	resto.registerWildGrowthSpell()
+	resto.registerSwiftmendSpell()
+	resto.registerEfflorescence()
+	resto.registerIncarnation()
 }
 
-func (resto *RestorationDruid) ApplyTalents() {}
+func (resto *RestorationDruid) ApplyTalents() {
+	resto.Druid.ApplyTalents()
+	resto.ApplyArmorSpecializationEffect(stats.Intellect, proto.ArmorType_ArmorTypeLeather, 86104)
+
+	// Meditation
+	resto.PseudoStats.SpiritRegenRateCombat = 0.5
+
+	// Naturalist
+	resto.PseudoStats.HealingDealtMultiplier *= 1.1
+
+	resto.registerHarmony() // Mastery
+}
 
 func (resto *RestorationDruid) Reset(sim *core.Simulation) {
+	resto.lifebloomTarget = nil
 	resto.Druid.Reset(sim)
 }
+
+// Helpful spells cast at an enemy, such as the default APL target, land on the
+// druid instead.
+func (resto *RestorationDruid) healTarget(target *core.Unit) *core.Unit {
+	if target == nil || target.IsOpponent(&resto.Unit) {
+		return &resto.Unit
+	}
+	return target
+}
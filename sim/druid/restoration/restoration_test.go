@@ -0,0 +1,120 @@
+package restoration
+
+import (
+	"testing"
+
+	"github.com/wowsims/mop/sim/common" // imported to get item effects included.
+	"github.com/wowsims/mop/sim/core"
+	"github.com/wowsims/mop/sim/core/proto"
+)
+
+func init() {
+	RegisterRestorationDruid()
+	common.RegisterAllEffects()
+}
+
+func TestRestoration(t *testing.T) {
+	core.RunTestSuite(t, t.Name(), core.FullCharacterTestSuiteGenerator([]core.CharacterSuiteConfig{
+		{
+			Class:      proto.Class_ClassDruid,
+			Race:       proto.Race_RaceTauren,
+			OtherRaces: []proto.Race{proto.Race_RaceNightElf},
+
+			GearSet:     core.GetGearSet("../../../ui/druid/balance/gear_sets", "t15"),
+			Talents:     StandardTalents,
+			Glyphs:      &proto.Glyphs{},
+			Consumables: FullConsumesSpec,
+			SpecOptions: core.SpecOptionsCombo{Label: "Default", SpecOptions: PlayerOptionsRestoration},
+			Rotation:    core.GetAplRotation("../../../ui/druid/restoration/apls", "default"),
+
+			IsHealer: true,
+
+			ItemFilter: core.ItemFilter{
+				WeaponTypes: []proto.WeaponType{
+					proto.WeaponType_WeaponTypeDagger,
+					proto.WeaponType_WeaponTypeMace,
+					proto.WeaponType_WeaponTypeOffHand,
+					proto.WeaponType_WeaponTypeStaff,
+					proto.WeaponType_WeaponTypePolearm,
+				},
+				ArmorType: proto.ArmorType_ArmorTypeLeather,
+			},
+		},
+	}))
+}
+
+var StandardTalents = "113221"
+
+var FullConsumesSpec = &proto.ConsumesSpec{
+	FlaskId:  76085, // Flask of the Warm Sun
+	FoodId:   74650, // Mogu Fish Stew
+	PotId:    76093, // Potion of the Jade Serpent
+	PrepotId: 76093, // Potion of the Jade Serpent
+}
+
+var PlayerOptionsRestoration = &proto.Player_RestorationDruid{
+	RestorationDruid: &proto.RestorationDruid{
+		Options: &proto.RestorationDruid_Options{
+			ClassOptions: &proto.DruidOptions{},
+		},
+	},
+}
+
+func TestLifebloomBlooms(t *testing.T) {
+	player := &proto.Player{
+		Name:      "Restoration",
+		Race:      proto.Race_RaceTauren,
+		Class:     proto.Class_ClassDruid,
+		Equipment: &proto.EquipmentSpec{},
+		Rotation: core.APLRotationFromJsonString(`{
+			"type": "TypeAPL",
+			"priorityList": [
+				{"action":{"condition":{"cmp":{"op":"OpLt","lhs":{"auraNumStacks":{"sourceUnit":{"type":"Player","index":1},"auraId":{"spellId":33763}}},"rhs":{"const":{"val":"3"}}}},"castFriendlySpell":{"spellId":{"spellId":33763},"target":{"type":"Player","index":1}}}}
+			]
+		}`),
+		Spec: &proto.Player_RestorationDruid{
+			RestorationDruid: &proto.RestorationDruid{
+				Options: &proto.RestorationDruid_Options{
+					ClassOptions: &proto.DruidOptions{},
+				},
+			},
+		},
+		Glyphs: &proto.Glyphs{},
+		Buffs:  &proto.IndividualBuffs{},
+	}
+
+	raid := core.SinglePlayerRaidProto(player, &proto.PartyBuffs{}, &proto.RaidBuffs{}, &proto.Debuffs{})
+	raid.TargetDummies = 1
+	result := core.RunRaidSim(&proto.RaidSimRequest{
+		Raid: raid,
+		Encounter: &proto.Encounter{
+			Duration: 60,
+			Targets:  []*proto.Target{core.NewDefaultTarget()},
+		},
+		SimOptions: &proto.SimOptions{
+			Iterations: 1,
+			RandomSeed: 101,
+		},
+	})
+	if result.Error != nil {
+		t.Fatal(result.Error.Message)
+	}
+
+	// Lifebloom is never refreshed before it runs out, so every application blooms.
+	var lifebloomCasts, blooms int32
+	for _, action := range result.RaidMetrics.Parties[0].Players[0].Actions {
+		for _, target := range action.Targets {
+			switch action.Id.GetSpellId() {
+			case 33763:
+				lifebloomCasts += target.Casts
+			case 33778:
+				blooms += target.Hits + target.Crits
+			}
+		}
+	}
+	// Three stacks are applied every time the previous Lifebloom ran out. The
+	// fourth set of stacks is still ticking when the fight ends.
+	if lifebloomCasts != 12 || blooms != 3 {
+		t.Errorf("Expected 12 Lifebloom casts and 3 blooms, got %d casts and %d blooms", lifebloomCasts, blooms)
+	}
+}
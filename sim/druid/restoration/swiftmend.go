@@ -0,0 +1,113 @@
+package restoration
+
+import (
+	"time"
+
+	"github.com/wowsims/mop/sim/core"
+	"github.com/wowsims/mop/sim/druid"
+)
+
+const (
+	SwiftmendBonusCoeff     = 1.29
+	SwiftmendCoeff          = 12.16
+	EfflorescenceBonusCoeff = 0.238
+	EfflorescenceCoeff      = 0.47
+)
+
+/*
+Tooltip:
+Instantly heals a friendly target that has an active Rejuvenation or Regrowth effect.
+*/
+func (resto *RestorationDruid) registerSwiftmendSpell() {
+	baseHealing := SwiftmendCoeff * resto.ClassSpellScaling
+
+	hasHot := func(target *core.Unit) bool {
+		return resto.Rejuvenation.Hot(target).IsActive() || resto.Regrowth.Hot(target).IsActive()
+	}
+
+	resto.Swiftmend = resto.RegisterSpell(druid.Humanoid|druid.Tree, core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: 18562},
+		SpellSchool:      core.SpellSchoolNature,
+		ProcMask:         core.ProcMaskSpellHealing,
+		ClassSpellMask:   druid.DruidSpellSwiftmend,
+		Flags:            core.SpellFlagHelpful | core.SpellFlagAPL,
+		MaxRange:         40,
+		DamageMultiplier: 1,
+		ThreatMultiplier: 1,
+		CritMultiplier:   resto.DefaultCritMultiplier(),
+		BonusCoefficient: SwiftmendBonusCoeff,
+
+		ManaCost: core.ManaCostOptions{
+			BaseCostPercent: 8.5,
+		},
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.GCDDefault,
+			},
+			CD: core.Cooldown{
+				Timer:    resto.NewTimer(),
+				Duration: time.Second * 15,
+			},
+		},
+
+		ExtraCastCondition: func(_ *core.Simulation, target *core.Unit) bool {
+			return hasHot(resto.healTarget(target))
+		},
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			target = resto.healTarget(target)
+			spell.CalcAndDealHealing(sim, target, baseHealing, spell.OutcomeHealingCrit)
+			resto.Efflorescence.Cast(sim, target)
+		},
+	})
+}
+
+// Swiftmend causes healing flowers to sprout beneath the target, healing up to
+// 3 of the most injured allies every 2 seconds for 30 seconds.
+func (resto *RestorationDruid) registerEfflorescence() {
+	baseHealing := EfflorescenceCoeff * resto.ClassSpellScaling
+
+	efflorescenceHeal := resto.RegisterSpell(druid.Any, core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: 81269},
+		SpellSchool:      core.SpellSchoolNature,
+		ProcMask:         core.ProcMaskSpellHealing,
+		ClassSpellMask:   druid.DruidSpellEfflorescence,
+		Flags:            core.SpellFlagHelpful | core.SpellFlagNoOnCastComplete | core.SpellFlagPassiveSpell,
+		DamageMultiplier: 1,
+		ThreatMultiplier: 1,
+		CritMultiplier:   resto.DefaultCritMultiplier(),
+		BonusCoefficient: EfflorescenceBonusCoeff,
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			spell.CalcAndDealPeriodicHealing(sim, target, baseHealing, spell.OutcomeHealingCrit)
+		},
+	})
+
+	resto.Efflorescence = resto.RegisterSpell(druid.Any, core.SpellConfig{
+		ActionID:       core.ActionID{SpellID: 81262},
+		SpellSchool:    core.SpellSchoolNature,
+		ProcMask:       core.ProcMaskEmpty,
+		Flags:          core.SpellFlagHelpful | core.SpellFlagNoOnCastComplete | core.SpellFlagPassiveSpell,
+		ClassSpellMask: druid.DruidSpellEfflorescence,
+
+		Hot: core.DotConfig{
+			SelfOnly: true,
+			Aura: core.Aura{
+				Label: "Efflorescence",
+			},
+			NumberOfTicks: 15,
+			TickLength:    time.Second * 2,
+
+			OnTick: func(sim *core.Simulation, _ *core.Unit, _ *core.Dot) {
+				for _, target := range sim.Environment.Raid.GetLowestHealthAllyUnits(3) {
+					efflorescenceHeal.Cast(sim, target)
+				}
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			spell.SelfHot().Apply(sim)
+		},
+	})
+}
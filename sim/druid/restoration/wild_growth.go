@@ -0,0 +1,107 @@
+package restoration
+
+import (
+	"time"
+
+	"github.com/wowsims/mop/sim/core"
+	"github.com/wowsims/mop/sim/core/proto"
+	"github.com/wowsims/mop/sim/druid"
+)
+
+const (
+	WildGrowthBonusCoeff = 0.1311
+	WildGrowthCoeff      = 0.908
+
+	// Wild Growth heals quickly at first and slows down over its duration.
+	// The first tick heals for 30% more than the average tick and the last
+	// one for 30% less.
+	WildGrowthDecay = 0.3
+)
+
+/*
+Tooltip:
+Heals up to 5 friendly party or raid members within 30 yards of the target over 7 sec.
+Prioritizes healing most injured party members. The amount healed is applied quickly at first, and slows down as the Wild Growth reaches its full duration.
+*/
+func (resto *RestorationDruid) registerWildGrowthSpell() {
+	baseTickHealing := WildGrowthCoeff * resto.ClassSpellScaling
+	hasGlyph := resto.HasMajorGlyph(proto.DruidMajorGlyph_GlyphOfWildGrowth)
+
+	numTargets := core.TernaryInt(hasGlyph, 6, 5)
+	cooldown := core.TernaryDuration(hasGlyph, time.Second*10, time.Second*8)
+
+	resto.WildGrowth = resto.RegisterSpell(druid.Humanoid|druid.Tree, core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: 48438},
+		SpellSchool:      core.SpellSchoolNature,
+		ProcMask:         core.ProcMaskSpellHealing,
+		ClassSpellMask:   druid.DruidSpellWildGrowth,
+		Flags:            core.SpellFlagHelpful | core.SpellFlagAPL,
+		MaxRange:         40,
+		DamageMultiplier: 1,
+		ThreatMultiplier: 1,
+		CritMultiplier:   resto.DefaultCritMultiplier(),
+
+		ManaCost: core.ManaCostOptions{
+			BaseCostPercent: 22.9,
+		},
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.GCDDefault,
+			},
+			CD: core.Cooldown{
+				Timer:    resto.NewTimer(),
+				Duration: cooldown,
+			},
+		},
+
+		Hot: core.DotConfig{
+			Aura: core.Aura{
+				Label: "Wild Growth",
+			},
+
+			NumberOfTicks:       7,
+			TickLength:          time.Second,
+			AffectedByCastSpeed: true,
+			BonusCoefficient:    WildGrowthBonusCoeff,
+
+			OnSnapshot: func(_ *core.Simulation, target *core.Unit, dot *core.Dot, _ bool) {
+				dot.SnapshotHeal(target, baseTickHealing)
+			},
+
+			OnTick: func(sim *core.Simulation, target *core.Unit, dot *core.Dot) {
+				snapshot := dot.SnapshotBaseDamage
+				dot.SnapshotBaseDamage *= wildGrowthTickMultiplier(dot.TickCount(), dot.HastedTickCount())
+				dot.CalcAndDealPeriodicSnapshotHealing(sim, target, dot.OutcomeSnapshotCrit)
+				dot.SnapshotBaseDamage = snapshot
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			target = resto.healTarget(target)
+			spell.Hot(target).Apply(sim)
+
+			// Tree of Life lets Wild Growth heal 2 additional targets.
+			remaining := numTargets - 1 + core.TernaryInt(resto.InForm(druid.Tree), 2, 0)
+			for _, unit := range sim.Environment.Raid.GetLowestHealthAllyUnits(len(sim.Environment.AllUnits)) {
+				if remaining == 0 {
+					break
+				}
+				if unit == target {
+					continue
+				}
+				spell.Hot(unit).Apply(sim)
+				remaining--
+			}
+		},
+	})
+}
+
+// Scales the n-th of numTicks ticks so that the healing decays linearly over
+// the duration while the total stays the same.
+func wildGrowthTickMultiplier(n int32, numTicks int32) float64 {
+	if numTicks <= 1 {
+		return 1
+	}
+	return 1 + WildGrowthDecay*float64(numTicks+1-2*n)/float64(numTicks-1)
+}
@@ -62,6 +62,40 @@ func computeStats(json *C.char) *C.char {
 	return C.CString(string(out))
 }
 
+//export optimizeGear
+func optimizeGear(json *C.char) *C.char {
+	input := &proto.GearOptimizationRequest{}
+	jsonString := C.GoString(json)
+	err := protojson.Unmarshal([]byte(jsonString), input)
+	if err != nil {
+		log.Fatalf("failed to load input json file: %s", err)
+	}
+	sim.RegisterAll()
+	result := core.OptimizeGear(input)
+	out, err := protojson.Marshal(result)
+	if err != nil {
+		panic(err)
+	}
+	return C.CString(string(out))
+}
+
+//export tuneAPL
+func tuneAPL(json *C.char) *C.char {
+	input := &proto.APLTuningRequest{}
+	jsonString := C.GoString(json)
+	err := protojson.Unmarshal([]byte(jsonString), input)
+	if err != nil {
+		log.Fatalf("failed to load input json file: %s", err)
+	}
+	sim.RegisterAll()
+	result := core.TuneAPL(input)
+	out, err := protojson.Marshal(result)
+	if err != nil {
+		panic(err)
+	}
+	return C.CString(string(out))
+}
+
 //export encodeSettings
 func encodeSettings(json *C.char) *C.char {
 	input := &proto.RaidSimRequest{}
@@ -79,6 +79,12 @@ func (mage *Mage) AddRaidBuffs(raidBuffs *proto.RaidBuffs) {
 	raidBuffs.ArcaneBrilliance = true
 }
 
+func (mage *Mage) AddDebuffs(debuffs *proto.Debuffs) {
+	if mage.Spec == proto.Spec_SpecArcaneMage {
+		debuffs.Slow = true
+	}
+}
+
 func (mage *Mage) AddPartyBuffs(partyBuffs *proto.PartyBuffs) {
 }
 
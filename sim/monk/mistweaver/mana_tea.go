@@ -0,0 +1,110 @@
+package mistweaver
+
+import (
+	"time"
+
+	"github.com/wowsims/mop/sim/core"
+	"github.com/wowsims/mop/sim/core/proto"
+	"github.com/wowsims/mop/sim/core/stats"
+	"github.com/wowsims/mop/sim/monk"
+)
+
+const manaTeaManaPerStack = 0.04
+
+/*
+Tooltip:
+For every 4 Chi you consume, you gain a stack of Mana Tea, with a chance equal to your critical strike chance to gain an extra stack.
+Mana Tea can be channeled to consume stacks, each stack restoring 4% of maximum mana.
+*/
+func (mw *MistweaverMonk) registerManaTea() {
+	actionID := core.ActionID{SpellID: 115294}
+	stackActionID := core.ActionID{SpellID: 115867}
+	manaMetrics := mw.NewManaMetrics(actionID)
+	hasGlyph := mw.HasMajorGlyph(proto.MonkMajorGlyph_GlyphOfManaTea)
+
+	mw.ManaTeaStackAura = core.BlockPrepull(mw.RegisterAura(core.Aura{
+		Label:     "Mana Tea Stacks" + mw.Label,
+		ActionID:  stackActionID,
+		Duration:  time.Minute * 2,
+		MaxStacks: 20,
+	}))
+
+	mw.Monk.RegisterOnChiSpent(func(sim *core.Simulation, chiSpent int32) {
+		accumulatedChi := mw.outstandingChi + chiSpent
+
+		for accumulatedChi >= 4 {
+			mw.AddBrewStacks(sim, 1)
+			accumulatedChi -= 4
+		}
+
+		mw.outstandingChi = accumulatedChi
+	})
+
+	mw.Monk.RegisterOnNewBrewStacks(func(sim *core.Simulation, stacksToAdd int32) {
+		if sim.Proc(mw.GetStat(stats.SpellCritPercent)/100, "Mana Tea") {
+			stacksToAdd += 1
+		}
+
+		mw.ManaTeaStackAura.Activate(sim)
+		mw.ManaTeaStackAura.SetStacks(sim, mw.ManaTeaStackAura.GetStacks()+stacksToAdd)
+	})
+
+	consumeStack := func(sim *core.Simulation) {
+		if mw.ManaTeaStackAura.GetStacks() == 0 {
+			return
+		}
+		mw.ManaTeaStackAura.RemoveStack(sim)
+		mw.AddMana(sim, mw.MaxMana()*manaTeaManaPerStack, manaMetrics)
+	}
+
+	config := core.SpellConfig{
+		ActionID:       actionID,
+		Flags:          core.SpellFlagHelpful | core.SpellFlagAPL,
+		ClassSpellMask: monk.MonkSpellManaTea,
+
+		ExtraCastCondition: func(sim *core.Simulation, target *core.Unit) bool {
+			return mw.ManaTeaStackAura.GetStacks() > 0
+		},
+	}
+
+	if hasGlyph {
+		// Glyph of Mana Tea makes Mana Tea instant, consuming 2 stacks at a time.
+		config.Cast = core.CastConfig{
+			CD: core.Cooldown{
+				Timer:    mw.NewTimer(),
+				Duration: time.Second * 10,
+			},
+		}
+		config.ApplyEffects = func(sim *core.Simulation, _ *core.Unit, _ *core.Spell) {
+			consumeStack(sim)
+			consumeStack(sim)
+		}
+	} else {
+		config.Flags |= core.SpellFlagChanneled
+		config.Cast = core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.GCDDefault,
+			},
+		}
+		config.Hot = core.DotConfig{
+			SelfOnly: true,
+			Aura: core.Aura{
+				Label: "Mana Tea",
+			},
+			NumberOfTicks: 20,
+			TickLength:    time.Millisecond * 500,
+
+			OnTick: func(sim *core.Simulation, _ *core.Unit, _ *core.Dot) {
+				consumeStack(sim)
+			},
+		}
+		config.ApplyEffects = func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			// The channel lasts as long as there are stacks left to consume.
+			hot := spell.SelfHot()
+			hot.BaseTickCount = mw.ManaTeaStackAura.GetStacks()
+			hot.Apply(sim)
+		}
+	}
+
+	mw.ManaTea = mw.RegisterSpell(config)
+}
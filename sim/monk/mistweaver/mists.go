@@ -0,0 +1,192 @@
+package mistweaver
+
+import (
+	"time"
+
+	"github.com/wowsims/mop/sim/core"
+	"github.com/wowsims/mop/sim/monk"
+)
+
+const mistweaverHealingSpells = monk.MonkSpellSoothingMist | monk.MonkSpellSurgingMist | monk.MonkSpellEnvelopingMist | monk.MonkSpellRenewingMist | monk.MonkSpellUplift
+
+/*
+Tooltip:
+Heals the target for $o1 over 8 sec. While channeling, Enveloping Mist and Surging Mist may be cast instantly on the target.
+Each heal has a chance to cause you to gain 1 Chi.
+*/
+func (mw *MistweaverMonk) registerSoothingMist() {
+	actionID := core.ActionID{SpellID: 115175}
+	chiMetrics := mw.NewChiMetrics(actionID)
+
+	instantCastMod := mw.AddDynamicMod(core.SpellModConfig{
+		Kind:       core.SpellMod_CastTime_Pct,
+		ClassMask:  monk.MonkSpellSurgingMist | monk.MonkSpellEnvelopingMist,
+		FloatValue: -1,
+	})
+
+	mw.SoothingMist = mw.RegisterSpell(core.SpellConfig{
+		ActionID:       actionID,
+		SpellSchool:    core.SpellSchoolNature,
+		ProcMask:       core.ProcMaskSpellHealing,
+		Flags:          core.SpellFlagHelpful | core.SpellFlagChanneled | core.SpellFlagCastWhileChanneling | core.SpellFlagAPL,
+		ClassSpellMask: monk.MonkSpellSoothingMist,
+		MaxRange:       40,
+
+		ManaCost: core.ManaCostOptions{
+			BaseCostPercent: 1,
+		},
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.GCDDefault,
+			},
+		},
+
+		DamageMultiplier: 1,
+		ThreatMultiplier: 1,
+		CritMultiplier:   mw.DefaultCritMultiplier(),
+
+		Hot: core.DotConfig{
+			Aura: core.Aura{
+				Label: "Soothing Mist",
+				OnGain: func(_ *core.Aura, _ *core.Simulation) {
+					instantCastMod.Activate()
+				},
+				OnExpire: func(_ *core.Aura, _ *core.Simulation) {
+					instantCastMod.Deactivate()
+				},
+			},
+			NumberOfTicks:       8,
+			TickLength:          time.Second,
+			AffectedByCastSpeed: true,
+			BonusCoefficient:    0.179,
+
+			OnTick: func(sim *core.Simulation, target *core.Unit, dot *core.Dot) {
+				// Enveloping Mist increases the healing done by Soothing Mist by 30%.
+				envelopingMistBonus := core.TernaryFloat64(mw.EnvelopingMist.Hot(target).IsActive(), 1.3, 1)
+				dot.Spell.DamageMultiplier *= envelopingMistBonus
+				dot.Spell.CalcAndDealPeriodicHealing(sim, target, mw.CalcScalingSpellDmg(1.95), dot.Spell.OutcomeHealingCrit)
+				dot.Spell.DamageMultiplier /= envelopingMistBonus
+
+				if sim.Proc(0.3, "Soothing Mist Chi") {
+					mw.AddChi(sim, dot.Spell, 1, chiMetrics)
+				}
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			spell.Hot(mw.healTarget(target)).Apply(sim)
+		},
+	})
+}
+
+/*
+Tooltip:
+Heals the target for ${$m1*$<mult>} to ${$M1*$<mult>}, and generates 1 Chi.
+*/
+func (mw *MistweaverMonk) registerSurgingMist() {
+	actionID := core.ActionID{SpellID: 116694}
+	chiMetrics := mw.NewChiMetrics(actionID)
+
+	mw.SurgingMist = mw.RegisterSpell(core.SpellConfig{
+		ActionID:       actionID,
+		SpellSchool:    core.SpellSchoolNature,
+		ProcMask:       core.ProcMaskSpellHealing,
+		Flags:          core.SpellFlagHelpful | core.SpellFlagCastWhileChanneling | core.SpellFlagAPL,
+		ClassSpellMask: monk.MonkSpellSurgingMist,
+		MaxRange:       40,
+
+		ManaCost: core.ManaCostOptions{
+			BaseCostPercent: 8.8,
+		},
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD:      core.GCDDefault,
+				CastTime: time.Millisecond * 1500,
+			},
+		},
+
+		DamageMultiplier: 1,
+		ThreatMultiplier: 1,
+		CritMultiplier:   mw.DefaultCritMultiplier(),
+		BonusCoefficient: 1.8,
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			target = mw.soothingMistTarget(target)
+
+			// Thunder Focus Tea doubles the healing of the next Surging Mist.
+			thunderFocusTeaBonus := core.TernaryFloat64(mw.ThunderFocusTeaAura.IsActive(), 2, 1)
+			mw.ThunderFocusTeaAura.Deactivate(sim)
+
+			spell.DamageMultiplier *= thunderFocusTeaBonus
+			baseHealing := mw.CalcAndRollDamageRange(sim, 17.53, 0.15)
+			spell.CalcAndDealHealing(sim, target, baseHealing, spell.OutcomeHealingCrit)
+			spell.DamageMultiplier /= thunderFocusTeaBonus
+
+			mw.AddChi(sim, spell, 1, chiMetrics)
+		},
+	})
+}
+
+/*
+Tooltip:
+Heals the target for $o1 over 6 sec, and increases healing done to the target by Soothing Mist by 30%.
+*/
+func (mw *MistweaverMonk) registerEnvelopingMist() {
+	actionID := core.ActionID{SpellID: 124682}
+	chiMetrics := mw.NewChiMetrics(actionID)
+	chiCost := int32(3)
+
+	mw.EnvelopingMist = mw.RegisterSpell(core.SpellConfig{
+		ActionID:       actionID,
+		SpellSchool:    core.SpellSchoolNature,
+		ProcMask:       core.ProcMaskSpellHealing,
+		Flags:          core.SpellFlagHelpful | core.SpellFlagCastWhileChanneling | monk.SpellFlagSpender | core.SpellFlagAPL,
+		ClassSpellMask: monk.MonkSpellEnvelopingMist,
+		MaxRange:       40,
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD:      core.GCDDefault,
+				CastTime: time.Second * 2,
+			},
+		},
+
+		ExtraCastCondition: func(sim *core.Simulation, target *core.Unit) bool {
+			return mw.GetChi() >= chiCost
+		},
+
+		DamageMultiplier: 1,
+		ThreatMultiplier: 1,
+		CritMultiplier:   mw.DefaultCritMultiplier(),
+
+		Hot: core.DotConfig{
+			Aura: core.Aura{
+				Label: "Enveloping Mist",
+			},
+			NumberOfTicks:    6,
+			TickLength:       time.Second,
+			BonusCoefficient: 0.18,
+
+			OnSnapshot: func(_ *core.Simulation, target *core.Unit, dot *core.Dot, _ bool) {
+				dot.SnapshotHeal(target, 0)
+			},
+			OnTick: func(sim *core.Simulation, target *core.Unit, dot *core.Dot) {
+				dot.CalcAndDealPeriodicSnapshotHealing(sim, target, dot.OutcomeSnapshotCrit)
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			mw.SpendChi(sim, chiCost, chiMetrics)
+			spell.Hot(mw.soothingMistTarget(target)).Apply(sim)
+		},
+	})
+}
+
+// Surging Mist and Enveloping Mist cast during Soothing Mist land on the
+// channel target.
+func (mw *MistweaverMonk) soothingMistTarget(target *core.Unit) *core.Unit {
+	if mw.IsChanneling() && mw.ChanneledDot.Spell == mw.SoothingMist {
+		return mw.ChanneledDot.Unit
+	}
+	return mw.healTarget(target)
+}
@@ -50,6 +50,23 @@ func NewMistweaverMonk(character *core.Character, options *proto.Player) *Mistwe
 
 type MistweaverMonk struct {
 	*monk.Monk
+
+	SoothingMist     *core.Spell
+	SurgingMist      *core.Spell
+	EnvelopingMist   *core.Spell
+	RenewingMist     *core.Spell
+	Uplift           *core.Spell
+	ThunderFocusTea  *core.Spell
+	ManaTea          *core.Spell
+	GiftOfTheSerpent *core.Spell
+
+	ThunderFocusTeaAura *core.Aura
+	ManaTeaStackAura    *core.Aura
+	MuscleMemoryAura    *core.Aura
+	SerpentsZealAura    *core.Aura
+
+	outstandingChi    int32
+	renewingMistJumps []int32
 }
 
 func (mw *MistweaverMonk) GetMonk() *monk.Monk {
@@ -57,8 +74,13 @@ func (mw *MistweaverMonk) GetMonk() *monk.Monk {
 }
 
 func (mw *MistweaverMonk) Initialize() {
+	// Spells pick their resource costs based on the stance at registration time.
+	mw.Stance = monk.WiseSerpent
+
 	mw.Monk.Initialize()
 
+	mw.renewingMistJumps = make([]int32, len(mw.Env.AllUnits))
+
 	mw.RegisterSpecializationEffects()
 }
 
@@ -68,12 +90,75 @@ func (mw *MistweaverMonk) ApplyTalents() {
 }
 
 func (mw *MistweaverMonk) Reset(sim *core.Simulation) {
+	mw.outstandingChi = 0
+
+	// Clearing the build phase auras leaves the monk without a stance, so
+	// re-enter Stance of the Wise Serpent every iteration.
+	mw.Stance = monk.WiseSerpent
 	mw.Monk.Reset(sim)
 }
 
 func (mw *MistweaverMonk) RegisterSpecializationEffects() {
+	mw.registerSoothingMist()
+	mw.registerSurgingMist()
+	mw.registerEnvelopingMist()
+	mw.registerRenewingMist()
+	mw.registerUplift()
+	mw.registerThunderFocusTea()
+	mw.registerManaTea()
+
+	mw.registerSerpentsZeal()
+	mw.registerMuscleMemory()
 	mw.RegisterMastery()
 }
 
+// Mastery: Gift of the Serpent. Direct heals have a chance to summon a
+// Healing Sphere next to the most injured ally, which is assumed to be picked
+// up immediately.
 func (mw *MistweaverMonk) RegisterMastery() {
+	mw.GiftOfTheSerpent = mw.RegisterSpell(core.SpellConfig{
+		ActionID:       core.ActionID{SpellID: 124041},
+		SpellSchool:    core.SpellSchoolNature,
+		ProcMask:       core.ProcMaskSpellHealing,
+		Flags:          core.SpellFlagHelpful | core.SpellFlagNoOnCastComplete | core.SpellFlagPassiveSpell,
+		ClassSpellMask: monk.MonkSpellGiftOfTheSerpent,
+
+		DamageMultiplier: 1,
+		ThreatMultiplier: 1,
+		CritMultiplier:   mw.DefaultCritMultiplier(),
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			heal := mw.CalcScalingSpellDmg(9.122) + spell.MeleeAttackPower()*0.75
+			spell.CalcAndDealHealing(sim, target, heal, spell.OutcomeHealing)
+		},
+	})
+
+	mw.MakeProcTriggerAura(core.ProcTrigger{
+		Name:               "Mastery: Gift of the Serpent",
+		ActionID:           core.ActionID{SpellID: 117907},
+		Callback:           core.CallbackOnHealDealt,
+		ProcMask:           core.ProcMaskSpellHealing,
+		ClassSpellMask:     mistweaverHealingSpells,
+		TriggerImmediately: true,
+
+		ExtraCondition: func(sim *core.Simulation, _ *core.Spell, _ *core.SpellResult) bool {
+			return sim.Proc(mw.giftOfTheSerpentChance(), "Gift of the Serpent")
+		},
+		Handler: func(sim *core.Simulation, _ *core.Spell, _ *core.SpellResult) {
+			mw.GiftOfTheSerpent.Cast(sim, sim.Environment.Raid.GetLowestHealthAllyUnits(1)[0])
+		},
+	})
+}
+
+func (mw *MistweaverMonk) giftOfTheSerpentChance() float64 {
+	return (8 + mw.GetMasteryPoints()) * 2.5 / 100
+}
+
+// Helpful spells cast at an enemy, such as the default APL target, land on the
+// monk instead.
+func (mw *MistweaverMonk) healTarget(target *core.Unit) *core.Unit {
+	if target == nil || target.IsOpponent(&mw.Unit) {
+		return &mw.Unit
+	}
+	return target
 }
@@ -0,0 +1,59 @@
+package mistweaver
+
+import (
+	"testing"
+
+	"github.com/wowsims/mop/sim/common" // imported to get item effects included.
+	"github.com/wowsims/mop/sim/core"
+	"github.com/wowsims/mop/sim/core/proto"
+)
+
+func init() {
+	RegisterMistweaverMonk()
+	common.RegisterAllEffects()
+}
+
+func TestMistweaver(t *testing.T) {
+	core.RunTestSuite(t, t.Name(), core.FullCharacterTestSuiteGenerator([]core.CharacterSuiteConfig{
+		{
+			Class:      proto.Class_ClassMonk,
+			Race:       proto.Race_RaceTroll,
+			OtherRaces: []proto.Race{proto.Race_RaceOrc},
+
+			GearSet:     core.GetGearSet("../../../ui/monk/mistweaver/gear_sets", "default"),
+			Talents:     MistweaverTalents,
+			Glyphs:      &proto.Glyphs{},
+			Consumables: FullConsumesSpec,
+			SpecOptions: core.SpecOptionsCombo{Label: "Basic", SpecOptions: PlayerOptionsMistweaver},
+			Rotation:    core.GetAplRotation("../../../ui/monk/mistweaver/apls", "default"),
+
+			IsHealer: true,
+
+			ItemFilter: core.ItemFilter{
+				WeaponTypes: []proto.WeaponType{
+					proto.WeaponType_WeaponTypeMace,
+					proto.WeaponType_WeaponTypeOffHand,
+					proto.WeaponType_WeaponTypeStaff,
+				},
+				ArmorType: proto.ArmorType_ArmorTypeLeather,
+			},
+		},
+	}))
+}
+
+var MistweaverTalents = "213322"
+
+var PlayerOptionsMistweaver = &proto.Player_MistweaverMonk{
+	MistweaverMonk: &proto.MistweaverMonk{
+		Options: &proto.MistweaverMonk_Options{
+			ClassOptions: &proto.MonkOptions{},
+		},
+	},
+}
+
+var FullConsumesSpec = &proto.ConsumesSpec{
+	FlaskId:  76085, // Flask of the Warm Sun
+	FoodId:   74650, // Mogu Fish Stew
+	PotId:    76093, // Potion of the Jade Serpent
+	PrepotId: 76093, // Potion of the Jade Serpent
+}
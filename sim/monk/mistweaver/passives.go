@@ -0,0 +1,112 @@
+package mistweaver
+
+import (
+	"time"
+
+	"github.com/wowsims/mop/sim/core"
+	"github.com/wowsims/mop/sim/monk"
+)
+
+const serpentsZealPercentPerStack = 0.25
+
+/*
+Teachings of the Monastery: while in Stance of the Wise Serpent, Blackout Kick empowers you with Serpent's Zeal,
+causing your auto-attacks to heal the most injured nearby ally for 25% of the damage done. Stacks up to 2 times.
+*/
+func (mw *MistweaverMonk) registerSerpentsZeal() {
+	actionID := core.ActionID{SpellID: 127722}
+	damageDone := 0.0
+
+	serpentsZealHeal := mw.RegisterSpell(core.SpellConfig{
+		ActionID:    actionID,
+		SpellSchool: core.SpellSchoolNature,
+		ProcMask:    core.ProcMaskSpellHealing,
+		Flags:       core.SpellFlagHelpful | core.SpellFlagNoOnCastComplete | core.SpellFlagPassiveSpell,
+
+		DamageMultiplier: 1,
+		ThreatMultiplier: 1,
+		CritMultiplier:   mw.DefaultCritMultiplier(),
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			spell.CalcAndDealHealing(sim, target, damageDone, spell.OutcomeHealing)
+		},
+	})
+
+	mw.SerpentsZealAura = mw.RegisterAura(core.Aura{
+		Label:     "Serpent's Zeal" + mw.Label,
+		ActionID:  actionID,
+		Duration:  time.Second * 30,
+		MaxStacks: 2,
+
+		OnSpellHitDealt: func(aura *core.Aura, sim *core.Simulation, spell *core.Spell, result *core.SpellResult) {
+			if !result.Landed() || result.Damage == 0 || !spell.ProcMask.Matches(core.ProcMaskWhiteHit) {
+				return
+			}
+
+			damageDone = result.Damage * serpentsZealPercentPerStack * float64(aura.GetStacks())
+			serpentsZealHeal.Cast(sim, sim.Environment.Raid.GetLowestHealthAllyUnits(1)[0])
+		},
+	})
+
+	mw.MakeProcTriggerAura(core.ProcTrigger{
+		Name:           "Teachings of the Monastery",
+		Callback:       core.CallbackOnSpellHitDealt,
+		ClassSpellMask: monk.MonkSpellBlackoutKick,
+		Outcome:        core.OutcomeLanded,
+
+		ExtraCondition: func(_ *core.Simulation, _ *core.Spell, _ *core.SpellResult) bool {
+			return mw.StanceMatches(monk.WiseSerpent)
+		},
+		Handler: func(sim *core.Simulation, _ *core.Spell, _ *core.SpellResult) {
+			mw.SerpentsZealAura.Activate(sim)
+			mw.SerpentsZealAura.AddStack(sim)
+		},
+	})
+}
+
+/*
+Muscle Memory: successful Jab, Expel Harm, Spinning Crane Kick and Crackling Jade Lightning casts cause your next
+Tiger Palm or Blackout Kick to restore 4% of your maximum mana and deal 150% additional damage.
+*/
+func (mw *MistweaverMonk) registerMuscleMemory() {
+	actionID := core.ActionID{SpellID: 139597}
+	manaMetrics := mw.NewManaMetrics(actionID)
+	spenders := monk.MonkSpellTigerPalm | monk.MonkSpellBlackoutKick
+
+	damageMod := mw.AddDynamicMod(core.SpellModConfig{
+		Kind:       core.SpellMod_DamageDone_Pct,
+		ClassMask:  spenders,
+		FloatValue: 1.5,
+	})
+
+	mw.MuscleMemoryAura = mw.RegisterAura(core.Aura{
+		Label:    "Muscle Memory" + mw.Label,
+		ActionID: actionID,
+		Duration: time.Second * 15,
+
+		OnGain: func(_ *core.Aura, _ *core.Simulation) {
+			damageMod.Activate()
+		},
+		OnExpire: func(_ *core.Aura, _ *core.Simulation) {
+			damageMod.Deactivate()
+		},
+		OnSpellHitDealt: func(aura *core.Aura, sim *core.Simulation, spell *core.Spell, result *core.SpellResult) {
+			if !spell.Matches(spenders) || !result.Landed() {
+				return
+			}
+
+			mw.AddMana(sim, mw.MaxMana()*0.04, manaMetrics)
+			aura.Deactivate(sim)
+		},
+	})
+
+	mw.MakeProcTriggerAura(core.ProcTrigger{
+		Name:           "Muscle Memory Trigger",
+		Callback:       core.CallbackOnCastComplete,
+		ClassSpellMask: monk.MonkSpellJab | monk.MonkSpellExpelHarm | monk.MonkSpellSpinningCraneKick | monk.MonkSpellCracklingJadeLightning,
+
+		Handler: func(sim *core.Simulation, _ *core.Spell, _ *core.SpellResult) {
+			mw.MuscleMemoryAura.Activate(sim)
+		},
+	})
+}
@@ -0,0 +1,194 @@
+package mistweaver
+
+import (
+	"time"
+
+	"github.com/wowsims/mop/sim/core"
+	"github.com/wowsims/mop/sim/monk"
+)
+
+const renewingMistJumps = 2
+
+/*
+Tooltip:
+Surrounds the target with healing mists, restoring $o1 health over 18 sec, and generates 1 Chi.
+When Renewing Mist heals, it jumps to a new injured target without Renewing Mist. Renewing Mist can jump up to 2 times.
+*/
+func (mw *MistweaverMonk) registerRenewingMist() {
+	actionID := core.ActionID{SpellID: 115151}
+	chiMetrics := mw.NewChiMetrics(actionID)
+
+	mw.RenewingMist = mw.RegisterSpell(core.SpellConfig{
+		ActionID:       actionID,
+		SpellSchool:    core.SpellSchoolNature,
+		ProcMask:       core.ProcMaskSpellHealing,
+		Flags:          core.SpellFlagHelpful | core.SpellFlagAPL,
+		ClassSpellMask: monk.MonkSpellRenewingMist,
+		MaxRange:       40,
+
+		ManaCost: core.ManaCostOptions{
+			BaseCostPercent: 5.85,
+		},
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.GCDDefault,
+			},
+			CD: core.Cooldown{
+				Timer:    mw.NewTimer(),
+				Duration: time.Second * 8,
+			},
+		},
+
+		DamageMultiplier: 1,
+		ThreatMultiplier: 1,
+		CritMultiplier:   mw.DefaultCritMultiplier(),
+
+		Hot: core.DotConfig{
+			Aura: core.Aura{
+				Label: "Renewing Mist",
+			},
+			NumberOfTicks:    9,
+			TickLength:       time.Second * 2,
+			BonusCoefficient: 0.0688,
+
+			OnSnapshot: func(_ *core.Simulation, target *core.Unit, dot *core.Dot, _ bool) {
+				dot.SnapshotHeal(target, mw.CalcScalingSpellDmg(0.765))
+			},
+			OnTick: func(sim *core.Simulation, target *core.Unit, dot *core.Dot) {
+				dot.CalcAndDealPeriodicSnapshotHealing(sim, target, dot.OutcomeSnapshotCrit)
+				mw.spreadRenewingMist(sim, target)
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			target = mw.healTarget(target)
+			mw.renewingMistJumps[target.UnitIndex] = renewingMistJumps
+			spell.Hot(target).Apply(sim)
+			mw.AddChi(sim, spell, 1, chiMetrics)
+		},
+	})
+}
+
+// Moves the remaining jumps of the Renewing Mist on source to the most injured
+// ally which doesn't have Renewing Mist yet.
+func (mw *MistweaverMonk) spreadRenewingMist(sim *core.Simulation, source *core.Unit) {
+	jumps := mw.renewingMistJumps[source.UnitIndex]
+	if jumps <= 0 {
+		return
+	}
+
+	for _, unit := range sim.Environment.Raid.GetLowestHealthAllyUnits(len(sim.Environment.AllUnits)) {
+		if unit == source || mw.RenewingMist.Hot(unit).IsActive() {
+			continue
+		}
+
+		mw.renewingMistJumps[source.UnitIndex] = 0
+		mw.renewingMistJumps[unit.UnitIndex] = jumps - 1
+		mw.RenewingMist.Hot(unit).Apply(sim)
+		return
+	}
+}
+
+/*
+Tooltip:
+You channel the power of the Thunder King, causing your next Uplift to refresh the duration of your Renewing Mists on all targets,
+or your next Surging Mist to heal for twice the normal amount.
+*/
+func (mw *MistweaverMonk) registerThunderFocusTea() {
+	actionID := core.ActionID{SpellID: 116680}
+	chiMetrics := mw.NewChiMetrics(actionID)
+	chiCost := int32(1)
+
+	mw.ThunderFocusTeaAura = mw.RegisterAura(core.Aura{
+		Label:    "Thunder Focus Tea" + mw.Label,
+		ActionID: actionID,
+		Duration: time.Second * 30,
+	})
+
+	mw.ThunderFocusTea = mw.RegisterSpell(core.SpellConfig{
+		ActionID:       actionID,
+		Flags:          core.SpellFlagNoOnCastComplete | monk.SpellFlagSpender | core.SpellFlagAPL,
+		ClassSpellMask: monk.MonkSpellThunderFocusTea,
+
+		Cast: core.CastConfig{
+			CD: core.Cooldown{
+				Timer:    mw.NewTimer(),
+				Duration: time.Second * 45,
+			},
+		},
+
+		ExtraCastCondition: func(sim *core.Simulation, target *core.Unit) bool {
+			return mw.GetChi() >= chiCost
+		},
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			mw.SpendChi(sim, chiCost, chiMetrics)
+			mw.ThunderFocusTeaAura.Activate(sim)
+		},
+
+		RelatedSelfBuff: mw.ThunderFocusTeaAura,
+	})
+}
+
+/*
+Tooltip:
+You Uplift your allies, restoring ${$m1*$<mult>} to ${$M1*$<mult>} health to all targets affected by your Renewing Mist.
+*/
+func (mw *MistweaverMonk) registerUplift() {
+	actionID := core.ActionID{SpellID: 116670}
+	chiMetrics := mw.NewChiMetrics(actionID)
+	chiCost := int32(2)
+
+	mw.Uplift = mw.RegisterSpell(core.SpellConfig{
+		ActionID:       actionID,
+		SpellSchool:    core.SpellSchoolNature,
+		ProcMask:       core.ProcMaskSpellHealing,
+		Flags:          core.SpellFlagHelpful | monk.SpellFlagSpender | core.SpellFlagAPL,
+		ClassSpellMask: monk.MonkSpellUplift,
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.GCDDefault,
+			},
+		},
+
+		ExtraCastCondition: func(sim *core.Simulation, target *core.Unit) bool {
+			return mw.GetChi() >= chiCost && mw.numRenewingMists() > 0
+		},
+
+		DamageMultiplier: 1,
+		ThreatMultiplier: 1,
+		CritMultiplier:   mw.DefaultCritMultiplier(),
+		BonusCoefficient: 0.684,
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			mw.SpendChi(sim, chiCost, chiMetrics)
+
+			refreshRenewingMist := mw.ThunderFocusTeaAura.IsActive()
+			mw.ThunderFocusTeaAura.Deactivate(sim)
+
+			for _, unit := range sim.Environment.Raid.AllUnits {
+				hot := mw.RenewingMist.Hot(unit)
+				if !hot.IsActive() {
+					continue
+				}
+
+				baseHealing := mw.CalcAndRollDamageRange(sim, 5.95, 0.17)
+				spell.CalcAndDealHealing(sim, unit, baseHealing, spell.OutcomeHealingCrit)
+				if refreshRenewingMist {
+					hot.Apply(sim)
+				}
+			}
+		},
+	})
+}
+
+func (mw *MistweaverMonk) numRenewingMists() int {
+	count := 0
+	for _, unit := range mw.Env.Raid.AllUnits {
+		if mw.RenewingMist.Hot(unit).IsActive() {
+			count++
+		}
+	}
+	return count
+}
@@ -165,6 +165,17 @@ func (monk *Monk) AddRaidBuffs(raidBuffs *proto.RaidBuffs) {
 
 func (monk *Monk) AddPartyBuffs(_ *proto.PartyBuffs) {}
 
+func (monk *Monk) AddDebuffs(debuffs *proto.Debuffs) {
+	switch monk.Spec {
+	case proto.Spec_SpecBrewmasterMonk:
+		// Keg Smash
+		debuffs.WeakenedBlows = true
+	case proto.Spec_SpecWindwalkerMonk:
+		// Rising Sun Kick
+		debuffs.MortalWounds = true
+	}
+}
+
 func (monk *Monk) HasMajorGlyph(glyph proto.MonkMajorGlyph) bool {
 	return monk.HasGlyph(int32(glyph))
 }
@@ -360,6 +371,16 @@ const (
 	MonkSpellPurifyingBrew
 	MonkSpellGiftOfTheOx
 
+	// Mistweaver
+	MonkSpellSoothingMist
+	MonkSpellSurgingMist
+	MonkSpellEnvelopingMist
+	MonkSpellRenewingMist
+	MonkSpellUplift
+	MonkSpellThunderFocusTea
+	MonkSpellManaTea
+	MonkSpellGiftOfTheSerpent
+
 	MonkSpellLast
 	MonkSpellsAll = MonkSpellLast<<1 - 1
 )
@@ -132,8 +132,7 @@ func (monk *Monk) registerStanceOfTheWiseSerpent(stanceCD *core.Timer) {
 			}
 
 			dmgDone = result.Damage
-			// Should be a smart heal
-			eminenceHeal.Cast(sim, &monk.Unit)
+			eminenceHeal.Cast(sim, sim.Environment.Raid.GetLowestHealthAllyUnits(1)[0])
 		},
 	})
 
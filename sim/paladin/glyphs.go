@@ -240,7 +240,6 @@ func (paladin *Paladin) registerGlyphOfDivinePlea() {
 		return
 	}
 
-	// TODO: Handle the mana return part in holy/divine_plea.go
 	core.MakePermanent(paladin.RegisterAura(core.Aura{
 		Label:    "Glyph of Divine Plea" + paladin.Label,
 		ActionID: core.ActionID{SpellID: 63223},
@@ -522,7 +521,6 @@ func (paladin *Paladin) registerGlyphOfLightOfDawn() {
 		return
 	}
 
-	// TODO: Handle the target count part in holy/light_of_dawn.go
 	core.MakePermanent(paladin.RegisterAura(core.Aura{
 		Label:    "Glyph of Light of Dawn" + paladin.Label,
 		ActionID: core.ActionID{SpellID: 54940},
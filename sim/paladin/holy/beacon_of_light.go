@@ -0,0 +1,101 @@
+package holy
+
+import (
+	"github.com/wowsims/mop/sim/core"
+	"github.com/wowsims/mop/sim/core/proto"
+	"github.com/wowsims/mop/sim/paladin"
+)
+
+/*
+The target becomes a Beacon of Light to all members of your party or raid within a 60 yard radius.
+Any heals you cast on other party or raid members will also heal the Beacon for 50% of the amount healed.
+Holy Light will heal the Beacon for 100% of the amount healed, and Light of Dawn and Holy Radiance for 15%.
+Only one target can be the Beacon of Light at a time.
+
+-- Glyph of Beacon of Light --
+Your Beacon of Light no longer triggers the global cooldown.
+-- /Glyph of Beacon of Light --
+*/
+func (holy *HolyPaladin) registerBeaconOfLight() {
+	actionID := core.ActionID{SpellID: 53563}
+	hasGlyph := holy.HasMajorGlyph(proto.PaladinMajorGlyph_GlyphOfBeaconOfLight)
+
+	beaconAuras := holy.NewAllyAuraArray(func(unit *core.Unit) *core.Aura {
+		return unit.RegisterAura(core.Aura{
+			Label:    "Beacon of Light" + unit.Label,
+			ActionID: actionID,
+			Duration: core.NeverExpires,
+		})
+	})
+
+	holy.BeaconOfLight = holy.RegisterSpell(core.SpellConfig{
+		ActionID:    actionID,
+		SpellSchool: core.SpellSchoolHoly,
+		ProcMask:    core.ProcMaskEmpty,
+		Flags:       core.SpellFlagAPL | core.SpellFlagHelpful,
+
+		MaxRange: 60,
+
+		ManaCost: core.ManaCostOptions{
+			BaseCostPercent: 6,
+		},
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD:      core.TernaryDuration(hasGlyph, 0, core.GCDDefault),
+				NonEmpty: hasGlyph,
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, _ *core.Spell) {
+			target = holy.healTarget(target)
+
+			if prev := holy.beaconTarget; prev != nil && prev != target {
+				beaconAuras.Get(prev).Deactivate(sim)
+			}
+			holy.beaconTarget = target
+
+			beaconAuras.Get(target).Activate(sim)
+		},
+	})
+
+	holy.BeaconOfLightTransfer = holy.RegisterSpell(core.SpellConfig{
+		ActionID:       core.ActionID{SpellID: 53652},
+		SpellSchool:    core.SpellSchoolHoly,
+		ProcMask:       core.ProcMaskSpellHealing,
+		Flags:          core.SpellFlagHelpful | core.SpellFlagNoOnCastComplete | core.SpellFlagPassiveSpell | core.SpellFlagIgnoreModifiers,
+		ClassSpellMask: paladin.SpellMaskBeaconOfLight,
+
+		DamageMultiplier: 1,
+		ThreatMultiplier: 1,
+	})
+
+	holy.MakeProcTriggerAura(core.ProcTrigger{
+		Name:               "Beacon of Light Trigger" + holy.Label,
+		Callback:           core.CallbackOnHealDealt,
+		ProcMask:           core.ProcMaskSpellHealing,
+		TriggerImmediately: true,
+		ExtraCondition: func(_ *core.Simulation, spell *core.Spell, result *core.SpellResult) bool {
+			return holy.beaconTarget != nil && result.Target != holy.beaconTarget && spell != holy.BeaconOfLightTransfer
+		},
+
+		Handler: func(sim *core.Simulation, spell *core.Spell, result *core.SpellResult) {
+			// The transfer ignores healing modifiers, so any bonus to it, e.g. the
+			// T15 4pc, has to be applied through the spell's own multiplier.
+			transfer := holy.BeaconOfLightTransfer
+			amount := result.Damage * beaconTransferPercent(spell) * transfer.DamageMultiplier * transfer.DamageMultiplierAdditive
+			transfer.CalcAndDealHealing(sim, holy.beaconTarget, amount, transfer.OutcomeHealing)
+		},
+	})
+}
+
+func beaconTransferPercent(spell *core.Spell) float64 {
+	switch {
+	case spell.Matches(paladin.SpellMaskHolyLight):
+		return 1
+	case spell.Matches(paladin.SpellMaskLightOfDawn | paladin.SpellMaskHolyRadiance):
+		return 0.15
+	default:
+		return 0.5
+	}
+}
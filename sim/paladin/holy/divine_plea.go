@@ -0,0 +1,64 @@
+package holy
+
+import (
+	"time"
+
+	"github.com/wowsims/mop/sim/core"
+	"github.com/wowsims/mop/sim/core/proto"
+	"github.com/wowsims/mop/sim/paladin"
+)
+
+/*
+You gain 12% of your total mana over 9 sec, but the amount healed by your healing spells is reduced by 50%.
+
+-- Glyph of Divine Plea --
+Divine Plea returns 50% less mana but has a 50% shorter cooldown.
+-- /Glyph of Divine Plea --
+*/
+func (holy *HolyPaladin) registerDivinePlea() {
+	actionID := core.ActionID{SpellID: 54428}
+	manaMetrics := holy.NewManaMetrics(actionID)
+
+	manaPercent := core.TernaryFloat64(holy.HasMajorGlyph(proto.PaladinMajorGlyph_GlyphOfDivinePlea), 0.06, 0.12)
+	numTicks := 3
+
+	holy.DivinePleaAura = holy.RegisterAura(core.Aura{
+		Label:    "Divine Plea" + holy.Label,
+		ActionID: actionID,
+		Duration: time.Second * 9,
+
+		OnGain: func(_ *core.Aura, sim *core.Simulation) {
+			core.StartPeriodicAction(sim, core.PeriodicActionOptions{
+				Period:   time.Second * 3,
+				NumTicks: numTicks,
+				OnAction: func(sim *core.Simulation) {
+					holy.AddMana(sim, holy.MaxMana()*manaPercent/float64(numTicks), manaMetrics)
+				},
+			})
+		},
+	}).AttachMultiplicativePseudoStatBuff(&holy.PseudoStats.HealingDealtMultiplier, 0.5)
+
+	holy.DivinePlea = holy.RegisterSpell(core.SpellConfig{
+		ActionID:       actionID,
+		SpellSchool:    core.SpellSchoolHoly,
+		ProcMask:       core.ProcMaskEmpty,
+		Flags:          core.SpellFlagAPL | core.SpellFlagHelpful,
+		ClassSpellMask: paladin.SpellMaskDivinePlea,
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.GCDDefault,
+			},
+			CD: core.Cooldown{
+				Timer:    holy.NewTimer(),
+				Duration: time.Minute * 2,
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			spell.RelatedSelfBuff.Activate(sim)
+		},
+
+		RelatedSelfBuff: holy.DivinePleaAura,
+	})
+}
@@ -36,6 +36,20 @@ func NewHolyPaladin(character *core.Character, options *proto.Player) *HolyPalad
 
 type HolyPaladin struct {
 	*paladin.Paladin
+
+	BeaconOfLight         *core.Spell
+	BeaconOfLightTransfer *core.Spell
+	DivineLight           *core.Spell
+	DivinePlea            *core.Spell
+	HolyLight             *core.Spell
+	HolyShock             *core.Spell
+	IlluminatedHealing    *core.Spell
+	LightOfDawn           *core.Spell
+
+	DivinePleaAura *core.Aura
+
+	// Beacon of Light can only be active on one target at a time.
+	beaconTarget *core.Unit
 }
 
 func (holy *HolyPaladin) GetPaladin() *paladin.Paladin {
@@ -45,14 +59,36 @@ func (holy *HolyPaladin) GetPaladin() *paladin.Paladin {
 func (holy *HolyPaladin) ApplyTalents() {
 	holy.Paladin.ApplyTalents()
 	holy.ApplyArmorSpecializationEffect(stats.Intellect, proto.ArmorType_ArmorTypePlate, 86525)
+
+	holy.registerHolyInsight()
 }
 
 func (holy *HolyPaladin) Initialize() {
 	holy.Paladin.Initialize()
 
+	holy.registerMastery()
+
+	holy.registerBeaconOfLight()
+	holy.registerDivinePlea()
+	holy.registerHolyLight()
+	holy.registerDivineLight()
+	holy.registerHolyShock()
+	holy.registerInfusionOfLight()
+	holy.registerLightOfDawn()
+
 	holy.registerHotfixPassive()
 }
 
 func (holy *HolyPaladin) Reset(sim *core.Simulation) {
+	holy.beaconTarget = nil
 	holy.Paladin.Reset(sim)
 }
+
+// Helpful spells cast at an enemy, such as the default APL target, land on the
+// paladin instead.
+func (holy *HolyPaladin) healTarget(target *core.Unit) *core.Unit {
+	if target == nil || target.IsOpponent(&holy.Unit) {
+		return &holy.Unit
+	}
+	return target
+}
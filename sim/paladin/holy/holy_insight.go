@@ -0,0 +1,26 @@
+package holy
+
+import (
+	"github.com/wowsims/mop/sim/core"
+	"github.com/wowsims/mop/sim/paladin"
+)
+
+/*
+Increases your healing done by 25%.
+Increases the healing done by your Word of Glory, Eternal Flame and Light of Dawn by 50%.
+Allows 50% of your mana regeneration from Spirit to continue while in combat.
+*/
+func (holy *HolyPaladin) registerHolyInsight() {
+	holy.PseudoStats.SpiritRegenRateCombat = 0.5
+
+	core.MakePermanent(holy.RegisterAura(core.Aura{
+		Label:    "Holy Insight" + holy.Label,
+		ActionID: core.ActionID{SpellID: 112859},
+	})).AttachMultiplicativePseudoStatBuff(
+		&holy.PseudoStats.HealingDealtMultiplier, 1.25,
+	).AttachSpellMod(core.SpellModConfig{
+		Kind:       core.SpellMod_DamageDone_Pct,
+		ClassMask:  paladin.SpellMaskWordOfGlory | paladin.SpellMaskLightOfDawn,
+		FloatValue: 0.5,
+	})
+}
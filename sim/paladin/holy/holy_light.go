@@ -0,0 +1,78 @@
+package holy
+
+import (
+	"time"
+
+	"github.com/wowsims/mop/sim/core"
+	"github.com/wowsims/mop/sim/paladin"
+)
+
+// Heals a friendly target for (<7400-8230> + 0.785 * <SP>).
+func (holy *HolyPaladin) registerHolyLight() {
+	holy.HolyLight = holy.RegisterSpell(core.SpellConfig{
+		ActionID:       core.ActionID{SpellID: 635},
+		SpellSchool:    core.SpellSchoolHoly,
+		ProcMask:       core.ProcMaskSpellHealing,
+		Flags:          core.SpellFlagAPL | core.SpellFlagHelpful,
+		ClassSpellMask: paladin.SpellMaskHolyLight,
+
+		MaxRange: 40,
+
+		ManaCost: core.ManaCostOptions{
+			BaseCostPercent: 12.6,
+		},
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD:      core.GCDDefault,
+				CastTime: time.Millisecond * 2500,
+			},
+		},
+
+		DamageMultiplier: 1,
+		CritMultiplier:   holy.DefaultCritMultiplier(),
+		ThreatMultiplier: 1,
+
+		BonusCoefficient: 0.78500002623,
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			baseHealing := holy.CalcAndRollDamageRange(sim, 7.30000019073, 0.10700000077)
+			spell.CalcAndDealHealing(sim, holy.healTarget(target), baseHealing, spell.OutcomeHealingCrit)
+		},
+	})
+}
+
+// A large heal which heals a friendly target for (<13920-15490> + 1.49 * <SP>).
+func (holy *HolyPaladin) registerDivineLight() {
+	holy.DivineLight = holy.RegisterSpell(core.SpellConfig{
+		ActionID:       core.ActionID{SpellID: 82326},
+		SpellSchool:    core.SpellSchoolHoly,
+		ProcMask:       core.ProcMaskSpellHealing,
+		Flags:          core.SpellFlagAPL | core.SpellFlagHelpful,
+		ClassSpellMask: paladin.SpellMaskDivineLight,
+
+		MaxRange: 40,
+
+		ManaCost: core.ManaCostOptions{
+			BaseCostPercent: 36,
+		},
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD:      core.GCDDefault,
+				CastTime: time.Millisecond * 2500,
+			},
+		},
+
+		DamageMultiplier: 1,
+		CritMultiplier:   holy.DefaultCritMultiplier(),
+		ThreatMultiplier: 1,
+
+		BonusCoefficient: 1.49000000954,
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			baseHealing := holy.CalcAndRollDamageRange(sim, 13.73999977112, 0.10700000077)
+			spell.CalcAndDealHealing(sim, holy.healTarget(target), baseHealing, spell.OutcomeHealingCrit)
+		},
+	})
+}
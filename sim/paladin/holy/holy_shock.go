@@ -0,0 +1,99 @@
+package holy
+
+import (
+	"time"
+
+	"github.com/wowsims/mop/sim/core"
+	"github.com/wowsims/mop/sim/paladin"
+)
+
+/*
+Blasts the target with Holy energy, causing (1.36 * <SP>) Holy damage to an enemy, or (0.833 * <SP>) healing to an ally,
+and grants a charge of Holy Power.
+
+Holy Shock has an additional 25% chance to be a critical strike.
+*/
+func (holy *HolyPaladin) registerHolyShock() {
+	actionID := core.ActionID{SpellID: 20473}
+	holy.CanTriggerHolyAvengerHpGain(actionID)
+
+	holyShockHeal := holy.RegisterSpell(core.SpellConfig{
+		ActionID:       core.ActionID{SpellID: 25914},
+		SpellSchool:    core.SpellSchoolHoly,
+		ProcMask:       core.ProcMaskSpellHealing,
+		Flags:          core.SpellFlagHelpful | core.SpellFlagPassiveSpell,
+		ClassSpellMask: paladin.SpellMaskHolyShockHeal,
+
+		BonusCritPercent: 25,
+
+		DamageMultiplier: 1,
+		CritMultiplier:   holy.DefaultCritMultiplier(),
+		ThreatMultiplier: 1,
+
+		BonusCoefficient: 0.83300000429,
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			baseHealing := holy.CalcAndRollDamageRange(sim, 8.17399978638, 0.19499999285)
+			spell.CalcAndDealHealing(sim, target, baseHealing, spell.OutcomeHealingCrit)
+		},
+	})
+
+	holyShockDamage := holy.RegisterSpell(core.SpellConfig{
+		ActionID:       core.ActionID{SpellID: 25912},
+		SpellSchool:    core.SpellSchoolHoly,
+		ProcMask:       core.ProcMaskSpellDamage,
+		Flags:          core.SpellFlagPassiveSpell,
+		ClassSpellMask: paladin.SpellMaskHolyShockDamage,
+
+		MaxRange: 40,
+
+		BonusCritPercent: 25,
+
+		DamageMultiplier: 1,
+		CritMultiplier:   holy.DefaultCritMultiplier(),
+		ThreatMultiplier: 1,
+
+		BonusCoefficient: 1.36000001431,
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			baseDamage := holy.CalcAndRollDamageRange(sim, 1.26999998093, 0.09700000286)
+			spell.CalcAndDealDamage(sim, target, baseDamage, spell.OutcomeMagicHitAndCrit)
+		},
+	})
+
+	holy.HolyShock = holy.RegisterSpell(core.SpellConfig{
+		ActionID:       actionID,
+		SpellSchool:    core.SpellSchoolHoly,
+		ProcMask:       core.ProcMaskEmpty,
+		Flags:          core.SpellFlagAPL,
+		ClassSpellMask: paladin.SpellMaskHolyShock,
+
+		MaxRange: 40,
+
+		ManaCost: core.ManaCostOptions{
+			BaseCostPercent: 8,
+		},
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.GCDDefault,
+			},
+			CD: core.Cooldown{
+				Timer:    holy.NewTimer(),
+				Duration: time.Second * 6,
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			// Unlike the other heals, Holy Shock strikes an enemy target
+			// instead of falling back to the paladin.
+			if target.IsOpponent(&holy.Unit) {
+				holyShockDamage.Cast(sim, target)
+			} else {
+				holyShockHeal.Cast(sim, target)
+			}
+
+			holy.HolyPower.Gain(sim, 1, actionID)
+		},
+	})
+}
@@ -0,0 +1,76 @@
+package holy
+
+import (
+	"testing"
+
+	"github.com/wowsims/mop/sim/common" // imported to get item effects included.
+	"github.com/wowsims/mop/sim/core"
+	"github.com/wowsims/mop/sim/core/proto"
+)
+
+func init() {
+	RegisterHolyPaladin()
+	common.RegisterAllEffects()
+}
+
+func TestHoly(t *testing.T) {
+	core.RunTestSuite(t, t.Name(), core.FullCharacterTestSuiteGenerator([]core.CharacterSuiteConfig{
+		{
+			Class:      proto.Class_ClassPaladin,
+			Race:       proto.Race_RaceBloodElf,
+			OtherRaces: []proto.Race{proto.Race_RaceHuman},
+
+			GearSet: core.GetGearSet("../../../ui/paladin/retribution/gear_sets", "p3"),
+			Talents: EternalFlameTalents,
+			Glyphs:  StandardGlyphs,
+			OtherTalentSets: []core.TalentsCombo{
+				{
+					Label:   "WordOfGlory",
+					Talents: WordOfGloryTalents,
+					Glyphs:  &proto.Glyphs{},
+				},
+			},
+			Consumables: FullConsumesSpec,
+			SpecOptions: core.SpecOptionsCombo{Label: "Basic", SpecOptions: BasicOptions},
+			Rotation:    core.GetAplRotation("../../../ui/paladin/holy/apls", "default"),
+
+			IsHealer:        true,
+			InFrontOfTarget: true,
+
+			ItemFilter: core.ItemFilter{
+				WeaponTypes: []proto.WeaponType{
+					proto.WeaponType_WeaponTypeSword,
+					proto.WeaponType_WeaponTypePolearm,
+					proto.WeaponType_WeaponTypeMace,
+					proto.WeaponType_WeaponTypeShield,
+				},
+				ArmorType:         proto.ArmorType_ArmorTypePlate,
+				RangedWeaponTypes: []proto.RangedWeaponType{},
+			},
+		},
+	}))
+}
+
+var EternalFlameTalents = "112211"
+var WordOfGloryTalents = "111211"
+
+var StandardGlyphs = &proto.Glyphs{
+	Major1: int32(proto.PaladinMajorGlyph_GlyphOfDivinePlea),
+}
+
+var BasicOptions = &proto.Player_HolyPaladin{
+	HolyPaladin: &proto.HolyPaladin{
+		Options: &proto.HolyPaladin_Options{
+			ClassOptions: &proto.PaladinOptions{
+				Seal: proto.PaladinSeal_Insight,
+			},
+		},
+	},
+}
+
+var FullConsumesSpec = &proto.ConsumesSpec{
+	FlaskId:  76085, // Flask of the Warm Sun
+	FoodId:   74650, // Mogu Fish Stew
+	PotId:    76093, // Potion of the Jade Serpent
+	PrepotId: 76093, // Potion of the Jade Serpent
+}
@@ -0,0 +1,46 @@
+package holy
+
+import (
+	"time"
+
+	"github.com/wowsims/mop/sim/core"
+	"github.com/wowsims/mop/sim/paladin"
+)
+
+/*
+Your Holy Shock critical effects reduce the cast time of your next Holy Light, Divine Light or Holy Radiance by 1.5 sec.
+*/
+func (holy *HolyPaladin) registerInfusionOfLight() {
+	classMask := paladin.SpellMaskHolyLight | paladin.SpellMaskDivineLight | paladin.SpellMaskHolyRadiance
+
+	holy.InfusionOfLightAura = holy.RegisterAura(core.Aura{
+		Label:    "Infusion of Light" + holy.Label,
+		ActionID: core.ActionID{SpellID: 54149},
+		Duration: time.Second * 15,
+	}).AttachSpellMod(core.SpellModConfig{
+		Kind:      core.SpellMod_CastTime_Flat,
+		ClassMask: classMask,
+		TimeValue: time.Millisecond * -1500,
+	}).AttachProcTrigger(core.ProcTrigger{
+		Callback:           core.CallbackOnCastComplete,
+		ClassSpellMask:     classMask,
+		TriggerImmediately: true,
+
+		Handler: func(sim *core.Simulation, _ *core.Spell, _ *core.SpellResult) {
+			holy.InfusionOfLightAura.Deactivate(sim)
+		},
+	})
+
+	holy.MakeProcTriggerAura(core.ProcTrigger{
+		Name:               "Infusion of Light Trigger" + holy.Label,
+		ActionID:           core.ActionID{SpellID: 53576},
+		Callback:           core.CallbackOnSpellHitDealt | core.CallbackOnHealDealt,
+		Outcome:            core.OutcomeCrit,
+		ClassSpellMask:     paladin.SpellMaskHolyShock,
+		TriggerImmediately: true,
+
+		Handler: func(sim *core.Simulation, _ *core.Spell, _ *core.SpellResult) {
+			holy.InfusionOfLightAura.Activate(sim)
+		},
+	})
+}
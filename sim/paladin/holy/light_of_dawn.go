@@ -0,0 +1,63 @@
+package holy
+
+import (
+	"github.com/wowsims/mop/sim/core"
+	"github.com/wowsims/mop/sim/core/proto"
+	"github.com/wowsims/mop/sim/paladin"
+)
+
+/*
+Consumes up to 3 Holy Power to unleash a wave of healing energy,
+healing up to 6 injured allies within 30 yards for (<1039-1157> + 0.152 * <SP>) per charge of Holy Power.
+
+-- Glyph of Light of Dawn --
+Light of Dawn affects 2 fewer targets
+-- /Glyph of Light of Dawn --
+*/
+func (holy *HolyPaladin) registerLightOfDawn() {
+	actionID := core.ActionID{SpellID: 85222}
+	numTargets := core.TernaryInt(holy.HasMajorGlyph(proto.PaladinMajorGlyph_GlyphOfLightOfDawn), 4, 6)
+
+	holy.LightOfDawn = holy.RegisterSpell(core.SpellConfig{
+		ActionID:       actionID,
+		SpellSchool:    core.SpellSchoolHoly,
+		ProcMask:       core.ProcMaskSpellHealing,
+		Flags:          core.SpellFlagAPL | core.SpellFlagHelpful | core.SpellFlagAoE,
+		ClassSpellMask: paladin.SpellMaskLightOfDawn,
+		MetricSplits:   4,
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.GCDDefault,
+			},
+			ModifyCast: func(sim *core.Simulation, spell *core.Spell, cast *core.Cast) {
+				holy.DynamicHolyPowerSpent = holy.SpendableHolyPower()
+				spell.SetMetricsSplit(int32(holy.DynamicHolyPowerSpent))
+			},
+		},
+
+		ExtraCastCondition: func(sim *core.Simulation, target *core.Unit) bool {
+			return holy.HolyPower.CanSpend(1)
+		},
+
+		DamageMultiplier: 1,
+		CritMultiplier:   holy.DefaultCritMultiplier(),
+		ThreatMultiplier: 1,
+
+		BonusCoefficient: 0.15199999511,
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			damageMultiplier := spell.DamageMultiplier
+			spell.DamageMultiplier *= holy.DynamicHolyPowerSpent
+
+			for _, unit := range sim.Environment.Raid.GetLowestHealthAllyUnits(numTargets) {
+				baseHealing := holy.CalcAndRollDamageRange(sim, 1.02999997139, 0.10700000077)
+				spell.CalcAndDealHealing(sim, unit, baseHealing, spell.OutcomeHealingCrit)
+			}
+
+			spell.DamageMultiplier = damageMultiplier
+
+			holy.HolyPower.SpendUpTo(sim, holy.DynamicHolyPowerSpent, actionID)
+		},
+	})
+}
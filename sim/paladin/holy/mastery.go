@@ -0,0 +1,51 @@
+package holy
+
+import (
+	"math"
+	"time"
+
+	"github.com/wowsims/mop/sim/core"
+)
+
+/*
+Your direct healing spells also place an absorb shield on your target for ((8 + <Mastery Rating> / 600) * 1.5)% of the amount healed, lasting 15 sec.
+The total absorb of the shield can not exceed 1/3 of your maximum health.
+*/
+func (holy *HolyPaladin) registerMastery() {
+	holy.IlluminatedHealing = holy.RegisterSpell(core.SpellConfig{
+		ActionID:    core.ActionID{SpellID: 86273},
+		SpellSchool: core.SpellSchoolHoly,
+		ProcMask:    core.ProcMaskSpellHealing,
+		Flags:       core.SpellFlagHelpful | core.SpellFlagNoOnCastComplete | core.SpellFlagPassiveSpell,
+
+		DamageMultiplier: 1,
+		ThreatMultiplier: 1,
+
+		Shield: core.ShieldConfig{
+			Aura: core.Aura{
+				Label:     "Illuminated Healing",
+				Duration:  time.Second * 15,
+				MaxStacks: math.MaxInt32,
+			},
+		},
+	})
+
+	holy.MakeProcTriggerAura(core.ProcTrigger{
+		Name:               "Mastery: Illuminated Healing" + holy.Label,
+		ActionID:           core.ActionID{SpellID: 76669},
+		Callback:           core.CallbackOnHealDealt,
+		ProcMask:           core.ProcMaskSpellHealing,
+		TriggerImmediately: true,
+		ExtraCondition: func(_ *core.Simulation, spell *core.Spell, result *core.SpellResult) bool {
+			return spell != holy.BeaconOfLightTransfer && result.Damage > 0
+		},
+
+		Handler: func(sim *core.Simulation, _ *core.Spell, result *core.SpellResult) {
+			holy.IlluminatedHealing.Shield(result.Target).Stack(sim, result.Damage*holy.getMasteryPercent(), holy.MaxHealth()/3)
+		},
+	})
+}
+
+func (holy *HolyPaladin) getMasteryPercent() float64 {
+	return (8.0 + holy.GetMasteryPoints()) * 1.5 / 100.0
+}
@@ -1,11 +0,0 @@
-package holy
-
-import (
-	"time"
-
-	"github.com/wowsims/mop/sim/core"
-)
-
-func (holy *HolyPaladin) OnGCDReady(sim *core.Simulation) {
-	holy.WaitUntil(sim, sim.CurrentTime+time.Second*5)
-}
@@ -82,6 +82,15 @@ func (paladin *Paladin) GetPaladin() *Paladin {
 func (paladin *Paladin) AddRaidBuffs(_ *proto.RaidBuffs) {
 }
 
+func (paladin *Paladin) AddRaidMemberBuffs(raidBuffs *proto.RaidBuffs) {
+	// Use Might when another class already provides the stats buff.
+	if raidBuffs.BlessingOfKings || raidBuffs.MarkOfTheWild || raidBuffs.LegacyOfTheEmperor || raidBuffs.EmbraceOfTheShaleSpider {
+		raidBuffs.BlessingOfMight = true
+	} else {
+		raidBuffs.BlessingOfKings = true
+	}
+}
+
 func (paladin *Paladin) AddPartyBuffs(_ *proto.PartyBuffs) {
 }
 
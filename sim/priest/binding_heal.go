@@ -0,0 +1,48 @@
+package priest
+
+import (
+	"time"
+
+	"github.com/wowsims/mop/sim/core"
+)
+
+const bindingHealScale = 8.472
+const bindingHealVariance = 0.25
+const bindingHealCoeff = 0.899
+
+// Heals the target and the priest for the same amount.
+func (priest *Priest) registerBindingHealSpell() {
+	priest.BindingHeal = priest.RegisterSpell(core.SpellConfig{
+		ActionID:       core.ActionID{SpellID: 32546},
+		SpellSchool:    core.SpellSchoolHoly,
+		ProcMask:       core.ProcMaskSpellHealing,
+		Flags:          core.SpellFlagHelpful | core.SpellFlagAPL,
+		ClassSpellMask: PriestSpellBindingHeal,
+
+		MaxRange: 40,
+
+		ManaCost: core.ManaCostOptions{
+			BaseCostPercent: 28,
+		},
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD:      core.GCDDefault,
+				CastTime: time.Millisecond * 1500,
+			},
+		},
+
+		DamageMultiplier: 1,
+		CritMultiplier:   priest.DefaultCritMultiplier(),
+		ThreatMultiplier: 0.5,
+		BonusCoefficient: bindingHealCoeff,
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			target = priest.HealTarget(target)
+			baseHealing := priest.CalcAndRollDamageRange(sim, bindingHealScale, bindingHealVariance)
+			spell.CalcAndDealHealing(sim, target, baseHealing, spell.OutcomeHealingCrit)
+			if target != &priest.Unit {
+				spell.CalcAndDealHealing(sim, &priest.Unit, baseHealing, spell.OutcomeHealingCrit)
+			}
+		},
+	})
+}
@@ -0,0 +1,53 @@
+package priest
+
+import (
+	"time"
+
+	"github.com/wowsims/mop/sim/core"
+	"github.com/wowsims/mop/sim/core/proto"
+)
+
+const circleOfHealingScale = 4.839
+const circleOfHealingVariance = 0.1
+const circleOfHealingCoeff = 0.467
+
+// Heals the most injured allies around the target.
+func (priest *Priest) registerCircleOfHealingSpell() {
+	hasGlyph := priest.HasMajorGlyph(proto.PriestMajorGlyph_GlyphOfCircleOfHealing)
+	numTargets := core.TernaryInt(hasGlyph, 6, 5)
+
+	priest.CircleOfHealing = priest.RegisterSpell(core.SpellConfig{
+		ActionID:       core.ActionID{SpellID: 34861},
+		SpellSchool:    core.SpellSchoolHoly,
+		ProcMask:       core.ProcMaskSpellHealing,
+		Flags:          core.SpellFlagHelpful | core.SpellFlagAPL,
+		ClassSpellMask: PriestSpellCircleOfHealing,
+
+		MaxRange: 40,
+
+		ManaCost: core.ManaCostOptions{
+			BaseCostPercent: core.TernaryFloat64(hasGlyph, 32*1.35, 32),
+		},
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.GCDDefault,
+			},
+			CD: core.Cooldown{
+				Timer:    priest.NewTimer(),
+				Duration: time.Second * 10,
+			},
+		},
+
+		DamageMultiplier: 1,
+		CritMultiplier:   priest.DefaultCritMultiplier(),
+		ThreatMultiplier: 1,
+		BonusCoefficient: circleOfHealingCoeff,
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			for _, aoeTarget := range priest.Env.Raid.GetLowestHealthAllyUnits(numTargets) {
+				baseHealing := priest.CalcAndRollDamageRange(sim, circleOfHealingScale, circleOfHealingVariance)
+				spell.CalcAndDealHealing(sim, aoeTarget, baseHealing, spell.OutcomeHealingCrit)
+			}
+		},
+	})
+}
@@ -27,6 +27,10 @@ type DisciplinePriest struct {
 	*priest.Priest
 
 	Options *proto.DisciplinePriest_Options
+
+	Atonement   *core.Spell
+	DivineAegis *core.Spell
+	SpiritShell *core.Spell
 }
 
 func newDisciplinePriest(character *core.Character, options *proto.Player) *DisciplinePriest {
@@ -56,27 +60,30 @@ func (discPriest *DisciplinePriest) GetPriest() *priest.Priest {
 	return discPriest.Priest
 }
 
-func (discPriest *DisciplinePriest) GetMainTarget() *core.Unit {
-	target := discPriest.Env.Raid.GetFirstTargetDummy()
-	if target == nil {
-		return &discPriest.Unit
-	} else {
-		return &target.Unit
-	}
-}
-
 func (discPriest *DisciplinePriest) Initialize() {
-	discPriest.CurrentTarget = discPriest.GetMainTarget()
 	discPriest.Priest.Initialize()
-	// discPriest.Priest.RegisterHealingSpells()
 
-	// // discPriest.ApplyRapture(discPriest.Options.RapturesPerMinute)
-	// discPriest.RegisterHymnOfHopeCD()
+	discPriest.RegisterHealingSpells()
+	discPriest.RegisterPowerWordShieldSpell()
+	discPriest.RegisterPenanceSpells()
+	discPriest.RegisterHolyFireSpell()
+	discPriest.RegisterSmiteSpell()
+	discPriest.RegisterHymnOfHopeCD()
+
+	discPriest.registerSpiritShell()
 }
 
-func (holyPriest *DisciplinePriest) ApplyTalents() {
+func (discPriest *DisciplinePriest) ApplyTalents() {
+	discPriest.Priest.ApplyTalents()
+
+	// Meditation
+	discPriest.PseudoStats.SpiritRegenRateCombat = 0.5
+
+	discPriest.registerAtonement()
+	discPriest.registerDivineAegis()
+	discPriest.registerShieldDiscipline() // Mastery
 }
 
 func (discPriest *DisciplinePriest) Reset(sim *core.Simulation) {
-	//discPriest.Priest.Reset(sim)
+	discPriest.Priest.Reset(sim)
 }
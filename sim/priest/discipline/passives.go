@@ -0,0 +1,145 @@
+package discipline
+
+import (
+	"math"
+	"time"
+
+	"github.com/wowsims/mop/sim/core"
+	"github.com/wowsims/mop/sim/priest"
+)
+
+const atonementPercent = 0.9
+const divineAegisPercent = 0.5
+
+// Damage from Smite, Holy Fire and Penance heals the most injured ally for 90%
+// of the damage dealt.
+func (disc *DisciplinePriest) registerAtonement() {
+	disc.Atonement = disc.RegisterSpell(core.SpellConfig{
+		ActionID:       core.ActionID{SpellID: 81751},
+		SpellSchool:    core.SpellSchoolHoly,
+		ProcMask:       core.ProcMaskSpellHealing,
+		Flags:          core.SpellFlagHelpful | core.SpellFlagNoOnCastComplete | core.SpellFlagPassiveSpell | core.SpellFlagIgnoreModifiers,
+		ClassSpellMask: priest.PriestSpellAtonement,
+
+		DamageMultiplier: 1,
+		CritMultiplier:   disc.DefaultCritMultiplier(),
+		ThreatMultiplier: 1,
+	})
+
+	disc.MakeProcTriggerAura(core.ProcTrigger{
+		Name:               "Atonement",
+		ActionID:           core.ActionID{SpellID: 81749},
+		Callback:           core.CallbackOnSpellHitDealt,
+		ProcMask:           core.ProcMaskSpellDamage,
+		ClassSpellMask:     priest.PriestSpellSmite | priest.PriestSpellHolyFire | priest.PriestSpellPenance,
+		Outcome:            core.OutcomeLanded,
+		TriggerImmediately: true,
+
+		Handler: func(sim *core.Simulation, _ *core.Spell, result *core.SpellResult) {
+			disc.Atonement.CalcAndDealHealing(sim, disc.atonementTarget(), result.Damage*atonementPercent, disc.Atonement.OutcomeHealing)
+		},
+	})
+}
+
+// Atonement prefers the most injured target dummy, falling back to the priest
+// when there are none.
+func (disc *DisciplinePriest) atonementTarget() *core.Unit {
+	target := &disc.Unit
+	lowestHealthPercent := math.MaxFloat64
+	for _, dummy := range disc.Env.Raid.GetTargetDummies() {
+		if !dummy.IsActive() {
+			continue
+		}
+
+		healthPercent := 1.0
+		if dummy.HasHealthBar() {
+			healthPercent = dummy.CurrentHealthPercent()
+		}
+		if healthPercent < lowestHealthPercent {
+			target = &dummy.Unit
+			lowestHealthPercent = healthPercent
+		}
+	}
+	return target
+}
+
+// Critical direct heals and all Prayer of Healing heals also shield the target
+// for 50% of the amount healed. The shield stacks up to 40% of the target's
+// maximum health.
+func (disc *DisciplinePriest) registerDivineAegis() {
+	disc.DivineAegis = disc.RegisterSpell(core.SpellConfig{
+		ActionID:       core.ActionID{SpellID: 47753},
+		SpellSchool:    core.SpellSchoolHoly,
+		ProcMask:       core.ProcMaskSpellHealing,
+		Flags:          core.SpellFlagHelpful | core.SpellFlagNoOnCastComplete | core.SpellFlagPassiveSpell,
+		ClassSpellMask: priest.PriestSpellDivineAegis,
+
+		DamageMultiplier: 1,
+		ThreatMultiplier: 1,
+
+		Shield: core.ShieldConfig{
+			Aura: core.Aura{
+				Label:     "Divine Aegis",
+				Duration:  time.Second * 15,
+				MaxStacks: math.MaxInt32,
+			},
+		},
+	})
+
+	disc.MakeProcTriggerAura(core.ProcTrigger{
+		Name:               "Divine Aegis Trigger",
+		ActionID:           core.ActionID{SpellID: 47515},
+		Callback:           core.CallbackOnHealDealt,
+		ProcMask:           core.ProcMaskSpellHealing,
+		ClassSpellMask:     priest.PriestSpellsAll ^ priest.PriestSpellAtonement,
+		TriggerImmediately: true,
+		ExtraCondition: func(_ *core.Simulation, spell *core.Spell, result *core.SpellResult) bool {
+			return result.DidCrit() || spell.Matches(priest.PriestSpellPrayerOfHealing)
+		},
+
+		Handler: func(sim *core.Simulation, _ *core.Spell, result *core.SpellResult) {
+			disc.applyDivineAegis(sim, result.Target, result.Damage)
+		},
+	})
+}
+
+func (disc *DisciplinePriest) applyDivineAegis(sim *core.Simulation, target *core.Unit, amountHealed float64) {
+	if disc.DivineAegis == nil || amountHealed <= 0 {
+		return
+	}
+
+	maxAbsorb := 0.0
+	if target.HasHealthBar() {
+		maxAbsorb = target.MaxHealth() * 0.4
+	}
+	disc.DivineAegis.Shield(target).Stack(sim, amountHealed*divineAegisPercent, maxAbsorb)
+}
+
+// Mastery: Shield Discipline. Increases the potency of all absorbs.
+func (disc *DisciplinePriest) registerShieldDiscipline() {
+	masteryMod := disc.AddDynamicMod(core.SpellModConfig{
+		Kind:      core.SpellMod_DamageDone_Pct,
+		ClassMask: priest.PriestSpellPowerWordShield | priest.PriestSpellDivineAegis | priest.PriestSpellSpiritShell,
+	})
+
+	core.MakePermanent(disc.RegisterAura(core.Aura{
+		Label:    "Mastery: Shield Discipline",
+		ActionID: core.ActionID{SpellID: 77484},
+
+		OnGain: func(_ *core.Aura, _ *core.Simulation) {
+			masteryMod.UpdateFloatValue(disc.shieldDisciplinePercent())
+			masteryMod.Activate()
+		},
+		OnExpire: func(_ *core.Aura, _ *core.Simulation) {
+			masteryMod.Deactivate()
+		},
+	}))
+
+	disc.AddOnMasteryStatChanged(func(_ *core.Simulation, _ float64, _ float64) {
+		masteryMod.UpdateFloatValue(disc.shieldDisciplinePercent())
+	})
+}
+
+func (disc *DisciplinePriest) shieldDisciplinePercent() float64 {
+	return (8 + disc.GetMasteryPoints()) * 1.6 / 100
+}
@@ -0,0 +1,71 @@
+package discipline
+
+import (
+	"math"
+	"time"
+
+	"github.com/wowsims/mop/sim/core"
+	"github.com/wowsims/mop/sim/priest"
+)
+
+// For 15 seconds, Heal, Flash Heal, Greater Heal and Prayer of Healing absorb
+// damage instead of healing. The absorb stacks up to 60% of the target's
+// maximum health.
+func (disc *DisciplinePriest) registerSpiritShell() {
+	absorbSpell := disc.RegisterSpell(core.SpellConfig{
+		ActionID:       core.ActionID{SpellID: 114908},
+		SpellSchool:    core.SpellSchoolHoly,
+		ProcMask:       core.ProcMaskSpellHealing,
+		Flags:          core.SpellFlagHelpful | core.SpellFlagNoOnCastComplete | core.SpellFlagPassiveSpell,
+		ClassSpellMask: priest.PriestSpellSpiritShell,
+
+		DamageMultiplier: 1,
+		ThreatMultiplier: 1,
+
+		Shield: core.ShieldConfig{
+			Aura: core.Aura{
+				Label:     "Spirit Shell Absorb",
+				Duration:  time.Second * 15,
+				MaxStacks: math.MaxInt32,
+			},
+		},
+	})
+
+	disc.SpiritShellAbsorb = func(sim *core.Simulation, result *core.SpellResult) {
+		target := result.Target
+		maxAbsorb := 0.0
+		if target.HasHealthBar() {
+			maxAbsorb = target.MaxHealth() * 0.6
+		}
+		absorbSpell.Shield(target).Stack(sim, result.Damage, maxAbsorb)
+
+		// Spirit Shell absorbs can still trigger Divine Aegis.
+		if result.DidCrit() {
+			disc.applyDivineAegis(sim, target, result.Damage)
+		}
+	}
+
+	disc.SpiritShellAura = disc.RegisterAura(core.Aura{
+		Label:    "Spirit Shell",
+		ActionID: core.ActionID{SpellID: 109964},
+		Duration: time.Second * 15,
+	})
+
+	disc.SpiritShell = disc.RegisterSpell(core.SpellConfig{
+		ActionID:       core.ActionID{SpellID: 109964},
+		SpellSchool:    core.SpellSchoolHoly,
+		Flags:          core.SpellFlagHelpful | core.SpellFlagAPL,
+		ClassSpellMask: priest.PriestSpellSpiritShell,
+
+		Cast: core.CastConfig{
+			CD: core.Cooldown{
+				Timer:    disc.NewTimer(),
+				Duration: time.Minute,
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, _ *core.Spell) {
+			disc.SpiritShellAura.Activate(sim)
+		},
+	})
+}
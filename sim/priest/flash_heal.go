@@ -0,0 +1,44 @@
+package priest
+
+import (
+	"time"
+
+	"github.com/wowsims/mop/sim/core"
+)
+
+const flashHealScale = 12.262
+const flashHealVariance = 0.15
+const flashHealCoeff = 1.314
+
+func (priest *Priest) registerFlashHealSpell() {
+	priest.FlashHeal = priest.RegisterSpell(core.SpellConfig{
+		ActionID:       core.ActionID{SpellID: 2061},
+		SpellSchool:    core.SpellSchoolHoly,
+		ProcMask:       core.ProcMaskSpellHealing,
+		Flags:          core.SpellFlagHelpful | core.SpellFlagAPL,
+		ClassSpellMask: PriestSpellFlashHeal,
+
+		MaxRange: 40,
+
+		ManaCost: core.ManaCostOptions{
+			BaseCostPercent: 28.4,
+		},
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD:      core.GCDDefault,
+				CastTime: time.Millisecond * 1500,
+			},
+		},
+
+		DamageMultiplier: 1,
+		CritMultiplier:   priest.DefaultCritMultiplier(),
+		ThreatMultiplier: 1,
+		BonusCoefficient: flashHealCoeff,
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			target = priest.HealTarget(target)
+			baseHealing := priest.CalcAndRollDamageRange(sim, flashHealScale, flashHealVariance)
+			priest.calcAndDealShellableHealing(sim, spell, target, baseHealing)
+		},
+	})
+}
@@ -0,0 +1,44 @@
+package priest
+
+import (
+	"time"
+
+	"github.com/wowsims/mop/sim/core"
+)
+
+const greaterHealScale = 21.857
+const greaterHealVariance = 0.15
+const greaterHealCoeff = 2.19
+
+func (priest *Priest) registerGreaterHealSpell() {
+	priest.GreaterHeal = priest.RegisterSpell(core.SpellConfig{
+		ActionID:       core.ActionID{SpellID: 2060},
+		SpellSchool:    core.SpellSchoolHoly,
+		ProcMask:       core.ProcMaskSpellHealing,
+		Flags:          core.SpellFlagHelpful | core.SpellFlagAPL,
+		ClassSpellMask: PriestSpellGreaterHeal,
+
+		MaxRange: 40,
+
+		ManaCost: core.ManaCostOptions{
+			BaseCostPercent: 59,
+		},
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD:      core.GCDDefault,
+				CastTime: time.Millisecond * 2500,
+			},
+		},
+
+		DamageMultiplier: 1,
+		CritMultiplier:   priest.DefaultCritMultiplier(),
+		ThreatMultiplier: 1,
+		BonusCoefficient: greaterHealCoeff,
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			target = priest.HealTarget(target)
+			baseHealing := priest.CalcAndRollDamageRange(sim, greaterHealScale, greaterHealVariance)
+			priest.calcAndDealShellableHealing(sim, spell, target, baseHealing)
+		},
+	})
+}
@@ -0,0 +1,44 @@
+package priest
+
+import (
+	"time"
+
+	"github.com/wowsims/mop/sim/core"
+)
+
+const healScale = 9.494
+const healVariance = 0.15
+const healCoeff = 1.024
+
+func (priest *Priest) registerHealSpell() {
+	priest.Heal = priest.RegisterSpell(core.SpellConfig{
+		ActionID:       core.ActionID{SpellID: 2050},
+		SpellSchool:    core.SpellSchoolHoly,
+		ProcMask:       core.ProcMaskSpellHealing,
+		Flags:          core.SpellFlagHelpful | core.SpellFlagAPL,
+		ClassSpellMask: PriestSpellHeal,
+
+		MaxRange: 40,
+
+		ManaCost: core.ManaCostOptions{
+			BaseCostPercent: 19,
+		},
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD:      core.GCDDefault,
+				CastTime: time.Millisecond * 2500,
+			},
+		},
+
+		DamageMultiplier: 1,
+		CritMultiplier:   priest.DefaultCritMultiplier(),
+		ThreatMultiplier: 1,
+		BonusCoefficient: healCoeff,
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			target = priest.HealTarget(target)
+			baseHealing := priest.CalcAndRollDamageRange(sim, healScale, healVariance)
+			priest.calcAndDealShellableHealing(sim, spell, target, baseHealing)
+		},
+	})
+}
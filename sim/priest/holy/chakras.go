@@ -0,0 +1,104 @@
+package holy
+
+import (
+	"time"
+
+	"github.com/wowsims/mop/sim/core"
+	"github.com/wowsims/mop/sim/priest"
+)
+
+const chakraSerenityMask = priest.PriestSpellHeal |
+	priest.PriestSpellFlashHeal |
+	priest.PriestSpellGreaterHeal |
+	priest.PriestSpellBindingHeal |
+	priest.PriestSpellRenew |
+	priest.PriestSpellEmpoweredRenew |
+	priest.PriestSpellHolyWordSerenity
+
+const chakraSanctuaryMask = priest.PriestSpellPrayerOfHealing |
+	priest.PriestSpellPrayerOfMending |
+	priest.PriestSpellCircleOfHealing |
+	priest.PriestSpellDivineHymn |
+	priest.PriestSpellHolyWordSanctuary |
+	priest.PriestSpellCascade |
+	priest.PriestSpellDivineStar |
+	priest.PriestSpellHalo
+
+const chakraChastiseMask = priest.PriestSpellSmite |
+	priest.PriestSpellHolyFire |
+	priest.PriestSpellHolyWordChastise
+
+// Each chakra lasts until another one is entered, and they share a cooldown.
+func (holy *HolyPriest) registerChakras() {
+	chakraTimer := holy.NewTimer()
+
+	registerChakra := func(label string, spellID int32, config core.SpellModConfig) *core.Aura {
+		aura := holy.RegisterAura(core.Aura{
+			Label:    label,
+			ActionID: core.ActionID{SpellID: spellID},
+			Duration: core.NeverExpires,
+		}).AttachSpellMod(config)
+
+		holy.RegisterSpell(core.SpellConfig{
+			ActionID: core.ActionID{SpellID: spellID},
+			Flags:    core.SpellFlagAPL | core.SpellFlagNoOnCastComplete,
+
+			Cast: core.CastConfig{
+				CD: core.Cooldown{
+					Timer:    chakraTimer,
+					Duration: time.Second * 30,
+				},
+			},
+
+			ApplyEffects: func(sim *core.Simulation, _ *core.Unit, _ *core.Spell) {
+				for _, chakra := range []*core.Aura{holy.ChakraSerenityAura, holy.ChakraSanctuaryAura, holy.ChakraChastiseAura} {
+					if chakra != aura {
+						chakra.Deactivate(sim)
+					}
+				}
+				aura.Activate(sim)
+			},
+		})
+		return aura
+	}
+
+	holy.ChakraSerenityAura = registerChakra("Chakra: Serenity", 81208, core.SpellModConfig{
+		Kind:       core.SpellMod_DamageDone_Pct,
+		ClassMask:  chakraSerenityMask,
+		FloatValue: 0.25,
+	})
+	holy.ChakraSanctuaryAura = registerChakra("Chakra: Sanctuary", 81206, core.SpellModConfig{
+		Kind:       core.SpellMod_DamageDone_Pct,
+		ClassMask:  chakraSanctuaryMask,
+		FloatValue: 0.25,
+	})
+	holy.ChakraChastiseAura = registerChakra("Chakra: Chastise", 81209, core.SpellModConfig{
+		Kind:       core.SpellMod_DamageDone_Pct,
+		ClassMask:  chakraChastiseMask,
+		FloatValue: 0.5,
+	})
+
+	// Sanctuary also shortens the cooldown of Circle of Healing.
+	holy.ChakraSanctuaryAura.AttachSpellMod(core.SpellModConfig{
+		Kind:      core.SpellMod_Cooldown_Flat,
+		ClassMask: priest.PriestSpellCircleOfHealing,
+		TimeValue: -time.Second * 2,
+	})
+
+	// Serenity also refreshes Renew on the targets of direct single target heals.
+	holy.MakeProcTriggerAura(core.ProcTrigger{
+		Name:               "Chakra: Serenity - Renew",
+		Callback:           core.CallbackOnHealDealt,
+		ClassSpellMask:     priest.PriestSpellHeal | priest.PriestSpellFlashHeal | priest.PriestSpellGreaterHeal | priest.PriestSpellBindingHeal | priest.PriestSpellHolyWordSerenity,
+		TriggerImmediately: true,
+		ExtraCondition: func(_ *core.Simulation, _ *core.Spell, _ *core.SpellResult) bool {
+			return holy.ChakraSerenityAura.IsActive()
+		},
+
+		Handler: func(sim *core.Simulation, _ *core.Spell, result *core.SpellResult) {
+			if renew := holy.Renew.Hot(result.Target); renew.IsActive() {
+				renew.Apply(sim)
+			}
+		},
+	})
+}
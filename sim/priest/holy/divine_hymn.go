@@ -0,0 +1,63 @@
+package holy
+
+import (
+	"time"
+
+	"github.com/wowsims/mop/sim/core"
+	"github.com/wowsims/mop/sim/priest"
+)
+
+const divineHymnScale = 7.59
+const divineHymnCoeff = 1.542
+
+// Channels for 8 seconds, healing the most injured allies every 2 seconds.
+func (holy *HolyPriest) registerDivineHymn() {
+	numTargets := core.TernaryInt(len(holy.Env.Raid.AllPlayerUnits) > 10, 12, 5)
+
+	holy.DivineHymn = holy.RegisterSpell(core.SpellConfig{
+		ActionID:       core.ActionID{SpellID: 64843},
+		SpellSchool:    core.SpellSchoolHoly,
+		ProcMask:       core.ProcMaskSpellHealing,
+		Flags:          core.SpellFlagHelpful | core.SpellFlagChanneled | core.SpellFlagAPL,
+		ClassSpellMask: priest.PriestSpellDivineHymn,
+
+		ManaCost: core.ManaCostOptions{
+			BaseCostPercent: 63.5,
+		},
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.GCDDefault,
+			},
+			CD: core.Cooldown{
+				Timer:    holy.NewTimer(),
+				Duration: time.Minute * 3,
+			},
+		},
+
+		DamageMultiplier: 1,
+		CritMultiplier:   holy.DefaultCritMultiplier(),
+		ThreatMultiplier: 1,
+		BonusCoefficient: divineHymnCoeff,
+
+		Hot: core.DotConfig{
+			SelfOnly: true,
+			Aura: core.Aura{
+				Label: "Divine Hymn",
+			},
+			NumberOfTicks:        4,
+			TickLength:           time.Second * 2,
+			AffectedByCastSpeed:  true,
+			HasteReducesDuration: true,
+
+			OnTick: func(sim *core.Simulation, _ *core.Unit, dot *core.Dot) {
+				for _, target := range holy.Env.Raid.GetLowestHealthAllyUnits(numTargets) {
+					dot.Spell.CalcAndDealPeriodicHealing(sim, target, holy.CalcScalingSpellDmg(divineHymnScale), dot.OutcomeTickHealingCrit)
+				}
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			spell.SelfHot().Apply(sim)
+		},
+	})
+}
@@ -41,6 +41,19 @@ func NewHolyPriest(character *core.Character, options *proto.Player) *HolyPriest
 
 type HolyPriest struct {
 	*priest.Priest
+
+	SerendipityAura *core.Aura
+
+	ChakraSerenityAura  *core.Aura
+	ChakraSanctuaryAura *core.Aura
+	ChakraChastiseAura  *core.Aura
+
+	HolyWordSerenity  *core.Spell
+	HolyWordSanctuary *core.Spell
+	HolyWordChastise  *core.Spell
+	DivineHymn        *core.Spell
+	Lightwell         *core.Spell
+	EchoOfLight       *core.Spell
 }
 
 func (holyPriest *HolyPriest) GetPriest() *priest.Priest {
@@ -50,15 +63,28 @@ func (holyPriest *HolyPriest) GetPriest() *priest.Priest {
 func (holyPriest *HolyPriest) Initialize() {
 	holyPriest.Priest.Initialize()
 
-	// holyPriest.RegisterHolyFireSpell()
-	// holyPriest.RegisterSmiteSpell()
-	// holyPriest.RegisterPenanceSpell()
-	// holyPriest.RegisterHymnOfHopeCD()
+	holyPriest.RegisterHealingSpells()
+	holyPriest.RegisterHolyFireSpell()
+	holyPriest.RegisterSmiteSpell()
+	holyPriest.RegisterHymnOfHopeCD()
+
+	holyPriest.registerChakras()
+	holyPriest.registerHolyWords()
+	holyPriest.registerDivineHymn()
+	holyPriest.registerLightwell()
 }
 
 func (holyPriest *HolyPriest) ApplyTalents() {
+	holyPriest.Priest.ApplyTalents()
+
+	// Meditation
+	holyPriest.PseudoStats.SpiritRegenRateCombat = 0.5
+
+	holyPriest.registerRapidRenewal()
+	holyPriest.registerSerendipity()
+	holyPriest.registerEchoOfLight() // Mastery
 }
 
 func (holyPriest *HolyPriest) Reset(sim *core.Simulation) {
-	//holyPriest.Priest.Reset(sim)
+	holyPriest.Priest.Reset(sim)
 }
@@ -0,0 +1,173 @@
+package holy
+
+import (
+	"time"
+
+	"github.com/wowsims/mop/sim/core"
+	"github.com/wowsims/mop/sim/priest"
+)
+
+const serenityScale = 12.68
+const serenityVariance = 0.16
+const serenityCoeff = 1.3
+
+const sanctuaryScale = 0.585
+const sanctuaryVariance = 0.17
+const sanctuaryCoeff = 0.0583
+
+const chastiseScale = 0.945
+const chastiseVariance = 0.12
+const chastiseCoeff = 0.614
+
+// Holy Word: Chastise turns into Serenity or Sanctuary while in the matching
+// chakra.
+func (holy *HolyPriest) registerHolyWords() {
+	holy.registerHolyWordSerenity()
+	holy.registerHolyWordSanctuary()
+	holy.registerHolyWordChastise()
+}
+
+func (holy *HolyPriest) registerHolyWordSerenity() {
+	critAuras := holy.NewAllyAuraArray(func(target *core.Unit) *core.Aura {
+		return target.RegisterAura(core.Aura{
+			Label:    "Holy Word: Serenity-" + holy.Label,
+			ActionID: core.ActionID{SpellID: 88684},
+			Duration: time.Second * 6,
+			OnGain: func(aura *core.Aura, _ *core.Simulation) {
+				holy.AttackTables[aura.Unit.UnitIndex].BonusSpellCritPercent += 25
+			},
+			OnExpire: func(aura *core.Aura, _ *core.Simulation) {
+				holy.AttackTables[aura.Unit.UnitIndex].BonusSpellCritPercent -= 25
+			},
+		})
+	})
+
+	holy.HolyWordSerenity = holy.RegisterSpell(core.SpellConfig{
+		ActionID:       core.ActionID{SpellID: 88684},
+		SpellSchool:    core.SpellSchoolHoly,
+		ProcMask:       core.ProcMaskSpellHealing,
+		Flags:          core.SpellFlagHelpful | core.SpellFlagAPL,
+		ClassSpellMask: priest.PriestSpellHolyWordSerenity,
+
+		MaxRange: 40,
+
+		ManaCost: core.ManaCostOptions{
+			BaseCostPercent: 2,
+		},
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.GCDDefault,
+			},
+			CD: core.Cooldown{
+				Timer:    holy.NewTimer(),
+				Duration: time.Second * 10,
+			},
+		},
+		ExtraCastCondition: func(_ *core.Simulation, _ *core.Unit) bool {
+			return holy.ChakraSerenityAura.IsActive()
+		},
+
+		DamageMultiplier: 1,
+		CritMultiplier:   holy.DefaultCritMultiplier(),
+		ThreatMultiplier: 1,
+		BonusCoefficient: serenityCoeff,
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			target = holy.HealTarget(target)
+			baseHealing := holy.CalcAndRollDamageRange(sim, serenityScale, serenityVariance)
+			spell.CalcAndDealHealing(sim, target, baseHealing, spell.OutcomeHealingCrit)
+			critAuras.Get(target).Activate(sim)
+		},
+	})
+}
+
+// Consecrates the ground for 30 seconds, healing up to 6 allies standing in it
+// every 2 seconds.
+func (holy *HolyPriest) registerHolyWordSanctuary() {
+	holy.HolyWordSanctuary = holy.RegisterSpell(core.SpellConfig{
+		ActionID:       core.ActionID{SpellID: 88685},
+		SpellSchool:    core.SpellSchoolHoly,
+		ProcMask:       core.ProcMaskSpellHealing,
+		Flags:          core.SpellFlagHelpful | core.SpellFlagAPL,
+		ClassSpellMask: priest.PriestSpellHolyWordSanctuary,
+
+		ManaCost: core.ManaCostOptions{
+			BaseCostPercent: 6,
+		},
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.GCDDefault,
+			},
+			CD: core.Cooldown{
+				Timer:    holy.NewTimer(),
+				Duration: time.Second * 40,
+			},
+		},
+		ExtraCastCondition: func(_ *core.Simulation, _ *core.Unit) bool {
+			return holy.ChakraSanctuaryAura.IsActive()
+		},
+
+		DamageMultiplier: 1,
+		CritMultiplier:   holy.DefaultCritMultiplier(),
+		ThreatMultiplier: 1,
+		BonusCoefficient: sanctuaryCoeff,
+
+		Hot: core.DotConfig{
+			SelfOnly: true,
+			Aura: core.Aura{
+				Label: "Holy Word: Sanctuary",
+			},
+			NumberOfTicks: 15,
+			TickLength:    time.Second * 2,
+
+			OnTick: func(sim *core.Simulation, _ *core.Unit, dot *core.Dot) {
+				for _, target := range holy.Env.Raid.GetLowestHealthAllyUnits(6) {
+					baseHealing := holy.CalcAndRollDamageRange(sim, sanctuaryScale, sanctuaryVariance)
+					dot.Spell.CalcAndDealPeriodicHealing(sim, target, baseHealing, dot.OutcomeTickHealingCrit)
+				}
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			spell.SelfHot().Apply(sim)
+		},
+	})
+}
+
+func (holy *HolyPriest) registerHolyWordChastise() {
+	holy.HolyWordChastise = holy.RegisterSpell(core.SpellConfig{
+		ActionID:       core.ActionID{SpellID: 88625},
+		SpellSchool:    core.SpellSchoolHoly,
+		ProcMask:       core.ProcMaskSpellDamage,
+		Flags:          core.SpellFlagAPL,
+		ClassSpellMask: priest.PriestSpellHolyWordChastise,
+
+		MaxRange: 30,
+
+		ManaCost: core.ManaCostOptions{
+			BaseCostPercent: 3,
+		},
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.GCDDefault,
+			},
+			CD: core.Cooldown{
+				Timer:    holy.NewTimer(),
+				Duration: time.Second * 30,
+			},
+		},
+		ExtraCastCondition: func(_ *core.Simulation, _ *core.Unit) bool {
+			return !holy.ChakraSerenityAura.IsActive() && !holy.ChakraSanctuaryAura.IsActive()
+		},
+
+		DamageMultiplier: 1,
+		CritMultiplier:   holy.DefaultCritMultiplier(),
+		ThreatMultiplier: 1,
+		BonusCoefficient: chastiseCoeff,
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			baseDamage := holy.CalcAndRollDamageRange(sim, chastiseScale, chastiseVariance)
+			spell.CalcAndDealDamage(sim, target, baseDamage, spell.OutcomeMagicHitAndCrit)
+		},
+	})
+}
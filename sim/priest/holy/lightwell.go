@@ -0,0 +1,100 @@
+package holy
+
+import (
+	"time"
+
+	"github.com/wowsims/mop/sim/core"
+	"github.com/wowsims/mop/sim/priest"
+)
+
+const lightwellRenewScale = 3.68
+const lightwellRenewCoeff = 0.308
+
+const lightwellCharges = 15
+const lightwellDuration = time.Minute * 3
+
+// The Lightwell puts a Lightwell Renew on the most injured ally without one
+// every 2 seconds, until its charges run out.
+func (holy *HolyPriest) registerLightwell() {
+	lightwellRenew := holy.RegisterSpell(core.SpellConfig{
+		ActionID:       core.ActionID{SpellID: 7001},
+		SpellSchool:    core.SpellSchoolHoly,
+		ProcMask:       core.ProcMaskSpellHealing,
+		Flags:          core.SpellFlagHelpful | core.SpellFlagNoOnCastComplete | core.SpellFlagPassiveSpell,
+		ClassSpellMask: priest.PriestSpellLightwell,
+
+		DamageMultiplier: 1,
+		CritMultiplier:   holy.DefaultCritMultiplier(),
+		ThreatMultiplier: 1,
+
+		Hot: core.DotConfig{
+			Aura: core.Aura{
+				Label: "Lightwell Renew",
+			},
+			NumberOfTicks:       3,
+			TickLength:          time.Second * 2,
+			AffectedByCastSpeed: true,
+			BonusCoefficient:    lightwellRenewCoeff,
+
+			OnSnapshot: func(_ *core.Simulation, target *core.Unit, dot *core.Dot, _ bool) {
+				dot.SnapshotHeal(target, holy.CalcScalingSpellDmg(lightwellRenewScale))
+			},
+			OnTick: func(sim *core.Simulation, target *core.Unit, dot *core.Dot) {
+				dot.CalcAndDealPeriodicSnapshotHealing(sim, target, dot.OutcomeSnapshotCrit)
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			spell.Hot(target).Apply(sim)
+		},
+	})
+
+	var lightwellAction *core.PendingAction
+	charges := 0
+	placeRenew := func(sim *core.Simulation) {
+		if charges == 0 {
+			return
+		}
+		for _, target := range holy.Env.Raid.GetLowestHealthAllyUnits(len(holy.Env.Raid.AllUnits)) {
+			if !lightwellRenew.Hot(target).IsActive() {
+				lightwellRenew.Cast(sim, target)
+				charges--
+				return
+			}
+		}
+	}
+
+	holy.Lightwell = holy.RegisterSpell(core.SpellConfig{
+		ActionID:       core.ActionID{SpellID: 724},
+		SpellSchool:    core.SpellSchoolHoly,
+		Flags:          core.SpellFlagHelpful | core.SpellFlagAPL,
+		ClassSpellMask: priest.PriestSpellLightwell,
+
+		ManaCost: core.ManaCostOptions{
+			BaseCostPercent: 30,
+		},
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.GCDDefault,
+			},
+			CD: core.Cooldown{
+				Timer:    holy.NewTimer(),
+				Duration: time.Minute * 3,
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, _ *core.Spell) {
+			if lightwellAction != nil {
+				lightwellAction.Cancel(sim)
+			}
+
+			charges = lightwellCharges
+			placeRenew(sim)
+			lightwellAction = core.StartPeriodicAction(sim, core.PeriodicActionOptions{
+				Period:   time.Second * 2,
+				NumTicks: int(lightwellDuration/(time.Second*2)) - 1,
+				OnAction: placeRenew,
+			})
+		},
+	})
+}
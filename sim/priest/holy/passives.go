@@ -0,0 +1,139 @@
+package holy
+
+import (
+	"time"
+
+	"github.com/wowsims/mop/sim/core"
+	"github.com/wowsims/mop/sim/priest"
+)
+
+// Renew also heals instantly for 15% of its periodic healing, and has a
+// shorter global cooldown.
+func (holy *HolyPriest) registerRapidRenewal() {
+	holy.AddStaticMod(core.SpellModConfig{
+		Kind:      core.SpellMod_GlobalCooldown_Flat,
+		ClassMask: priest.PriestSpellRenew,
+		TimeValue: -time.Millisecond * 500,
+	})
+
+	holy.EmpoweredRenew = holy.RegisterSpell(core.SpellConfig{
+		ActionID:       core.ActionID{SpellID: 63544},
+		SpellSchool:    core.SpellSchoolHoly,
+		ProcMask:       core.ProcMaskSpellHealing,
+		Flags:          core.SpellFlagHelpful | core.SpellFlagNoOnCastComplete | core.SpellFlagPassiveSpell,
+		ClassSpellMask: priest.PriestSpellEmpoweredRenew,
+
+		DamageMultiplier: 1,
+		CritMultiplier:   holy.DefaultCritMultiplier(),
+		ThreatMultiplier: 1,
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			renew := holy.Renew.Hot(target)
+			baseHealing := renew.SnapshotBaseDamage * float64(renew.BaseTickCount) * 0.15
+			spell.CalcAndDealHealing(sim, target, baseHealing, spell.OutcomeHealingCrit)
+		},
+	})
+}
+
+// Flash Heal and Binding Heal reduce the cast time and cost of the next
+// Greater Heal or Prayer of Healing by 20%, stacking twice.
+func (holy *HolyPriest) registerSerendipity() {
+	const consumers = priest.PriestSpellGreaterHeal | priest.PriestSpellPrayerOfHealing
+
+	castTimeMod := holy.AddDynamicMod(core.SpellModConfig{
+		Kind:      core.SpellMod_CastTime_Pct,
+		ClassMask: consumers,
+	})
+	costMod := holy.AddDynamicMod(core.SpellModConfig{
+		Kind:      core.SpellMod_PowerCost_Pct,
+		ClassMask: consumers,
+	})
+
+	holy.SerendipityAura = holy.RegisterAura(core.Aura{
+		Label:     "Serendipity",
+		ActionID:  core.ActionID{SpellID: 63735},
+		Duration:  time.Second * 20,
+		MaxStacks: 2,
+		OnStacksChange: func(_ *core.Aura, _ *core.Simulation, _ int32, newStacks int32) {
+			castTimeMod.UpdateFloatValue(-0.2 * float64(newStacks))
+			costMod.UpdateFloatValue(-0.2 * float64(newStacks))
+		},
+		OnGain: func(_ *core.Aura, _ *core.Simulation) {
+			castTimeMod.Activate()
+			costMod.Activate()
+		},
+		OnExpire: func(_ *core.Aura, _ *core.Simulation) {
+			castTimeMod.Deactivate()
+			costMod.Deactivate()
+		},
+		OnCastComplete: func(aura *core.Aura, sim *core.Simulation, spell *core.Spell) {
+			if spell.Matches(consumers) {
+				aura.Deactivate(sim)
+			}
+		},
+	})
+
+	holy.MakeProcTriggerAura(core.ProcTrigger{
+		Name:               "Serendipity Trigger",
+		Callback:           core.CallbackOnCastComplete,
+		ClassSpellMask:     priest.PriestSpellFlashHeal | priest.PriestSpellBindingHeal,
+		TriggerImmediately: true,
+
+		Handler: func(sim *core.Simulation, _ *core.Spell, _ *core.SpellResult) {
+			holy.SerendipityAura.Activate(sim)
+			holy.SerendipityAura.AddStack(sim)
+		},
+	})
+}
+
+// Mastery: Echo of Light. Direct heals also heal the target for a percentage
+// of the amount healed over 6 seconds. Reapplying it rolls the remaining
+// healing into the new effect.
+func (holy *HolyPriest) registerEchoOfLight() {
+	holy.EchoOfLight = holy.RegisterSpell(core.SpellConfig{
+		ActionID:       core.ActionID{SpellID: 77489},
+		SpellSchool:    core.SpellSchoolHoly,
+		ProcMask:       core.ProcMaskEmpty,
+		Flags:          core.SpellFlagHelpful | core.SpellFlagNoOnCastComplete | core.SpellFlagPassiveSpell | core.SpellFlagIgnoreModifiers,
+		ClassSpellMask: priest.PriestSpellEchoOfLight,
+
+		DamageMultiplier: 1,
+		ThreatMultiplier: 1,
+
+		Hot: core.DotConfig{
+			Aura: core.Aura{
+				Label: "Echo of Light",
+			},
+			NumberOfTicks: 6,
+			TickLength:    time.Second,
+
+			OnTick: func(sim *core.Simulation, target *core.Unit, dot *core.Dot) {
+				dot.CalcAndDealPeriodicSnapshotHealing(sim, target, dot.OutcomeTick)
+			},
+		},
+	})
+
+	holy.MakeProcTriggerAura(core.ProcTrigger{
+		Name:               "Echo of Light Trigger",
+		Callback:           core.CallbackOnHealDealt,
+		ProcMask:           core.ProcMaskSpellHealing,
+		ClassSpellMask:     priest.PriestSpellsAll,
+		TriggerImmediately: true,
+
+		Handler: func(sim *core.Simulation, _ *core.Spell, result *core.SpellResult) {
+			if result.Damage <= 0 {
+				return
+			}
+
+			hot := holy.EchoOfLight.Hot(result.Target)
+			totalHealing := result.Damage*holy.echoOfLightPercent() + hot.OutstandingDmg()
+			hot.Apply(sim)
+			hot.SnapshotBaseDamage = totalHealing / float64(hot.RemainingTicks())
+			hot.SnapshotAttackerMultiplier = 1
+		},
+	})
+}
+
+func (holy *HolyPriest) echoOfLightPercent() float64 {
+	return (8 + holy.GetMasteryPoints()) * 1.25 / 100
+}
@@ -0,0 +1,69 @@
+package priest
+
+import (
+	"time"
+
+	"github.com/wowsims/mop/sim/core"
+)
+
+const holyFireScale = 1.08
+const holyFireVariance = 0.238
+const holyFireCoeff = 1.11
+const holyFireDotScale = 0.055
+const holyFireDotCoeff = 0.0312
+
+func (priest *Priest) RegisterHolyFireSpell() {
+	priest.HolyFire = priest.RegisterSpell(core.SpellConfig{
+		ActionID:       core.ActionID{SpellID: 14914},
+		SpellSchool:    core.SpellSchoolHoly,
+		ProcMask:       core.ProcMaskSpellDamage,
+		Flags:          core.SpellFlagAPL,
+		ClassSpellMask: PriestSpellHolyFire,
+
+		MaxRange: 30,
+
+		ManaCost: core.ManaCostOptions{
+			BaseCostPercent: 0.9,
+		},
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD:      core.GCDDefault,
+				CastTime: time.Millisecond * 1500,
+			},
+			CD: core.Cooldown{
+				Timer:    priest.NewTimer(),
+				Duration: time.Second * 10,
+			},
+		},
+
+		DamageMultiplier: 1,
+		CritMultiplier:   priest.DefaultCritMultiplier(),
+		ThreatMultiplier: 1,
+		BonusCoefficient: holyFireCoeff,
+
+		Dot: core.DotConfig{
+			Aura: core.Aura{
+				Label: "Holy Fire",
+			},
+			NumberOfTicks:    7,
+			TickLength:       time.Second,
+			BonusCoefficient: holyFireDotCoeff,
+
+			OnSnapshot: func(_ *core.Simulation, target *core.Unit, dot *core.Dot, _ bool) {
+				dot.Snapshot(target, priest.CalcScalingSpellDmg(holyFireDotScale))
+			},
+			OnTick: func(sim *core.Simulation, target *core.Unit, dot *core.Dot) {
+				dot.CalcAndDealPeriodicSnapshotDamage(sim, target, dot.OutcomeSnapshotCrit)
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			baseDamage := priest.CalcAndRollDamageRange(sim, holyFireScale, holyFireVariance)
+			result := spell.CalcDamage(sim, target, baseDamage, spell.OutcomeMagicHitAndCrit)
+			if result.Landed() {
+				spell.Dot(target).Apply(sim)
+			}
+			spell.DealDamage(sim, result)
+		},
+	})
+}
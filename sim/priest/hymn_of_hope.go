@@ -4,7 +4,6 @@ import (
 	"time"
 
 	"github.com/wowsims/mop/sim/core"
-	"github.com/wowsims/mop/sim/core/proto"
 )
 
 // TODO: This currently only affects the caster, not other raid members.
@@ -12,11 +11,10 @@ func (priest *Priest) RegisterHymnOfHopeCD() {
 	actionID := core.ActionID{SpellID: 64901}
 	manaMetrics := priest.NewManaMetrics(actionID)
 
-	numTicks := 4 + core.TernaryInt32(priest.HasMajorGlyph(proto.PriestMajorGlyph_GlyphOfHymnOfHope), 1, 0)
-
 	hymnOfHopeSpell := priest.RegisterSpell(core.SpellConfig{
-		ActionID: actionID,
-		Flags:    core.SpellFlagHelpful,
+		ActionID:       actionID,
+		Flags:          core.SpellFlagHelpful | core.SpellFlagChanneled | core.SpellFlagAPL,
+		ClassSpellMask: PriestSpellHymnOfHope,
 
 		Cast: core.CastConfig{
 			DefaultCast: core.Cast{
@@ -28,16 +26,24 @@ func (priest *Priest) RegisterHymnOfHopeCD() {
 			},
 		},
 
+		Hot: core.DotConfig{
+			SelfOnly: true,
+			Aura: core.Aura{
+				Label: "Hymn of Hope",
+			},
+			NumberOfTicks:       4,
+			TickLength:          time.Second * 2,
+			AffectedByCastSpeed: true,
+
+			OnTick: func(sim *core.Simulation, _ *core.Unit, _ *core.Dot) {
+				// This is 2%, but it increases the target's max mana by 15% for the duration
+				// so just simplify to 2 * 1.15 = 2.3%.
+				priest.AddMana(sim, priest.MaxMana()*0.023, manaMetrics)
+			},
+		},
+
 		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
-			core.StartPeriodicAction(sim, core.PeriodicActionOptions{
-				Period:   spell.Unit.ApplyCastSpeedForSpell(time.Second*2, spell),
-				NumTicks: int(numTicks),
-				OnAction: func(sim *core.Simulation) {
-					// This is 3%, but it increases the target's max mana by 20% for the duration
-					// so just simplify to 3 * 1.2 = 3.6%.
-					priest.AddMana(sim, priest.MaxMana()*0.036, manaMetrics)
-				},
-			})
+			spell.SelfHot().Apply(sim)
 		},
 	})
 
@@ -0,0 +1,98 @@
+package priest
+
+import (
+	"time"
+
+	"github.com/wowsims/mop/sim/core"
+)
+
+const penanceDamageScale = 1.702
+const penanceDamageVariance = 0.122
+const penanceDamageCoeff = 0.838
+const penanceHealScale = 7.8
+const penanceHealVariance = 0.122
+const penanceHealCoeff = 0.838
+
+// Channels three bolts of holy light over 2 seconds, which damage an enemy or
+// heal an ally. Both versions share a cooldown.
+func (priest *Priest) RegisterPenanceSpells() {
+	cd := core.Cooldown{
+		Timer:    priest.NewTimer(),
+		Duration: time.Second * 9,
+	}
+
+	priest.Penance = priest.makePenanceSpell(false, cd)
+	priest.PenanceHeal = priest.makePenanceSpell(true, cd)
+}
+
+func (priest *Priest) makePenanceSpell(isHeal bool, cd core.Cooldown) *core.Spell {
+	actionID := core.ActionID{SpellID: 47540}
+	procMask := core.ProcMaskSpellDamage
+	flags := core.SpellFlagChanneled | core.SpellFlagAPL
+	if isHeal {
+		actionID = core.ActionID{SpellID: 47757}
+		procMask = core.ProcMaskSpellHealing
+		flags |= core.SpellFlagHelpful
+	}
+
+	config := core.DotConfig{
+		Aura: core.Aura{
+			Label: "Penance",
+		},
+		NumberOfTicks:       2,
+		TickLength:          time.Second,
+		AffectedByCastSpeed: true,
+
+		// Each bolt is a direct hit rather than a periodic effect.
+		OnTick: func(sim *core.Simulation, target *core.Unit, dot *core.Dot) {
+			if isHeal {
+				baseHealing := priest.CalcAndRollDamageRange(sim, penanceHealScale, penanceHealVariance)
+				dot.Spell.CalcAndDealHealing(sim, target, baseHealing, dot.Spell.OutcomeHealingCrit)
+			} else {
+				baseDamage := priest.CalcAndRollDamageRange(sim, penanceDamageScale, penanceDamageVariance)
+				dot.Spell.CalcAndDealDamage(sim, target, baseDamage, dot.Spell.OutcomeMagicHitAndCrit)
+			}
+		},
+	}
+
+	return priest.RegisterSpell(core.SpellConfig{
+		ActionID:       actionID,
+		SpellSchool:    core.SpellSchoolHoly,
+		ProcMask:       procMask,
+		Flags:          flags,
+		ClassSpellMask: PriestSpellPenance,
+
+		MaxRange: core.TernaryFloat64(isHeal, 40, 30),
+
+		ManaCost: core.ManaCostOptions{
+			BaseCostPercent: 3.1,
+		},
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.GCDDefault,
+			},
+			CD: cd,
+		},
+
+		DamageMultiplier: 1,
+		CritMultiplier:   priest.DefaultCritMultiplier(),
+		ThreatMultiplier: 1,
+		BonusCoefficient: core.TernaryFloat64(isHeal, penanceHealCoeff, penanceDamageCoeff),
+
+		Dot: core.Ternary(!isHeal, config, core.DotConfig{}),
+		Hot: core.Ternary(isHeal, config, core.DotConfig{}),
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			// The first bolt lands immediately.
+			if isHeal {
+				hot := spell.Hot(priest.HealTarget(target))
+				hot.Apply(sim)
+				hot.TickOnce(sim)
+			} else {
+				dot := spell.Dot(target)
+				dot.Apply(sim)
+				dot.TickOnce(sim)
+			}
+		},
+	})
+}
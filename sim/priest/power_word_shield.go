@@ -0,0 +1,89 @@
+package priest
+
+import (
+	"math"
+	"time"
+
+	"github.com/wowsims/mop/sim/core"
+	"github.com/wowsims/mop/sim/core/proto"
+)
+
+const powerWordShieldScale = 18.515
+const powerWordShieldCoeff = 1.871
+
+// Absorbs damage for 15 seconds. The target can't be shielded again until
+// Weakened Soul wears off.
+func (priest *Priest) RegisterPowerWordShieldSpell() {
+	priest.WeakenedSouls = priest.NewAllyAuraArray(func(target *core.Unit) *core.Aura {
+		return target.GetOrRegisterAura(core.Aura{
+			Label:    "Weakened Soul",
+			ActionID: core.ActionID{SpellID: 6788},
+			Duration: time.Second * 15,
+		})
+	})
+
+	var glyphHeal *core.Spell
+	if priest.HasMajorGlyph(proto.PriestMajorGlyph_GlyphOfPowerWordShield) {
+		glyphHeal = priest.RegisterSpell(core.SpellConfig{
+			ActionID:    core.ActionID{SpellID: 56160},
+			SpellSchool: core.SpellSchoolHoly,
+			ProcMask:    core.ProcMaskSpellHealing,
+			Flags:       core.SpellFlagHelpful | core.SpellFlagPassiveSpell | core.SpellFlagIgnoreModifiers,
+
+			DamageMultiplier: 1,
+			CritMultiplier:   priest.DefaultCritMultiplier(),
+			ThreatMultiplier: 1,
+		})
+	}
+
+	priest.PowerWordShield = priest.RegisterSpell(core.SpellConfig{
+		ActionID:       core.ActionID{SpellID: 17},
+		SpellSchool:    core.SpellSchoolHoly,
+		ProcMask:       core.ProcMaskSpellHealing,
+		Flags:          core.SpellFlagHelpful | core.SpellFlagAPL,
+		ClassSpellMask: PriestSpellPowerWordShield,
+
+		MaxRange: 40,
+
+		ManaCost: core.ManaCostOptions{
+			BaseCostPercent: 24.5,
+		},
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.GCDDefault,
+			},
+			CD: core.Cooldown{
+				Timer:    priest.NewTimer(),
+				Duration: time.Second * 6,
+			},
+		},
+		ExtraCastCondition: func(_ *core.Simulation, target *core.Unit) bool {
+			return !priest.WeakenedSouls.Get(priest.HealTarget(target)).IsActive()
+		},
+
+		DamageMultiplier: 1,
+		ThreatMultiplier: 1,
+
+		Shield: core.ShieldConfig{
+			Aura: core.Aura{
+				Label:     "Power Word: Shield",
+				Duration:  time.Second * 15,
+				MaxStacks: math.MaxInt32,
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			target = priest.HealTarget(target)
+			shieldAmount := priest.CalcScalingSpellDmg(powerWordShieldScale) + powerWordShieldCoeff*spell.HealingPower(target)
+
+			// The glyph turns 20% of the absorb into an instant heal.
+			if glyphHeal != nil {
+				glyphHeal.CalcAndDealHealing(sim, target, shieldAmount*spell.DamageMultiplier*0.2, glyphHeal.OutcomeHealing)
+				shieldAmount *= 0.8
+			}
+
+			spell.Shield(target).Apply(sim, shieldAmount)
+			priest.WeakenedSouls.Get(target).Activate(sim)
+		},
+	})
+}
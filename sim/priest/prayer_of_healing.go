@@ -0,0 +1,56 @@
+package priest
+
+import (
+	"time"
+
+	"github.com/wowsims/mop/sim/core"
+)
+
+const prayerOfHealingScale = 8.388
+const prayerOfHealingVariance = 0.055
+const prayerOfHealingCoeff = 0.838
+
+// Heals the target and the rest of its party.
+func (priest *Priest) registerPrayerOfHealingSpell() {
+	priest.PrayerOfHealing = priest.RegisterSpell(core.SpellConfig{
+		ActionID:       core.ActionID{SpellID: 596},
+		SpellSchool:    core.SpellSchoolHoly,
+		ProcMask:       core.ProcMaskSpellHealing,
+		Flags:          core.SpellFlagHelpful | core.SpellFlagAPL,
+		ClassSpellMask: PriestSpellPrayerOfHealing,
+
+		MaxRange: 40,
+
+		ManaCost: core.ManaCostOptions{
+			BaseCostPercent: 26.4,
+		},
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD:      core.GCDDefault,
+				CastTime: time.Millisecond * 2500,
+			},
+		},
+
+		DamageMultiplier: 1,
+		CritMultiplier:   priest.DefaultCritMultiplier(),
+		ThreatMultiplier: 1,
+		BonusCoefficient: prayerOfHealingCoeff,
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			target = priest.HealTarget(target)
+			targetAgent := target.Env.Raid.GetPlayerFromUnitIndex(target.UnitIndex)
+			if targetAgent == nil {
+				return
+			}
+
+			for _, partyAgent := range targetAgent.GetCharacter().Party.PlayersAndPets {
+				partyTarget := &partyAgent.GetCharacter().Unit
+				if !partyTarget.IsActive() {
+					continue
+				}
+				baseHealing := priest.CalcAndRollDamageRange(sim, prayerOfHealingScale, prayerOfHealingVariance)
+				priest.calcAndDealShellableHealing(sim, spell, partyTarget, baseHealing)
+			}
+		},
+	})
+}
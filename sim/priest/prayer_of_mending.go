@@ -0,0 +1,129 @@
+package priest
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/wowsims/mop/sim/core"
+)
+
+const prayerOfMendingScale = 5.96
+const prayerOfMendingCoeff = 0.571
+
+// Incoming damage is rarely modelled, so the prayer also triggers on its own
+// after this long.
+const prayerOfMendingAutoProcDelay = time.Second * 5
+
+func (priest *Priest) registerPrayerOfMendingSpell() {
+	maxJumps := 5
+
+	pomHeal := priest.RegisterSpell(core.SpellConfig{
+		ActionID:       core.ActionID{SpellID: 33110},
+		SpellSchool:    core.SpellSchoolHoly,
+		ProcMask:       core.ProcMaskSpellHealing,
+		Flags:          core.SpellFlagHelpful | core.SpellFlagPassiveSpell,
+		ClassSpellMask: PriestSpellPrayerOfMending,
+
+		DamageMultiplier: 1,
+		CritMultiplier:   priest.DefaultCritMultiplier(),
+		ThreatMultiplier: 1,
+		BonusCoefficient: prayerOfMendingCoeff,
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			spell.CalcAndDealHealing(sim, target, priest.CalcScalingSpellDmg(prayerOfMendingScale), spell.OutcomeHealingCrit)
+		},
+	})
+
+	var pomAuras core.AuraArray
+	var remainingJumps int
+	priest.ProcPrayerOfMending = func(sim *core.Simulation, target *core.Unit, _ *core.Spell) {
+		pomAuras.Get(target).Deactivate(sim)
+		pomHeal.Cast(sim, target)
+
+		if remainingJumps == 0 {
+			return
+		}
+
+		// Jump to the most injured ally other than the current target.
+		for _, newTarget := range priest.Env.Raid.GetLowestHealthAllyUnits(2) {
+			if newTarget != target {
+				remainingJumps--
+				pomAuras.Get(newTarget).Activate(sim)
+				return
+			}
+		}
+	}
+
+	pomAuras = priest.NewAllyAuraArray(func(unit *core.Unit) *core.Aura {
+		return priest.makePrayerOfMendingAura(unit)
+	})
+
+	priest.PrayerOfMending = priest.RegisterSpell(core.SpellConfig{
+		ActionID:       core.ActionID{SpellID: 33076},
+		SpellSchool:    core.SpellSchoolHoly,
+		ProcMask:       core.ProcMaskEmpty,
+		Flags:          core.SpellFlagHelpful | core.SpellFlagAPL,
+		ClassSpellMask: PriestSpellPrayerOfMending,
+
+		MaxRange: 40,
+
+		ManaCost: core.ManaCostOptions{
+			BaseCostPercent: 23.5,
+		},
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.GCDDefault,
+			},
+			CD: core.Cooldown{
+				Timer:    priest.NewTimer(),
+				Duration: time.Second * 10,
+			},
+		},
+
+		DamageMultiplier: 1,
+		ThreatMultiplier: 1,
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, _ *core.Spell) {
+			target = priest.HealTarget(target)
+			for _, aura := range pomAuras {
+				if aura != nil {
+					aura.Deactivate(sim)
+				}
+			}
+
+			remainingJumps = maxJumps - 1
+			pomAuras.Get(target).Activate(sim)
+		},
+	})
+}
+
+func (priest *Priest) makePrayerOfMendingAura(target *core.Unit) *core.Aura {
+	var autoProc *core.PendingAction
+
+	return target.RegisterAura(core.Aura{
+		Label:    "PrayerOfMending" + strconv.Itoa(int(priest.Index)),
+		ActionID: core.ActionID{SpellID: 41635},
+		Duration: time.Second * 30,
+		OnGain: func(aura *core.Aura, sim *core.Simulation) {
+			autoProc = core.NewDelayedAction(core.DelayedActionOptions{
+				DoAt: sim.CurrentTime + prayerOfMendingAutoProcDelay,
+				OnAction: func(sim *core.Simulation) {
+					autoProc = nil
+					priest.ProcPrayerOfMending(sim, aura.Unit, priest.PrayerOfMending)
+				},
+			})
+			sim.AddPendingAction(autoProc)
+		},
+		OnExpire: func(aura *core.Aura, sim *core.Simulation) {
+			if autoProc != nil {
+				autoProc.Cancel(sim)
+				autoProc = nil
+			}
+		},
+		OnSpellHitTaken: func(aura *core.Aura, sim *core.Simulation, spell *core.Spell, result *core.SpellResult) {
+			if result.Damage > 0 && !spell.Flags.Matches(core.SpellFlagHelpful) {
+				priest.ProcPrayerOfMending(sim, aura.Unit, priest.PrayerOfMending)
+			}
+		},
+	})
+}
@@ -37,6 +37,7 @@ type Priest struct {
 	CircleOfHealing   *core.Spell
 	FlashHeal         *core.Spell
 	GreaterHeal       *core.Spell
+	Heal              *core.Spell
 	Penance           *core.Spell
 	PenanceHeal       *core.Spell
 	PowerWordShield   *core.Spell
@@ -55,6 +56,11 @@ type Priest struct {
 
 	WeakenedSouls core.AuraArray
 
+	// Discipline only. While Spirit Shell is active, Heal, Flash Heal, Greater
+	// Heal and Prayer of Healing create absorbs instead of healing.
+	SpiritShellAura   *core.Aura
+	SpiritShellAbsorb func(sim *core.Simulation, result *core.SpellResult)
+
 	ProcPrayerOfMending      core.ApplySpellResults
 	T15_2PC_ExtensionTracker []TargetDoTInfo
 }
@@ -75,6 +81,10 @@ func (priest *Priest) GetCharacter() *core.Character {
 	return &priest.Character
 }
 
+func (priest *Priest) AddRaidMemberBuffs(raidBuffs *proto.RaidBuffs) {
+	raidBuffs.PowerWordFortitude = true
+}
+
 func (priest *Priest) AddPartyBuffs(_ *proto.PartyBuffs) {
 }
 
@@ -103,6 +113,39 @@ func (priest *Priest) Initialize() {
 	priest.T15_2PC_ExtensionTracker = make([]TargetDoTInfo, len(priest.Env.Encounter.AllTargets))
 }
 
+// Registers the heals shared by the healing specs.
+func (priest *Priest) RegisterHealingSpells() {
+	priest.registerHealSpell()
+	priest.registerFlashHealSpell()
+	priest.registerGreaterHealSpell()
+	priest.registerBindingHealSpell()
+	priest.registerRenewSpell()
+	priest.registerPrayerOfHealingSpell()
+	priest.registerPrayerOfMendingSpell()
+	priest.registerCircleOfHealingSpell()
+}
+
+// Deals a direct heal from one of the spells Spirit Shell converts, turning it
+// into an absorb while Spirit Shell is active.
+func (priest *Priest) calcAndDealShellableHealing(sim *core.Simulation, spell *core.Spell, target *core.Unit, baseHealing float64) {
+	if !priest.SpiritShellAura.IsActive() {
+		spell.CalcAndDealHealing(sim, target, baseHealing, spell.OutcomeHealingCrit)
+		return
+	}
+
+	result := spell.CalcHealing(sim, target, baseHealing, spell.OutcomeHealingCrit)
+	priest.SpiritShellAbsorb(sim, result)
+}
+
+// Helpful spells cast at an enemy, such as the default APL target, land on the
+// priest instead.
+func (priest *Priest) HealTarget(target *core.Unit) *core.Unit {
+	if target == nil || target.IsOpponent(&priest.Unit) {
+		return &priest.Unit
+	}
+	return target
+}
+
 func (priest *Priest) AddHolyEvanglismStack(sim *core.Simulation) {
 	if priest.HolyEvangelismProcAura != nil {
 		priest.HolyEvangelismProcAura.Activate(sim)
@@ -217,6 +260,11 @@ const (
 	PriestSpellSmite
 	PriestSpellVampiricEmbrace
 	PriestSpellVampiricTouch
+	PriestSpellHeal
+	PriestSpellEchoOfLight
+	PriestSpellLightwell
+	PriestSpellAtonement
+	PriestSpellSpiritShell
 
 	PriestSpellLast
 	PriestSpellsAll    = PriestSpellLast<<1 - 1
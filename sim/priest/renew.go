@@ -0,0 +1,64 @@
+package priest
+
+import (
+	"time"
+
+	"github.com/wowsims/mop/sim/core"
+	"github.com/wowsims/mop/sim/core/proto"
+)
+
+const renewScale = 2.573
+const renewCoeff = 0.207
+
+func (priest *Priest) registerRenewSpell() {
+	hasGlyph := priest.HasMajorGlyph(proto.PriestMajorGlyph_GlyphOfRenew)
+
+	priest.Renew = priest.RegisterSpell(core.SpellConfig{
+		ActionID:       core.ActionID{SpellID: 139},
+		SpellSchool:    core.SpellSchoolHoly,
+		ProcMask:       core.ProcMaskSpellHealing,
+		Flags:          core.SpellFlagHelpful | core.SpellFlagAPL,
+		ClassSpellMask: PriestSpellRenew,
+
+		MaxRange: 40,
+
+		ManaCost: core.ManaCostOptions{
+			BaseCostPercent: 26,
+		},
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.GCDDefault,
+			},
+		},
+
+		DamageMultiplier: core.TernaryFloat64(hasGlyph, 1.33, 1),
+		CritMultiplier:   priest.DefaultCritMultiplier(),
+		ThreatMultiplier: 1,
+
+		Hot: core.DotConfig{
+			Aura: core.Aura{
+				Label: "Renew",
+			},
+			NumberOfTicks:       core.TernaryInt32(hasGlyph, 3, 4),
+			TickLength:          time.Second * 3,
+			AffectedByCastSpeed: true,
+			BonusCoefficient:    renewCoeff,
+
+			OnSnapshot: func(_ *core.Simulation, target *core.Unit, dot *core.Dot, _ bool) {
+				dot.SnapshotHeal(target, priest.CalcScalingSpellDmg(renewScale))
+			},
+			OnTick: func(sim *core.Simulation, target *core.Unit, dot *core.Dot) {
+				dot.CalcAndDealPeriodicSnapshotHealing(sim, target, dot.OutcomeSnapshotCrit)
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			target = priest.HealTarget(target)
+			spell.Hot(target).Apply(sim)
+
+			if priest.EmpoweredRenew != nil {
+				priest.EmpoweredRenew.Cast(sim, target)
+			}
+		},
+	})
+}
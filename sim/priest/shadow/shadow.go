@@ -67,6 +67,12 @@ func (spriest *ShadowPriest) GetPriest() *priest.Priest {
 	return spriest.Priest
 }
 
+func (spriest *ShadowPriest) AddRaidMemberBuffs(raidBuffs *proto.RaidBuffs) {
+	spriest.Priest.AddRaidMemberBuffs(raidBuffs)
+	// Shadowform provides the same spell haste buff.
+	raidBuffs.MindQuickening = true
+}
+
 func (spriest *ShadowPriest) Initialize() {
 	spriest.Priest.Initialize()
 
@@ -0,0 +1,43 @@
+package priest
+
+import (
+	"time"
+
+	"github.com/wowsims/mop/sim/core"
+)
+
+const smiteScale = 2.235
+const smiteVariance = 0.115
+const smiteCoeff = 0.856
+
+func (priest *Priest) RegisterSmiteSpell() {
+	priest.Smite = priest.RegisterSpell(core.SpellConfig{
+		ActionID:       core.ActionID{SpellID: 585},
+		SpellSchool:    core.SpellSchoolHoly,
+		ProcMask:       core.ProcMaskSpellDamage,
+		Flags:          core.SpellFlagAPL,
+		ClassSpellMask: PriestSpellSmite,
+
+		MaxRange: 30,
+
+		ManaCost: core.ManaCostOptions{
+			BaseCostPercent: 1.5,
+		},
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD:      core.GCDDefault,
+				CastTime: time.Millisecond * 1500,
+			},
+		},
+
+		DamageMultiplier: 1,
+		CritMultiplier:   priest.DefaultCritMultiplier(),
+		ThreatMultiplier: 1,
+		BonusCoefficient: smiteCoeff,
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			baseDamage := priest.CalcAndRollDamageRange(sim, smiteScale, smiteVariance)
+			spell.CalcAndDealDamage(sim, target, baseDamage, spell.OutcomeMagicHitAndCrit)
+		},
+	})
+}
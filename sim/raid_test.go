@@ -1,8 +1,11 @@
 package sim
 
 import (
+	"math"
 	"testing"
 
+	googleProto "google.golang.org/protobuf/proto"
+
 	"github.com/wowsims/mop/sim/core"
 	"github.com/wowsims/mop/sim/core/proto"
 	"github.com/wowsims/mop/sim/core/stats"
@@ -100,3 +103,563 @@ func TestFixedRaid(t *testing.T) {
  	`)
 }
 */
+
+func TestBuffUptimes(t *testing.T) {
+	player := &proto.Player{
+		Name:      "Holy",
+		Race:      proto.Race_RaceBloodElf,
+		Class:     proto.Class_ClassPaladin,
+		Equipment: &proto.EquipmentSpec{},
+		Spec: &proto.Player_HolyPaladin{
+			HolyPaladin: &proto.HolyPaladin{
+				Options: &proto.HolyPaladin_Options{
+					ClassOptions: &proto.PaladinOptions{},
+				},
+			},
+		},
+		Buffs: &proto.IndividualBuffs{},
+	}
+	raidBuffs := &proto.RaidBuffs{
+		BlessingOfKings: true,
+		Uptimes: []*proto.BuffUptime{
+			{SpellId: 20217, Schedule: "0s-1m, 2m-"},
+		},
+	}
+	debuffs := &proto.Debuffs{
+		WeakenedArmor:         true,
+		PhysicalVulnerability: true,
+		Uptimes: []*proto.BuffUptime{
+			{SpellId: 113746, Schedule: "1m-"},
+			{SpellId: 81326, Uptime: googleProto.Float64(0.5)},
+		},
+	}
+
+	result := core.RunRaidSim(&proto.RaidSimRequest{
+		Raid: core.SinglePlayerRaidProto(player, &proto.PartyBuffs{}, raidBuffs, debuffs),
+		Encounter: &proto.Encounter{
+			Duration: 300,
+			Targets:  []*proto.Target{StandardTarget},
+		},
+		SimOptions: &proto.SimOptions{
+			Iterations: 50,
+			RandomSeed: 101,
+		},
+	})
+	if result.Error != nil {
+		t.Fatal(result.Error.Message)
+	}
+
+	auraUptime := func(auras []*proto.AuraMetrics, spellID int32) float64 {
+		for _, aura := range auras {
+			if aura.Id.GetSpellId() == spellID {
+				return aura.UptimeSecondsAvg
+			}
+		}
+		return 0
+	}
+
+	if uptime := auraUptime(result.RaidMetrics.Parties[0].Players[0].Auras, 20217); uptime < 239 || uptime > 241 {
+		t.Errorf("Expected Blessing of Kings to be up for 240s, got %0.1fs", uptime)
+	}
+	targetAuras := result.EncounterMetrics.Targets[0].Auras
+	if uptime := auraUptime(targetAuras, 113746); uptime < 239 || uptime > 241 {
+		t.Errorf("Expected Weakened Armor to be up for 240s, got %0.1fs", uptime)
+	}
+	if uptime := auraUptime(targetAuras, 81326); uptime < 120 || uptime > 180 {
+		t.Errorf("Expected Physical Vulnerability to be up for about 150s, got %0.1fs", uptime)
+	}
+}
+
+func TestExternalCooldownSchedules(t *testing.T) {
+	player := &proto.Player{
+		Name:      "Holy",
+		Race:      proto.Race_RaceBloodElf,
+		Class:     proto.Class_ClassPaladin,
+		Equipment: &proto.EquipmentSpec{},
+		Rotation: core.APLRotationFromJsonString(`{
+			"type": "TypeAPL",
+			"priorityList": [
+				{"action":{"autocastOtherCooldowns":{}}},
+				{"action":{
+					"condition":{"cmp":{"op":"OpLe","lhs":{"externalBuffTimeToNext":{"spellId":{"spellId":10060,"tag":-1}}},"rhs":{"const":{"val":"0s"}}}},
+					"castSpell":{"spellId":{"spellId":54428}}
+				}},
+				{"action":{"castFriendlySpell":{"spellId":{"spellId":635},"target":{"type":"Self"}}}}
+			]
+		}`),
+		Spec: &proto.Player_HolyPaladin{
+			HolyPaladin: &proto.HolyPaladin{
+				Options: &proto.HolyPaladin_Options{
+					ClassOptions: &proto.PaladinOptions{},
+				},
+			},
+		},
+		Buffs: &proto.IndividualBuffs{
+			PowerInfusionCount: 1,
+			UnholyFrenzyCount:  1,
+			ExternalCooldownSchedules: []*proto.ExternalCooldownSchedule{
+				{SpellId: 10060, Timings: []float64{30, 200}},
+				{SpellId: 49016, AlignWith: &proto.ActionID{RawId: &proto.ActionID_SpellId{SpellId: 31884}}},
+			},
+		},
+	}
+
+	result := core.RunRaidSim(&proto.RaidSimRequest{
+		Raid: core.SinglePlayerRaidProto(player, &proto.PartyBuffs{}, &proto.RaidBuffs{}, &proto.Debuffs{}),
+		Encounter: &proto.Encounter{
+			Duration: 300,
+			Targets:  []*proto.Target{StandardTarget},
+		},
+		SimOptions: &proto.SimOptions{
+			Iterations: 10,
+			RandomSeed: 101,
+		},
+	})
+	if result.Error != nil {
+		t.Fatal(result.Error.Message)
+	}
+
+	uptimes := map[int32]float64{}
+	for _, aura := range result.RaidMetrics.Parties[0].Players[0].Auras {
+		uptimes[aura.Id.GetSpellId()] += aura.UptimeSecondsAvg
+	}
+
+	if uptime := uptimes[10060]; uptime < 39 || uptime > 41 {
+		t.Errorf("Expected Power Infusion to be up for 40s, got %0.1fs", uptime)
+	}
+	// Divine Plea is only cast while Power Infusion is active.
+	if uptime := uptimes[54428]; uptime < 17 || uptime > 19 {
+		t.Errorf("Expected Divine Plea to be used during each Power Infusion, got %0.1fs", uptime)
+	}
+	if uptimes[31884] <= 0 {
+		t.Fatalf("Expected Avenging Wrath to be used")
+	}
+	if uptime := uptimes[49016]; uptime < 1.4*uptimes[31884] || uptime > 1.6*uptimes[31884] {
+		t.Errorf("Expected Unholy Frenzy to be used with each Avenging Wrath, got %0.1fs for %0.1fs", uptime, uptimes[31884])
+	}
+}
+
+var raidBonusStats = &proto.UnitStats{
+	Stats: stats.Stats{
+		stats.Stamina:         20000,
+		stats.Strength:        15000,
+		stats.Agility:         15000,
+		stats.Intellect:       15000,
+		stats.Spirit:          5000,
+		stats.HitRating:       2550,
+		stats.ExpertiseRating: 2550,
+		stats.CritRating:      5000,
+		stats.HasteRating:     5000,
+		stats.MasteryRating:   5000,
+	}.ToProtoArray(),
+}
+
+// A 10-man comp of 1 tank, 2 healers and 7 dps, with no gear. Parties are ordered
+// so that the rogue's Tricks of the Trade goes to the feral druid.
+func tenManRaid() *proto.Raid {
+	return &proto.Raid{
+		Parties: []*proto.Party{
+			{
+				Players: []*proto.Player{
+					{
+						Name:          "Rogue",
+						Race:          proto.Race_RaceHuman,
+						Class:         proto.Class_ClassRogue,
+						Equipment:     &proto.EquipmentSpec{},
+						TalentsString: "321233",
+						Rotation:      core.GetAplRotation("../ui/rogue/combat/apls", "combat").Rotation,
+						Spec: &proto.Player_CombatRogue{
+							CombatRogue: &proto.CombatRogue{
+								Options: &proto.CombatRogue_Options{
+									ClassOptions: &proto.RogueOptions{
+										LethalPoison: proto.RogueOptions_DeadlyPoison,
+									},
+								},
+							},
+						},
+						BonusStats: raidBonusStats,
+					},
+					{
+						Name:          "Feral",
+						Race:          proto.Race_RaceWorgen,
+						Class:         proto.Class_ClassDruid,
+						Equipment:     &proto.EquipmentSpec{},
+						TalentsString: "100302",
+						Rotation:      core.GetAplRotation("../ui/druid/feral/apls", "default").Rotation,
+						Spec: &proto.Player_FeralDruid{
+							FeralDruid: &proto.FeralDruid{
+								Options: &proto.FeralDruid_Options{
+									AssumeBleedActive: true,
+								},
+							},
+						},
+						BonusStats: raidBonusStats,
+					},
+					{
+						Name:          "Death Knight",
+						Race:          proto.Race_RaceTroll,
+						Class:         proto.Class_ClassDeathKnight,
+						Equipment:     &proto.EquipmentSpec{},
+						TalentsString: "300010",
+						Rotation:      core.GetAplRotation("../ui/death_knight/unholy/apls", "default").Rotation,
+						Spec: &proto.Player_UnholyDeathKnight{
+							UnholyDeathKnight: &proto.UnholyDeathKnight{
+								Options: &proto.UnholyDeathKnight_Options{
+									ClassOptions: &proto.DeathKnightOptions{},
+								},
+							},
+						},
+						BonusStats: raidBonusStats,
+					},
+					{
+						Name:          "Warrior",
+						Race:          proto.Race_RaceOrc,
+						Class:         proto.Class_ClassWarrior,
+						Equipment:     &proto.EquipmentSpec{},
+						TalentsString: "213332",
+						Rotation:      core.GetAplRotation("../ui/warrior/protection/apls", "default").Rotation,
+						Spec: &proto.Player_ProtectionWarrior{
+							ProtectionWarrior: &proto.ProtectionWarrior{
+								Options: &proto.ProtectionWarrior_Options{
+									ClassOptions: &proto.WarriorOptions{},
+								},
+							},
+						},
+						BonusStats: raidBonusStats,
+					},
+					{
+						Name:          "Paladin",
+						Race:          proto.Race_RaceBloodElf,
+						Class:         proto.Class_ClassPaladin,
+						Equipment:     &proto.EquipmentSpec{},
+						TalentsString: "112211",
+						Rotation:      core.GetAplRotation("../ui/paladin/holy/apls", "default").Rotation,
+						Spec: &proto.Player_HolyPaladin{
+							HolyPaladin: &proto.HolyPaladin{
+								Options: &proto.HolyPaladin_Options{
+									ClassOptions: &proto.PaladinOptions{
+										Seal: proto.PaladinSeal_Insight,
+									},
+								},
+							},
+						},
+						BonusStats: raidBonusStats,
+					},
+				},
+			},
+			{
+				Players: []*proto.Player{
+					{
+						Name:          "Priest",
+						Race:          proto.Race_RaceTroll,
+						Class:         proto.Class_ClassPriest,
+						Equipment:     &proto.EquipmentSpec{},
+						TalentsString: "223113",
+						Rotation:      core.GetAplRotation("../ui/priest/shadow/apls", "t15").Rotation,
+						Spec: &proto.Player_ShadowPriest{
+							ShadowPriest: &proto.ShadowPriest{
+								Options: &proto.ShadowPriest_Options{
+									ClassOptions: &proto.PriestOptions{
+										Armor: proto.PriestOptions_InnerFire,
+									},
+								},
+							},
+						},
+						BonusStats: raidBonusStats,
+					},
+					{
+						Name:          "Mage",
+						Race:          proto.Race_RaceTroll,
+						Class:         proto.Class_ClassMage,
+						Equipment:     &proto.EquipmentSpec{},
+						TalentsString: "311122",
+						Rotation:      core.GetAplRotation("../ui/mage/arcane/apls", "arcane_t15_4pc").Rotation,
+						Spec: &proto.Player_ArcaneMage{
+							ArcaneMage: &proto.ArcaneMage{
+								Options: &proto.ArcaneMage_Options{
+									ClassOptions: &proto.MageOptions{
+										DefaultMageArmor: proto.MageArmor_MageArmorFrostArmor,
+									},
+								},
+							},
+						},
+						BonusStats: raidBonusStats,
+					},
+					{
+						Name:          "Warlock",
+						Race:          proto.Race_RaceOrc,
+						Class:         proto.Class_ClassWarlock,
+						Equipment:     &proto.EquipmentSpec{},
+						TalentsString: "231211",
+						Rotation:      core.GetAplRotation("../ui/warlock/affliction/apls", "default").Rotation,
+						Spec: &proto.Player_AfflictionWarlock{
+							AfflictionWarlock: &proto.AfflictionWarlock{
+								Options: &proto.AfflictionWarlock_Options{
+									ClassOptions: &proto.WarlockOptions{
+										Summon: proto.WarlockOptions_Felhunter,
+									},
+								},
+							},
+						},
+						BonusStats: raidBonusStats,
+					},
+					{
+						Name:          "Balance",
+						Race:          proto.Race_RaceTroll,
+						Class:         proto.Class_ClassDruid,
+						Equipment:     &proto.EquipmentSpec{},
+						TalentsString: "113222",
+						Rotation:      core.GetAplRotation("../ui/druid/balance/apls", "standard").Rotation,
+						Spec: &proto.Player_BalanceDruid{
+							BalanceDruid: &proto.BalanceDruid{
+								Options: &proto.BalanceDruid_Options{
+									ClassOptions: &proto.DruidOptions{},
+								},
+							},
+						},
+						BonusStats: raidBonusStats,
+					},
+					{
+						Name:      "Shaman",
+						Race:      proto.Race_RaceTroll,
+						Class:     proto.Class_ClassShaman,
+						Equipment: &proto.EquipmentSpec{},
+						Rotation:  core.GetAplRotation("../ui/shaman/restoration/apls", "default").Rotation,
+						Spec: &proto.Player_RestorationShaman{
+							RestorationShaman: &proto.RestorationShaman{
+								Options: &proto.RestorationShaman_Options{
+									ClassOptions: &proto.ShamanOptions{
+										Shield: proto.ShamanShield_WaterShield,
+									},
+								},
+							},
+						},
+						BonusStats: raidBonusStats,
+					},
+				},
+			},
+		},
+		Tanks: []*proto.UnitReference{
+			{Type: proto.UnitReference_Player, Index: 3},
+		},
+		Buffs: &proto.RaidBuffs{
+			BlessingOfKings: true,
+			BlessingOfMight: true,
+			Bloodlust:       true,
+		},
+	}
+}
+
+func tenManRaidSimRequest() *proto.RaidSimRequest {
+	return &proto.RaidSimRequest{
+		Raid: tenManRaid(),
+		Encounter: &proto.Encounter{
+			Duration: 180,
+			Targets:  []*proto.Target{core.NewDefaultTarget()},
+		},
+		SimOptions: &proto.SimOptions{
+			Iterations: 20,
+			RandomSeed: 101,
+			IsTest:     true,
+		},
+	}
+}
+
+// The ten man raid without any buff or debuff settings, so that every buff and
+// debuff comes from the raid composition.
+func derivedBuffsRaidSimRequest() *proto.RaidSimRequest {
+	request := tenManRaidSimRequest()
+	request.Raid.Buffs = &proto.RaidBuffs{}
+	request.Raid.Debuffs = &proto.Debuffs{}
+	return request
+}
+
+func TestRaid(t *testing.T) {
+	core.RunRaidTestSuite(t, t.Name(), []core.RaidTestCase{
+		{Name: "TenMan", Request: tenManRaidSimRequest()},
+		{Name: "TenManDerivedBuffs", Request: derivedBuffsRaidSimRequest()},
+	})
+}
+
+func TestRaidDerivedBuffs(t *testing.T) {
+	result := core.RunRaidSim(derivedBuffsRaidSimRequest())
+	if result.Error != nil {
+		t.Fatal(result.Error.Message)
+	}
+
+	hasAura := func(unit *proto.UnitMetrics, spellID int32) bool {
+		for _, aura := range unit.Auras {
+			if aura.Id.GetSpellId() == spellID && aura.UptimeSecondsAvg > 0 {
+				return true
+			}
+		}
+		return false
+	}
+
+	buffs := []struct {
+		name    string
+		spellID int32
+	}{
+		{"Blessing of Might", 19740},     // Paladin, as Mark of the Wild already provides stats
+		{"Mark of the Wild", 1126},       // Druids
+		{"Moonkin Aura", 24907},          // Balance Druid
+		{"Power Word: Fortitude", 21562}, // Priest
+		{"Mind Quickening", 49868},       // Shadow Priest
+		{"Swiftblade's Cunning", 113742}, // Rogue
+		{"Dark Intent", 109773},          // Warlock
+		{"Arcane Brilliance", 1459},      // Mage
+		{"Grace of Air", 116956},         // Shaman
+	}
+	for _, party := range result.RaidMetrics.Parties {
+		for _, player := range party.Players {
+			for _, buff := range buffs {
+				if !hasAura(player, buff.spellID) {
+					t.Errorf("Expected %s to have %s", player.Name, buff.name)
+				}
+			}
+		}
+	}
+
+	// Other debuffs come from the players' own spells, e.g. rogue poisons.
+	debuffs := []struct {
+		name    string
+		spellID int32
+	}{
+		{"Weakened Blows", 115798}, // Shaman
+		{"Slow", 31589},            // Arcane Mage
+	}
+	target := result.EncounterMetrics.Targets[0]
+	for _, debuff := range debuffs {
+		if !hasAura(target, debuff.spellID) {
+			t.Errorf("Expected %s on the target", debuff.name)
+		}
+	}
+	if hasAura(target, 115804) {
+		t.Errorf("Expected no Mortal Wounds from a Protection Warrior")
+	}
+}
+
+func TestRaidClassInteractions(t *testing.T) {
+	// The default combat APL leaves Tricks of the Trade disabled.
+	request := tenManRaidSimRequest()
+	rogueRotation := request.Raid.Parties[0].Players[0].Rotation
+	rogueRotation.PriorityList = append([]*proto.APLListItem{{
+		Action: &proto.APLAction{
+			Action: &proto.APLAction_CastSpell{CastSpell: &proto.APLActionCastSpell{
+				SpellId: core.ActionID{SpellID: 57934}.ToProto(),
+			}},
+		},
+	}}, rogueRotation.PriorityList...)
+
+	result := core.RunRaidSim(request)
+	if result.Error != nil {
+		t.Fatal(result.Error.Message)
+	}
+
+	hasAura := func(unit *proto.UnitMetrics, spellID int32) bool {
+		for _, aura := range unit.Auras {
+			if aura.Id.GetSpellId() == spellID && aura.UptimeSecondsAvg > 0 {
+				return true
+			}
+		}
+		return false
+	}
+
+	var dpsShare float64
+	for _, party := range result.RaidMetrics.Parties {
+		for _, player := range party.Players {
+			dpsShare += player.RaidDpsShare
+
+			// Dark Intent and Swiftblade's Cunning come from the warlock and rogue.
+			if !hasAura(player, 109773) {
+				t.Errorf("Expected %s to have Dark Intent", player.Name)
+			}
+			if !hasAura(player, 113742) {
+				t.Errorf("Expected %s to have Swiftblade's Cunning", player.Name)
+			}
+		}
+	}
+	if dpsShare < 0.999 || dpsShare > 1.001 {
+		t.Errorf("Expected player DPS shares to add up to 1, got %0.3f", dpsShare)
+	}
+
+	// Dark Intent is cast once per iteration by the warlock, before the pull.
+	warlock := result.RaidMetrics.Parties[1].Players[2]
+	var darkIntentCasts int32
+	for _, action := range warlock.Actions {
+		if action.Id.GetSpellId() == 109773 {
+			for _, target := range action.Targets {
+				darkIntentCasts += target.Casts
+			}
+		}
+	}
+	if expected := request.SimOptions.Iterations; darkIntentCasts != expected {
+		t.Errorf("Expected %d Dark Intent casts but got %d", expected, darkIntentCasts)
+	}
+
+	feral := result.RaidMetrics.Parties[0].Players[1]
+	if !hasAura(feral, 57933) {
+		t.Errorf("Expected the rogue to cast Tricks of the Trade on the feral druid")
+	}
+
+	// Mortal Wounds is not provided by anyone in the comp, but Weakened Blows and
+	// Slow come from the shaman and mage.
+	target := result.EncounterMetrics.Targets[0]
+	if !hasAura(target, 115798) || !hasAura(target, 31589) {
+		t.Errorf("Expected Weakened Blows and Slow on the target")
+	}
+	if hasAura(target, 115804) {
+		t.Errorf("Expected no Mortal Wounds on the target")
+	}
+}
+
+func TestIndividualSimIgnoresRaidComposition(t *testing.T) {
+	// The warlock and shaman would provide Dark Intent and Weakened Blows in a raid.
+	for _, player := range []*proto.Player{tenManRaid().Parties[1].Players[2], tenManRaid().Parties[1].Players[4]} {
+		request := tenManRaidSimRequest()
+		request.Raid = core.SinglePlayerRaidProto(player, &proto.PartyBuffs{}, &proto.RaidBuffs{}, &proto.Debuffs{})
+
+		result := core.RunRaidSim(request)
+		if result.Error != nil {
+			t.Fatal(result.Error.Message)
+		}
+
+		for _, aura := range result.RaidMetrics.Parties[0].Players[0].Auras {
+			if aura.Id.GetSpellId() == 109773 {
+				t.Errorf("Expected no Dark Intent in an individual %s sim", player.Name)
+			}
+		}
+		for _, aura := range result.EncounterMetrics.Targets[0].Auras {
+			if aura.Id.GetSpellId() == 115798 {
+				t.Errorf("Expected no Weakened Blows in an individual %s sim", player.Name)
+			}
+		}
+	}
+}
+
+func TestRaidTargetPrecision(t *testing.T) {
+	request := tenManRaidSimRequest()
+	request.SimOptions.Iterations = 100
+	request.SimOptions.MinIterations = 10
+	request.SimOptions.TargetPrecision = 0.05
+
+	result := core.RunRaidSim(request)
+	if result.Error != nil {
+		t.Fatal(result.Error.Message)
+	}
+	if result.IterationsDone != request.SimOptions.MinIterations {
+		t.Fatalf("Expected the sim to stop after %d iterations, but ran %d", request.SimOptions.MinIterations, result.IterationsDone)
+	}
+
+	// The concurrent sim should stop at the same point, with the same results.
+	concurrentResult := core.RunRaidSimConcurrent(request)
+	if concurrentResult.Error != nil {
+		t.Fatal(concurrentResult.Error.Message)
+	}
+	if concurrentResult.IterationsDone != result.IterationsDone {
+		t.Fatalf("Concurrent sim ran %d iterations, expected %d", concurrentResult.IterationsDone, result.IterationsDone)
+	}
+	if math.Abs(concurrentResult.RaidMetrics.Dps.Avg-result.RaidMetrics.Dps.Avg) > 0.001 {
+		t.Fatalf("Concurrent sim DPS %0.3f does not match %0.3f", concurrentResult.RaidMetrics.Dps.Avg, result.RaidMetrics.Dps.Avg)
+	}
+}
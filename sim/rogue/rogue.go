@@ -122,6 +122,10 @@ func (rogue *Rogue) GetRogue() *Rogue {
 func (rogue *Rogue) AddRaidBuffs(_ *proto.RaidBuffs)   {}
 func (rogue *Rogue) AddPartyBuffs(_ *proto.PartyBuffs) {}
 
+func (rogue *Rogue) AddRaidMemberBuffs(raidBuffs *proto.RaidBuffs) {
+	raidBuffs.SwiftbladesCunning = true
+}
+
 func (rogue *Rogue) AddComboPointsOrAnticipation(sim *core.Simulation, numPoints int32, target *core.Unit, metric *core.ResourceMetrics) {
 	if rogue.Talents.Anticipation && rogue.ComboPoints()+numPoints > 5 {
 		realPoints := 5 - rogue.ComboPoints()
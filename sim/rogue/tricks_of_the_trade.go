@@ -15,6 +15,8 @@ func (rogue *Rogue) registerTricksOfTheTradeSpell() {
 	var tottTarget *core.Unit
 	if rogue.Options.TricksOfTheTradeTarget != nil {
 		tottTarget = rogue.GetUnit(rogue.Options.TricksOfTheTradeTarget)
+	} else {
+		tottTarget = rogue.defaultTricksOfTheTradeTarget()
 	}
 
 	tricksOfTheTradeThreatTransferAura := rogue.GetOrRegisterAura(core.Aura{
@@ -96,3 +98,20 @@ func (rogue *Rogue) registerTricksOfTheTradeSpell() {
 		Type:  core.CooldownTypeDPS,
 	})
 }
+
+// In raid sims without an explicit target, Tricks goes to the first other
+// player in the rogue's party, or otherwise the first other player in the raid.
+func (rogue *Rogue) defaultTricksOfTheTradeTarget() *core.Unit {
+	parties := append([]*core.Party{rogue.Party}, rogue.Env.Raid.Parties...)
+	for _, party := range parties {
+		for _, player := range party.Players {
+			if _, isDummy := player.(*core.TargetDummy); isDummy {
+				continue
+			}
+			if unit := &player.GetCharacter().Unit; unit != &rogue.Unit {
+				return unit
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package shaman
+
+import (
+	"time"
+
+	"github.com/wowsims/mop/sim/core"
+	"github.com/wowsims/mop/sim/core/proto"
+)
+
+// Helpful spells cast at an enemy, such as the default APL target, land on the
+// shaman instead.
+func (shaman *Shaman) HealTarget(target *core.Unit) *core.Unit {
+	if target == nil || target.IsOpponent(&shaman.Unit) {
+		return &shaman.Unit
+	}
+	return target
+}
+
+func (shaman *Shaman) newDirectHealSpellConfig(spellID int32, classSpellMask int64, baseCostPercent float64, castTime time.Duration, coeff float64) core.SpellConfig {
+	return core.SpellConfig{
+		ActionID:       core.ActionID{SpellID: spellID},
+		SpellSchool:    core.SpellSchoolNature,
+		ProcMask:       core.ProcMaskSpellHealing,
+		Flags:          core.SpellFlagHelpful | core.SpellFlagAPL | SpellFlagShamanSpell,
+		ClassSpellMask: classSpellMask,
+
+		MaxRange: 40,
+
+		ManaCost: core.ManaCostOptions{
+			BaseCostPercent: baseCostPercent,
+		},
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD:      core.GCDDefault,
+				CastTime: castTime,
+			},
+		},
+
+		DamageMultiplier: 1,
+		CritMultiplier:   shaman.DefaultCritMultiplier(),
+		ThreatMultiplier: 1,
+		BonusCoefficient: coeff,
+	}
+}
+
+func (shaman *Shaman) registerHealingSurgeSpell() {
+	config := shaman.newDirectHealSpellConfig(8004, SpellMaskHealingSurge, 20.7, time.Millisecond*1500, 1.135)
+	config.ApplyEffects = func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+		baseHealing := shaman.CalcAndRollDamageRange(sim, 11.4, 0.15)
+		spell.CalcAndDealHealing(sim, shaman.HealTarget(target), baseHealing, spell.OutcomeHealingCrit)
+	}
+	shaman.HealingSurge = shaman.RegisterSpell(config)
+}
+
+func (shaman *Shaman) registerHealingWaveSpell() {
+	config := shaman.newDirectHealSpellConfig(331, SpellMaskHealingWave, 9.2, time.Millisecond*2500, 0.756)
+	config.ApplyEffects = func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+		baseHealing := shaman.CalcAndRollDamageRange(sim, 8.4, 0.15)
+		spell.CalcAndDealHealing(sim, shaman.HealTarget(target), baseHealing, spell.OutcomeHealingCrit)
+	}
+	shaman.HealingWave = shaman.RegisterSpell(config)
+}
+
+func (shaman *Shaman) registerGreaterHealingWaveSpell() {
+	config := shaman.newDirectHealSpellConfig(77472, SpellMaskGreaterHealingWave, 27.1, time.Millisecond*2500, 1.377)
+	config.ApplyEffects = func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+		baseHealing := shaman.CalcAndRollDamageRange(sim, 15.3, 0.15)
+		spell.CalcAndDealHealing(sim, shaman.HealTarget(target), baseHealing, spell.OutcomeHealingCrit)
+	}
+	shaman.GreaterHealingWave = shaman.RegisterSpell(config)
+}
+
+// Heals the target, then jumps to the most injured allies which haven't been
+// healed yet. Targets with Riptide are healed for 25% more.
+func (shaman *Shaman) registerChainHealSpell() {
+	numHits := core.TernaryInt(shaman.HasMajorGlyph(proto.ShamanMajorGlyph_GlyphOfChaining), 5, 4)
+
+	config := shaman.newDirectHealSpellConfig(1064, SpellMaskChainHeal, 25.8, time.Millisecond*2500, 0.6876)
+	config.ApplyEffects = func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+		target = shaman.HealTarget(target)
+		targets := []*core.Unit{target}
+		for _, unit := range sim.Environment.Raid.GetLowestHealthAllyUnits(numHits) {
+			if len(targets) == numHits {
+				break
+			}
+			if unit != target {
+				targets = append(targets, unit)
+			}
+		}
+
+		for _, hitTarget := range targets {
+			riptideBonus := core.TernaryFloat64(shaman.Riptide != nil && shaman.Riptide.Hot(hitTarget).IsActive(), 1.25, 1)
+			spell.DamageMultiplier *= riptideBonus
+			baseHealing := shaman.CalcAndRollDamageRange(sim, 6.0, 0.15)
+			spell.CalcAndDealHealing(sim, hitTarget, baseHealing, spell.OutcomeHealingCrit)
+			spell.DamageMultiplier /= riptideBonus
+		}
+	}
+	shaman.ChainHeal = shaman.RegisterSpell(config)
+}
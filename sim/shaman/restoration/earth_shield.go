@@ -0,0 +1,124 @@
+package restoration
+
+import (
+	"time"
+
+	"github.com/wowsims/mop/sim/core"
+	"github.com/wowsims/mop/sim/shaman"
+)
+
+const earthShieldCharges = 9
+
+// Earth Shield heals the target when it takes damage, at most once every 3.5
+// seconds, until its charges run out. The target also receives 20% more healing
+// from the shaman. Without incoming damage, charges are consumed at the
+// configured procs per minute instead.
+func (resto *RestorationShaman) registerEarthShieldSpell() {
+	icd := core.Cooldown{
+		Timer:    resto.NewTimer(),
+		Duration: time.Millisecond * 3500,
+	}
+
+	earthShieldHeal := resto.RegisterSpell(core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: 379},
+		SpellSchool:      core.SpellSchoolNature,
+		ProcMask:         core.ProcMaskSpellHealing,
+		Flags:            core.SpellFlagHelpful | core.SpellFlagNoOnCastComplete | core.SpellFlagPassiveSpell | shaman.SpellFlagShamanSpell,
+		ClassSpellMask:   shaman.SpellMaskEarthShield,
+		DamageMultiplier: 1,
+		CritMultiplier:   resto.DefaultCritMultiplier(),
+		ThreatMultiplier: 1,
+		BonusCoefficient: 0.2747,
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			spell.CalcAndDealHealing(sim, target, resto.CalcScalingSpellDmg(2.54), spell.OutcomeHealingCrit)
+		},
+	})
+
+	procInterval := time.Duration(0)
+	if resto.Options.EarthShieldPPM > 0 {
+		procInterval = time.Minute / time.Duration(resto.Options.EarthShieldPPM)
+	}
+
+	var auras core.AuraArray
+	auras = resto.NewAllyAuraArray(func(target *core.Unit) *core.Aura {
+		var procAction *core.PendingAction
+		consumeCharge := func(aura *core.Aura, sim *core.Simulation) {
+			icd.Use(sim)
+			earthShieldHeal.Cast(sim, aura.Unit)
+			aura.RemoveStack(sim)
+		}
+
+		return target.RegisterAura(core.Aura{
+			Label:     "Earth Shield-" + resto.Label,
+			ActionID:  core.ActionID{SpellID: 974},
+			Duration:  time.Minute * 10,
+			MaxStacks: earthShieldCharges,
+
+			OnGain: func(aura *core.Aura, sim *core.Simulation) {
+				resto.AttackTables[aura.Unit.UnitIndex].HealingDealtMultiplier *= 1.2
+				if procInterval > 0 {
+					procAction = core.StartPeriodicAction(sim, core.PeriodicActionOptions{
+						Period: procInterval,
+						OnAction: func(sim *core.Simulation) {
+							if icd.IsReady(sim) {
+								consumeCharge(aura, sim)
+							}
+						},
+					})
+				}
+			},
+			OnExpire: func(aura *core.Aura, sim *core.Simulation) {
+				resto.AttackTables[aura.Unit.UnitIndex].HealingDealtMultiplier /= 1.2
+				if procAction != nil {
+					procAction.Cancel(sim)
+					procAction = nil
+				}
+			},
+			OnStacksChange: func(aura *core.Aura, sim *core.Simulation, _ int32, newStacks int32) {
+				if newStacks == 0 {
+					aura.Deactivate(sim)
+				}
+			},
+			OnSpellHitTaken: func(aura *core.Aura, sim *core.Simulation, _ *core.Spell, result *core.SpellResult) {
+				if result.Landed() && result.Damage > 0 && icd.IsReady(sim) {
+					consumeCharge(aura, sim)
+				}
+			},
+		})
+	})
+
+	resto.EarthShield = resto.RegisterSpell(core.SpellConfig{
+		ActionID:       core.ActionID{SpellID: 974},
+		SpellSchool:    core.SpellSchoolNature,
+		ProcMask:       core.ProcMaskEmpty,
+		Flags:          core.SpellFlagHelpful | core.SpellFlagAPL | shaman.SpellFlagShamanSpell,
+		ClassSpellMask: shaman.SpellMaskEarthShield,
+
+		MaxRange: 40,
+
+		ManaCost: core.ManaCostOptions{
+			BaseCostPercent: 11.9,
+		},
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.GCDDefault,
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, _ *core.Spell) {
+			target = resto.HealTarget(target)
+
+			// Only one Earth Shield can be active at a time.
+			for _, aura := range auras {
+				if aura != nil && aura.Unit != target {
+					aura.Deactivate(sim)
+				}
+			}
+
+			aura := auras.Get(target)
+			aura.Activate(sim)
+			aura.SetStacks(sim, earthShieldCharges)
+		},
+	})
+}
@@ -0,0 +1,69 @@
+package restoration
+
+import (
+	"time"
+
+	"github.com/wowsims/mop/sim/core"
+	"github.com/wowsims/mop/sim/shaman"
+)
+
+// Heals up to 6 of the most injured allies every 2 seconds for 10 seconds.
+func (resto *RestorationShaman) registerHealingRainSpell() {
+	rainHeal := resto.RegisterSpell(core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: 73921},
+		SpellSchool:      core.SpellSchoolNature,
+		ProcMask:         core.ProcMaskSpellHealing,
+		Flags:            core.SpellFlagHelpful | core.SpellFlagNoOnCastComplete | core.SpellFlagPassiveSpell | shaman.SpellFlagShamanSpell,
+		ClassSpellMask:   shaman.SpellMaskHealingRain,
+		DamageMultiplier: 1,
+		CritMultiplier:   resto.DefaultCritMultiplier(),
+		ThreatMultiplier: 1,
+		BonusCoefficient: 0.1665,
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			spell.CalcAndDealHealing(sim, target, resto.CalcScalingSpellDmg(1.532), spell.OutcomeHealingCrit)
+		},
+	})
+
+	resto.HealingRain = resto.RegisterSpell(core.SpellConfig{
+		ActionID:       core.ActionID{SpellID: 73920},
+		SpellSchool:    core.SpellSchoolNature,
+		ProcMask:       core.ProcMaskEmpty,
+		Flags:          core.SpellFlagHelpful | core.SpellFlagAPL | shaman.SpellFlagShamanSpell,
+		ClassSpellMask: shaman.SpellMaskHealingRain,
+
+		ManaCost: core.ManaCostOptions{
+			BaseCostPercent: 21.6,
+		},
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD:      core.GCDDefault,
+				CastTime: time.Second * 2,
+			},
+			CD: core.Cooldown{
+				Timer:    resto.NewTimer(),
+				Duration: time.Second * 10,
+			},
+		},
+
+		Hot: core.DotConfig{
+			SelfOnly: true,
+			Aura: core.Aura{
+				Label: "Healing Rain",
+			},
+			NumberOfTicks:       5,
+			TickLength:          time.Second * 2,
+			AffectedByCastSpeed: true,
+
+			OnTick: func(sim *core.Simulation, _ *core.Unit, _ *core.Dot) {
+				for _, target := range sim.Environment.Raid.GetLowestHealthAllyUnits(6) {
+					rainHeal.Cast(sim, target)
+				}
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			spell.SelfHot().Apply(sim)
+		},
+	})
+}
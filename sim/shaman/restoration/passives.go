@@ -0,0 +1,152 @@
+package restoration
+
+import (
+	"time"
+
+	"github.com/wowsims/mop/sim/core"
+	"github.com/wowsims/mop/sim/core/proto"
+	"github.com/wowsims/mop/sim/shaman"
+)
+
+const ancestralAwakeningPercent = 0.3
+
+// Purification. Increases healing done by 25%.
+func (resto *RestorationShaman) registerPurification() {
+	resto.AddStaticMod(core.SpellModConfig{
+		Kind:       core.SpellMod_DamageDone_Pct,
+		ClassMask:  shaman.SpellMaskDirectHeal | shaman.SpellMaskEarthShield | shaman.SpellMaskHealingRain | shaman.SpellMaskHealingStreamTotem | shaman.SpellMaskHealingTideTotem | shaman.SpellMaskAncestralAwakening,
+		FloatValue: 0.25,
+	})
+}
+
+// Casting Riptide or Chain Heal grants 2 charges of Tidal Waves. Each charge
+// reduces the cast time of Healing Wave and Greater Healing Wave by 30%, and
+// increases the critical strike chance of Healing Surge by 30%.
+func (resto *RestorationShaman) registerTidalWaves() {
+	castTimeMod := resto.AddDynamicMod(core.SpellModConfig{
+		Kind:       core.SpellMod_CastTime_Pct,
+		ClassMask:  shaman.SpellMaskHealingWave | shaman.SpellMaskGreaterHealingWave,
+		FloatValue: -0.3,
+	})
+	critMod := resto.AddDynamicMod(core.SpellModConfig{
+		Kind:       core.SpellMod_BonusCrit_Percent,
+		ClassMask:  shaman.SpellMaskHealingSurge,
+		FloatValue: 30,
+	})
+
+	resto.TidalWavesAura = resto.RegisterAura(core.Aura{
+		Label:     "Tidal Waves",
+		ActionID:  core.ActionID{SpellID: 53390},
+		Duration:  time.Second * 15,
+		MaxStacks: 2,
+
+		OnGain: func(_ *core.Aura, _ *core.Simulation) {
+			castTimeMod.Activate()
+			critMod.Activate()
+		},
+		OnExpire: func(_ *core.Aura, _ *core.Simulation) {
+			castTimeMod.Deactivate()
+			critMod.Deactivate()
+		},
+		OnCastComplete: func(aura *core.Aura, sim *core.Simulation, spell *core.Spell) {
+			if spell.Matches(shaman.SpellMaskHealingWave | shaman.SpellMaskGreaterHealingWave | shaman.SpellMaskHealingSurge) {
+				aura.RemoveStack(sim)
+			}
+		},
+	})
+
+	resto.MakeProcTriggerAura(core.ProcTrigger{
+		Name:           "Tidal Waves Trigger",
+		Callback:       core.CallbackOnCastComplete,
+		ClassSpellMask: shaman.SpellMaskRiptide | shaman.SpellMaskChainHeal,
+
+		Handler: func(sim *core.Simulation, _ *core.Spell, _ *core.SpellResult) {
+			resto.TidalWavesAura.Activate(sim)
+			resto.TidalWavesAura.SetStacks(sim, 2)
+		},
+	})
+}
+
+// Critical direct heals also heal the most injured ally for 30% of the amount
+// healed.
+func (resto *RestorationShaman) registerAncestralAwakening() {
+	resto.AncestralAwakening = resto.RegisterSpell(core.SpellConfig{
+		ActionID:       core.ActionID{SpellID: 52752},
+		SpellSchool:    core.SpellSchoolNature,
+		ProcMask:       core.ProcMaskSpellHealing,
+		Flags:          core.SpellFlagHelpful | core.SpellFlagNoOnCastComplete | core.SpellFlagPassiveSpell | core.SpellFlagIgnoreModifiers,
+		ClassSpellMask: shaman.SpellMaskAncestralAwakening,
+
+		DamageMultiplier: 1,
+		ThreatMultiplier: 1,
+	})
+
+	resto.MakeProcTriggerAura(core.ProcTrigger{
+		Name:               "Ancestral Awakening Trigger",
+		Callback:           core.CallbackOnHealDealt,
+		ProcMask:           core.ProcMaskSpellHealing,
+		ClassSpellMask:     shaman.SpellMaskDirectHeal,
+		Outcome:            core.OutcomeCrit,
+		TriggerImmediately: true,
+
+		Handler: func(sim *core.Simulation, _ *core.Spell, result *core.SpellResult) {
+			target := sim.Environment.Raid.GetLowestHealthAllyUnits(1)[0]
+			resto.AncestralAwakening.CalcAndDealHealing(sim, target, result.Damage*ancestralAwakeningPercent, resto.AncestralAwakening.OutcomeHealing)
+		},
+	})
+}
+
+// While Water Shield is active, critical heals restore mana. Healing Wave and
+// Greater Healing Wave restore the full amount, faster heals restore 60% and
+// Chain Heal restores 33%.
+func (resto *RestorationShaman) registerResurgence() {
+	if resto.SelfBuffs.Shield != proto.ShamanShield_WaterShield {
+		return
+	}
+
+	manaMetrics := resto.NewManaMetrics(core.ActionID{SpellID: 101033})
+	baseManaReturn := 0.0131 * resto.BaseMana
+
+	resto.MakeProcTriggerAura(core.ProcTrigger{
+		Name:               "Resurgence",
+		ActionID:           core.ActionID{SpellID: 16196},
+		Callback:           core.CallbackOnHealDealt,
+		ProcMask:           core.ProcMaskSpellHealing,
+		ClassSpellMask:     shaman.SpellMaskDirectHeal,
+		Outcome:            core.OutcomeCrit,
+		TriggerImmediately: true,
+
+		Handler: func(sim *core.Simulation, spell *core.Spell, _ *core.SpellResult) {
+			manaReturn := baseManaReturn
+			switch {
+			case spell.Matches(shaman.SpellMaskChainHeal):
+				manaReturn *= 0.333
+			case spell.Matches(shaman.SpellMaskHealingSurge | shaman.SpellMaskRiptide | shaman.SpellMaskUnleashLife):
+				manaReturn *= 0.6
+			}
+			resto.AddMana(sim, manaReturn, manaMetrics)
+		},
+	})
+}
+
+// Mastery: Deep Healing. Increases healing done by the shaman based on how
+// injured the target is.
+func (resto *RestorationShaman) registerDeepHealing() {
+	for _, unit := range resto.Env.Raid.AllUnits {
+		if unit.Type == core.EnemyUnit {
+			continue
+		}
+
+		unit.AddDynamicHealingTakenModifier(func(_ *core.Simulation, spell *core.Spell, result *core.SpellResult) {
+			if spell.Unit != &resto.Unit || !result.Target.HasHealthBar() {
+				return
+			}
+			missingHealth := 1 - result.Target.CurrentHealthPercent()
+			result.Damage *= 1 + missingHealth*resto.deepHealingPercent()
+		})
+	}
+}
+
+func (resto *RestorationShaman) deepHealingPercent() float64 {
+	return (8 + resto.GetMasteryPoints()) * 3 / 100
+}
@@ -32,7 +32,8 @@ func NewRestorationShaman(character *core.Character, options *proto.Player) *Res
 	}
 
 	resto := &RestorationShaman{
-		Shaman: shaman.NewShaman(character, options.TalentsString, selfBuffs, false, restoOptions.ClassOptions.FeleAutocast),
+		Shaman:  shaman.NewShaman(character, options.TalentsString, selfBuffs, false, restoOptions.ClassOptions.FeleAutocast),
+		Options: restoOptions,
 	}
 
 	// if resto.HasMHWeapon() {
@@ -44,6 +45,15 @@ func NewRestorationShaman(character *core.Character, options *proto.Player) *Res
 
 type RestorationShaman struct {
 	*shaman.Shaman
+
+	Options *proto.RestorationShaman_Options
+
+	TidalWavesAura *core.Aura
+
+	AncestralAwakening *core.Spell
+	HealingRain        *core.Spell
+	HealingTideTotem   *core.Spell
+	SpiritLinkTotem    *core.Spell
 }
 
 func (resto *RestorationShaman) GetShaman() *shaman.Shaman {
@@ -53,19 +63,8 @@ func (resto *RestorationShaman) GetShaman() *shaman.Shaman {
 func (resto *RestorationShaman) Reset(sim *core.Simulation) {
 	resto.Shaman.Reset(sim)
 }
-func (resto *RestorationShaman) GetMainTarget() *core.Unit {
-	// TODO: make this just grab first player that isn't self.
-	target := resto.Env.Raid.GetFirstTargetDummy()
-	if target == nil {
-		return &resto.Unit
-	} else {
-		return &target.Unit
-	}
-}
 
 func (resto *RestorationShaman) Initialize() {
-	// resto.CurrentTarget = resto.GetMainTarget()
-
 	// // Has to be here because earthliving can cast hots and needs Env to be set to create the hots.
 	// procMask := core.ProcMaskUnknown
 	// if resto.HasMHWeapon() {
@@ -78,9 +77,24 @@ func (resto *RestorationShaman) Initialize() {
 
 	resto.Shaman.Initialize()
 	resto.Shaman.RegisterHealingSpells()
+
+	resto.registerRiptideSpell()
+	resto.registerEarthShieldSpell()
+	resto.registerHealingRainSpell()
+	resto.registerHealingTideTotemSpell()
+	resto.registerSpiritLinkTotemSpell()
 }
 
 func (resto *RestorationShaman) ApplyTalents() {
 	resto.Shaman.ApplyTalents()
 	resto.ApplyArmorSpecializationEffect(stats.Intellect, proto.ArmorType_ArmorTypeMail, 86529)
+
+	// Meditation
+	resto.PseudoStats.SpiritRegenRateCombat = 0.5
+
+	resto.registerPurification()
+	resto.registerTidalWaves()
+	resto.registerAncestralAwakening()
+	resto.registerResurgence()
+	resto.registerDeepHealing() // Mastery
 }
@@ -0,0 +1,61 @@
+package restoration
+
+import (
+	"time"
+
+	"github.com/wowsims/mop/sim/core"
+	"github.com/wowsims/mop/sim/shaman"
+)
+
+func (resto *RestorationShaman) registerRiptideSpell() {
+	resto.Riptide = resto.RegisterSpell(core.SpellConfig{
+		ActionID:       core.ActionID{SpellID: 61295},
+		SpellSchool:    core.SpellSchoolNature,
+		ProcMask:       core.ProcMaskSpellHealing,
+		Flags:          core.SpellFlagHelpful | core.SpellFlagAPL | shaman.SpellFlagShamanSpell,
+		ClassSpellMask: shaman.SpellMaskRiptide,
+
+		MaxRange: 40,
+
+		ManaCost: core.ManaCostOptions{
+			BaseCostPercent: 10,
+		},
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.GCDDefault,
+			},
+			CD: core.Cooldown{
+				Timer:    resto.NewTimer(),
+				Duration: time.Second * 6,
+			},
+		},
+
+		DamageMultiplier: 1,
+		CritMultiplier:   resto.DefaultCritMultiplier(),
+		ThreatMultiplier: 1,
+		BonusCoefficient: 0.2986,
+
+		Hot: core.DotConfig{
+			Aura: core.Aura{
+				Label: "Riptide",
+			},
+			NumberOfTicks:       6,
+			TickLength:          time.Second * 3,
+			AffectedByCastSpeed: true,
+			BonusCoefficient:    0.1171,
+
+			OnSnapshot: func(_ *core.Simulation, target *core.Unit, dot *core.Dot, _ bool) {
+				dot.SnapshotHeal(target, resto.CalcScalingSpellDmg(1.08))
+			},
+			OnTick: func(sim *core.Simulation, target *core.Unit, dot *core.Dot) {
+				dot.CalcAndDealPeriodicSnapshotHealing(sim, target, dot.OutcomeSnapshotCrit)
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			target = resto.HealTarget(target)
+			spell.CalcAndDealHealing(sim, target, resto.CalcScalingSpellDmg(2.74), spell.OutcomeHealingCrit)
+			spell.Hot(target).Apply(sim)
+		},
+	})
+}
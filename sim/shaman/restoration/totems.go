@@ -0,0 +1,144 @@
+package restoration
+
+import (
+	"time"
+
+	"github.com/wowsims/mop/sim/core"
+	"github.com/wowsims/mop/sim/shaman"
+)
+
+// Heals the 5 most injured allies every 2 seconds for 10 seconds.
+func (resto *RestorationShaman) registerHealingTideTotemSpell() {
+	tideHeal := resto.RegisterSpell(core.SpellConfig{
+		ActionID:         core.ActionID{SpellID: 114942},
+		SpellSchool:      core.SpellSchoolNature,
+		ProcMask:         core.ProcMaskSpellHealing,
+		Flags:            core.SpellFlagHelpful | core.SpellFlagNoOnCastComplete | core.SpellFlagPassiveSpell | shaman.SpellFlagShamanSpell,
+		ClassSpellMask:   shaman.SpellMaskHealingTideTotem,
+		DamageMultiplier: 1,
+		CritMultiplier:   resto.DefaultCritMultiplier(),
+		ThreatMultiplier: 1,
+		BonusCoefficient: 0.484,
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			spell.CalcAndDealHealing(sim, target, resto.CalcScalingSpellDmg(4.35), spell.OutcomeHealingCrit)
+		},
+	})
+
+	resto.HealingTideTotem = resto.RegisterSpell(core.SpellConfig{
+		ActionID:       core.ActionID{SpellID: 108280},
+		SpellSchool:    core.SpellSchoolNature,
+		Flags:          core.SpellFlagHelpful | core.SpellFlagAPL | shaman.SpellFlagShamanSpell,
+		ClassSpellMask: shaman.SpellMaskHealingTideTotem,
+
+		ManaCost: core.ManaCostOptions{
+			BaseCostPercent: 5.65,
+		},
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: time.Second,
+			},
+			CD: core.Cooldown{
+				Timer:    resto.NewTimer(),
+				Duration: time.Minute * 3,
+			},
+		},
+
+		Hot: core.DotConfig{
+			SelfOnly: true,
+			Aura: core.Aura{
+				Label: "Healing Tide Totem",
+			},
+			NumberOfTicks: 5,
+			TickLength:    time.Second * 2,
+
+			OnTick: func(sim *core.Simulation, _ *core.Unit, _ *core.Dot) {
+				for _, target := range sim.Environment.Raid.GetLowestHealthAllyUnits(5) {
+					tideHeal.Cast(sim, target)
+				}
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			resto.TotemExpirations[shaman.WaterTotem] = sim.CurrentTime + time.Second*10
+			spell.SelfHot().Apply(sim)
+		},
+	})
+}
+
+// Redistributes health every second for 6 seconds so that all nearby allies
+// end up at the same health percentage.
+func (resto *RestorationShaman) registerSpiritLinkTotemSpell() {
+	actionID := core.ActionID{SpellID: 98008}
+	healthMetrics := make(map[*core.Unit]*core.ResourceMetrics)
+	for _, unit := range resto.Env.Raid.AllUnits {
+		if unit.Type != core.EnemyUnit {
+			healthMetrics[unit] = unit.NewHealthMetrics(actionID)
+		}
+	}
+
+	resto.SpiritLinkTotem = resto.RegisterSpell(core.SpellConfig{
+		ActionID:       actionID,
+		SpellSchool:    core.SpellSchoolNature,
+		Flags:          core.SpellFlagHelpful | core.SpellFlagAPL | shaman.SpellFlagShamanSpell,
+		ClassSpellMask: shaman.SpellMaskSpiritLinkTotem,
+
+		ManaCost: core.ManaCostOptions{
+			BaseCostPercent: 11.5,
+		},
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: time.Second,
+			},
+			CD: core.Cooldown{
+				Timer:    resto.NewTimer(),
+				Duration: time.Minute * 3,
+			},
+		},
+
+		Hot: core.DotConfig{
+			SelfOnly: true,
+			Aura: core.Aura{
+				Label: "Spirit Link Totem",
+			},
+			NumberOfTicks: 6,
+			TickLength:    time.Second,
+
+			OnTick: func(sim *core.Simulation, _ *core.Unit, _ *core.Dot) {
+				redistributeHealth(sim, healthMetrics)
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			resto.TotemExpirations[shaman.AirTotem] = sim.CurrentTime + time.Second*6
+			redistributeHealth(sim, healthMetrics)
+			spell.SelfHot().Apply(sim)
+		},
+	})
+}
+
+func redistributeHealth(sim *core.Simulation, healthMetrics map[*core.Unit]*core.ResourceMetrics) {
+	var linked []*core.Unit
+	totalHealth, totalMaxHealth := 0.0, 0.0
+	for _, unit := range sim.Environment.Raid.GetActiveAllyUnits() {
+		if !unit.HasHealthBar() || healthMetrics[unit] == nil {
+			continue
+		}
+		linked = append(linked, unit)
+		totalHealth += unit.CurrentHealth()
+		totalMaxHealth += unit.MaxHealth()
+	}
+	if len(linked) < 2 || totalMaxHealth <= 0 {
+		return
+	}
+
+	healthPercent := totalHealth / totalMaxHealth
+	for _, unit := range linked {
+		delta := unit.MaxHealth()*healthPercent - unit.CurrentHealth()
+		if delta > 0 {
+			unit.GainHealth(sim, delta, healthMetrics[unit])
+		} else if delta < 0 {
+			unit.RemoveHealth(sim, -delta)
+		}
+	}
+}
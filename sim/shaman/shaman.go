@@ -47,8 +47,6 @@ func NewShaman(character *core.Character, talents string, selfBuffs SelfBuffs, t
 		ThunderstormInRange: thunderstormRange,
 		ClassSpellScaling:   core.GetClassSpellScalingCoefficient(proto.Class_ClassShaman),
 	}
-	// shaman.waterShieldManaMetrics = shaman.NewManaMetrics(core.ActionID{SpellID: 57960})
-
 	core.FillTalentsProto(shaman.Talents.ProtoReflect(), talents)
 
 	// Add Shaman stat dependencies
@@ -184,19 +182,13 @@ type Shaman struct {
 	SearingFlamesMultiplier float64
 
 	// Healing Spells
-	tidalWaveProc          *core.Aura
-	ancestralHealingAmount float64
-	AncestralAwakening     *core.Spell
-	HealingSurge           *core.Spell
-
-	GreaterHealingWave *core.Spell
+	HealingSurge       *core.Spell
 	HealingWave        *core.Spell
+	GreaterHealingWave *core.Spell
 	ChainHeal          *core.Spell
 	Riptide            *core.Spell
 	EarthShield        *core.Spell
 
-	waterShieldManaMetrics *core.ResourceMetrics
-
 	// Item sets
 	T14Ele4pc *core.Aura
 	T14Enh4pc *core.Aura
@@ -228,6 +220,11 @@ func (shaman *Shaman) AddRaidBuffs(raidBuffs *proto.RaidBuffs) {
 	raidBuffs.BurningWrath = true
 }
 
+func (shaman *Shaman) AddDebuffs(debuffs *proto.Debuffs) {
+	// Earth Shock
+	debuffs.WeakenedBlows = true
+}
+
 func (shaman *Shaman) Initialize() {
 	shaman.registerChainLightningSpell()
 	shaman.registerFireElementalTotem(!shaman.Talents.PrimalElementalist)
@@ -246,33 +243,13 @@ func (shaman *Shaman) Initialize() {
 	shaman.registerStormlashCD()
 }
 
+// Registers the heals and healing totems shared by all specs.
 func (shaman *Shaman) RegisterHealingSpells() {
-	// shaman.registerAncestralHealingSpell()
-	// shaman.registerHealingSurgeSpell()
-	// shaman.registerHealingWaveSpell()
-	// shaman.registerRiptideSpell()
-	// shaman.registerEarthShieldSpell()
-	// shaman.registerChainHealSpell()
-
-	// if shaman.Talents.TidalWaves > 0 {
-	// 	shaman.tidalWaveProc = shaman.GetOrRegisterAura(core.Aura{
-	// 		Label:    "Tidal Wave Proc",
-	// 		ActionID: core.ActionID{SpellID: 53390},
-	// 		Duration: core.NeverExpires,
-	// 		OnReset: func(aura *core.Aura, sim *core.Simulation) {
-	// 			aura.Deactivate(sim)
-	// 		},
-	// 		OnGain: func(aura *core.Aura, sim *core.Simulation) {
-	// 			shaman.HealingWave.CastTimeMultiplier *= 0.7
-	// 			shaman.HealingSurge.BonusCritRating += core.CritRatingPerCritChance * 25
-	// 		},
-	// 		OnExpire: func(aura *core.Aura, sim *core.Simulation) {
-	// 			shaman.HealingWave.CastTimeMultiplier /= 0.7
-	// 			shaman.HealingSurge.BonusCritRating -= core.CritRatingPerCritChance * 25
-	// 		},
-	// 		MaxStacks: 2,
-	// 	})
-	// }
+	shaman.registerHealingSurgeSpell()
+	shaman.registerHealingWaveSpell()
+	shaman.registerGreaterHealingWaveSpell()
+	shaman.registerChainHealSpell()
+	shaman.registerHealingStreamTotemSpell()
 }
 
 func (shaman *Shaman) Reset(sim *core.Simulation) {
@@ -352,6 +329,17 @@ const (
 	SpellMaskElementalBlastOverload
 	SpellMaskStormlashTotem
 	SpellMaskBloodlust
+	SpellMaskHealingSurge
+	SpellMaskHealingWave
+	SpellMaskGreaterHealingWave
+	SpellMaskChainHeal
+	SpellMaskRiptide
+	SpellMaskHealingRain
+	SpellMaskHealingStreamTotem
+	SpellMaskHealingTideTotem
+	SpellMaskSpiritLinkTotem
+	SpellMaskAncestralAwakening
+	SpellMaskUnleashLife
 
 	SpellMaskStormstrike  = SpellMaskStormstrikeCast | SpellMaskStormstrikeDamage
 	SpellMaskStormblast   = SpellMaskStormblastCast | SpellMaskStormblastDamage
@@ -361,7 +349,8 @@ const (
 	SpellMaskFrost        = SpellMaskUnleashFrost | SpellMaskFrostShock | SpellMaskElementalBlast | SpellMaskElementalBlastOverload
 	SpellMaskOverload     = SpellMaskLavaBurstOverload | SpellMaskLightningBoltOverload | SpellMaskChainLightningOverload | SpellMaskElementalBlastOverload | SpellMaskLavaBeamOverload
 	SpellMaskShock        = SpellMaskFlameShock | SpellMaskEarthShock | SpellMaskFrostShock
-	SpellMaskTotem        = SpellMaskMagmaTotem | SpellMaskSearingTotem | SpellMaskFireElementalTotem | SpellMaskEarthElementalTotem | SpellMaskStormlashTotem
+	SpellMaskTotem        = SpellMaskMagmaTotem | SpellMaskSearingTotem | SpellMaskFireElementalTotem | SpellMaskEarthElementalTotem | SpellMaskStormlashTotem | SpellMaskHealingStreamTotem | SpellMaskHealingTideTotem | SpellMaskSpiritLinkTotem
+	SpellMaskDirectHeal   = SpellMaskHealingSurge | SpellMaskHealingWave | SpellMaskGreaterHealingWave | SpellMaskChainHeal | SpellMaskRiptide | SpellMaskUnleashLife
 	SpellMaskInstantSpell = SpellMaskAscendance | SpellMaskFeralSpirit | SpellMaskUnleashElements | SpellMaskBloodlust
 	SpellMaskImbue        = SpellMaskFrostbrandWeapon | SpellMaskWindfuryWeapon | SpellMaskFlametongueWeapon
 )
@@ -22,36 +22,44 @@ func (shaman *Shaman) newTotemSpellConfig(baseCostPercent int32, spellID int32)
 	}
 }
 
+// Heals the most injured ally every 2 seconds for 15 seconds.
 func (shaman *Shaman) registerHealingStreamTotemSpell() {
-	config := shaman.newTotemSpellConfig(3, 5394)
 	hsHeal := shaman.RegisterSpell(core.SpellConfig{
-		ActionID:         core.ActionID{SpellID: 5394},
+		ActionID:         core.ActionID{SpellID: 52042},
 		SpellSchool:      core.SpellSchoolNature,
-		ProcMask:         core.ProcMaskEmpty,
-		Flags:            core.SpellFlagHelpful | core.SpellFlagNoOnCastComplete,
+		ProcMask:         core.ProcMaskSpellHealing,
+		Flags:            core.SpellFlagHelpful | core.SpellFlagNoOnCastComplete | core.SpellFlagPassiveSpell | SpellFlagShamanSpell,
+		ClassSpellMask:   SpellMaskHealingStreamTotem,
 		DamageMultiplier: 1,
-		CritMultiplier:   1,
+		CritMultiplier:   shaman.DefaultCritMultiplier(),
 		ThreatMultiplier: 1,
+		BonusCoefficient: 0.4827,
 		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
-			healing := 28 + spell.HealingPower(target)*0.08272
-			spell.CalcAndDealHealing(sim, target, healing, spell.OutcomeHealing)
+			spell.CalcAndDealHealing(sim, target, shaman.CalcScalingSpellDmg(4.46), spell.OutcomeHealingCrit)
 		},
 	})
+
+	config := shaman.newTotemSpellConfig(3, 5394)
+	config.Flags |= core.SpellFlagHelpful | SpellFlagShamanSpell
+	config.ClassSpellMask = SpellMaskHealingStreamTotem
+	config.Cast.CD = core.Cooldown{
+		Timer:    shaman.NewTimer(),
+		Duration: time.Second * 30,
+	}
 	config.Hot = core.DotConfig{
+		SelfOnly: true,
 		Aura: core.Aura{
-			Label: "HealingStreamHot",
+			Label: "Healing Stream Totem",
 		},
-		NumberOfTicks: 150,
+		NumberOfTicks: 7,
 		TickLength:    time.Second * 2,
-		OnTick: func(sim *core.Simulation, target *core.Unit, dot *core.Dot) {
-			hsHeal.Cast(sim, target)
+		OnTick: func(sim *core.Simulation, _ *core.Unit, _ *core.Dot) {
+			hsHeal.Cast(sim, sim.Environment.Raid.GetLowestHealthAllyUnits(1)[0])
 		},
 	}
 	config.ApplyEffects = func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
-		shaman.TotemExpirations[WaterTotem] = sim.CurrentTime + time.Second*300
-		for _, agent := range shaman.Party.Players {
-			spell.Hot(&agent.GetCharacter().Unit).Activate(sim)
-		}
+		shaman.TotemExpirations[WaterTotem] = sim.CurrentTime + time.Second*15
+		spell.SelfHot().Apply(sim)
 	}
 	shaman.HealingStreamTotem = shaman.RegisterSpell(config)
 }
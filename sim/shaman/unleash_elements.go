@@ -144,17 +144,14 @@ func (shaman *Shaman) registerUnleashLife() {
 		SpellSchool:      core.SpellSchoolNature,
 		ProcMask:         core.ProcMaskSpellHealing,
 		Flags:            core.SpellFlagHelpful | core.SpellFlagPassiveSpell,
+		ClassSpellMask:   SpellMaskUnleashLife,
+		DamageMultiplier: 1,
 		CritMultiplier:   shaman.DefaultCritMultiplier(),
 		BonusCoefficient: 0.28600001335,
 		ThreatMultiplier: 1,
 		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
 			baseHeal := shaman.CalcScalingSpellDmg(2.82999992371)
-			result := spell.CalcAndDealHealing(sim, target, baseHeal, spell.OutcomeHealingCrit)
-
-			if result.Outcome.Matches(core.OutcomeCrit) {
-				shaman.ancestralHealingAmount = result.Damage * 0.3
-				shaman.AncestralAwakening.Cast(sim, target)
-			}
+			spell.CalcAndDealHealing(sim, shaman.HealTarget(target), baseHeal, spell.OutcomeHealingCrit)
 			unleashLifeAura.Activate(sim)
 		},
 	})
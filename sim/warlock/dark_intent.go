@@ -0,0 +1,46 @@
+package warlock
+
+import (
+	"github.com/wowsims/mop/sim/core"
+)
+
+// Dark Intent is cast on the whole raid before the pull. Individual sims, and
+// raids that already have it from the raid buff settings, keep using the raid
+// buff instead.
+func (warlock *Warlock) registerDarkIntent() {
+	if !warlock.castsDarkIntent || warlock.GetAuraByID(core.ActionID{SpellID: 109773}) != nil {
+		return
+	}
+
+	var darkIntentAuras []*core.Aura
+	for _, party := range warlock.Env.Raid.Parties {
+		for _, player := range party.Players {
+			if _, isDummy := player.(*core.TargetDummy); !isDummy {
+				darkIntentAuras = append(darkIntentAuras, core.DarkIntentCastAura(&player.GetCharacter().Unit))
+			}
+		}
+	}
+
+	darkIntent := warlock.RegisterSpell(core.SpellConfig{
+		ActionID:    core.ActionID{SpellID: 109773},
+		SpellSchool: core.SpellSchoolArcane,
+		ProcMask:    core.ProcMaskEmpty,
+		Flags:       core.SpellFlagHelpful | core.SpellFlagNoOnCastComplete,
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, _ *core.Spell) {
+			for _, aura := range darkIntentAuras {
+				aura.Activate(sim)
+			}
+		},
+	})
+
+	warlock.RegisterResetEffect(func(sim *core.Simulation) {
+		sim.AddPendingAction(core.NewDelayedAction(core.DelayedActionOptions{
+			DoAt:     warlock.Env.PrepullStartTime(sim),
+			Priority: core.ActionPriorityHigh,
+			OnAction: func(sim *core.Simulation) {
+				darkIntent.SkipCastAndApplyEffects(sim, &warlock.Unit)
+			},
+		}))
+	})
+}
@@ -38,6 +38,9 @@ type Warlock struct {
 
 	serviceTimer *core.Timer
 
+	// Whether Dark Intent is cast on the rest of the raid.
+	castsDarkIntent bool
+
 	// Item sets
 	T15_2pc      *core.Aura
 	T15_4pc      *core.Aura
@@ -68,6 +71,7 @@ func (warlock *Warlock) Initialize() {
 	warlock.registerSummonDoomguard(doomguardInfernalTimer)
 	warlock.registerSummonInfernal(doomguardInfernalTimer)
 	warlock.registerLifeTap()
+	warlock.registerDarkIntent()
 	warlock.registerGlyphs()
 
 	// Fel Armor 10% Stamina
@@ -87,6 +91,12 @@ func (warlock *Warlock) AddRaidBuffs(raidBuffs *proto.RaidBuffs) {
 
 }
 
+func (warlock *Warlock) AddRaidMemberBuffs(_ *proto.RaidBuffs) {
+	// Cast on the raid before the pull rather than set as a raid buff, see
+	// registerDarkIntent.
+	warlock.castsDarkIntent = true
+}
+
 func (warlock *Warlock) Reset(sim *core.Simulation) {
 }
 
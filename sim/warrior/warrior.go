@@ -152,6 +152,13 @@ func (warrior *Warrior) AddRaidBuffs(raidBuffs *proto.RaidBuffs) {
 
 }
 
+func (warrior *Warrior) AddDebuffs(debuffs *proto.Debuffs) {
+	if warrior.Spec != proto.Spec_SpecProtectionWarrior {
+		// Mortal Strike / Wild Strike
+		debuffs.MortalWounds = true
+	}
+}
+
 func (warrior *Warrior) AddPartyBuffs(_ *proto.PartyBuffs) {
 }
 
@@ -32,6 +32,9 @@ func main() {
 	js.Global().Set("raidSimAsync", js.FuncOf(raidSimAsync))
 	js.Global().Set("raidSimRequestSplit", js.FuncOf(raidSimRequestSplit))
 	js.Global().Set("raidSimResultCombination", js.FuncOf(raidSimResultCombination))
+	js.Global().Set("bulkSimAsync", js.FuncOf(bulkSimAsync))
+	js.Global().Set("optimizeGearAsync", js.FuncOf(optimizeGearAsync))
+	js.Global().Set("tuneAPLAsync", js.FuncOf(tuneAPLAsync))
 	js.Global().Set("statWeights", js.FuncOf(statWeights))
 	js.Global().Set("statWeightsAsync", js.FuncOf(statWeightsAsync))
 	js.Global().Set("statWeightRequests", js.FuncOf(statWeightRequests))
@@ -182,6 +185,60 @@ func raidSimAsync(this js.Value, args []js.Value) interface{} {
 	return js.Undefined()
 }
 
+func bulkSimAsync(this js.Value, args []js.Value) interface{} {
+	bsr := &proto.BulkSimRequest{}
+	if err := googleProto.Unmarshal(getArgsBinary(args[0]), bsr); err != nil {
+		log.Printf("Failed to parse request: %s", err)
+		return nil
+	}
+
+	requestId := args[2].String()
+	if strings.HasPrefix(requestId, "<T") {
+		requestId = "" // Make it return the error for an empty id
+	}
+
+	reporter := make(chan *proto.ProgressMetrics, 100)
+	go core.BulkSimAsync(bsr, reporter, requestId)
+	go processAsyncProgress(args[1], reporter)
+	return js.Undefined()
+}
+
+func optimizeGearAsync(this js.Value, args []js.Value) interface{} {
+	gor := &proto.GearOptimizationRequest{}
+	if err := googleProto.Unmarshal(getArgsBinary(args[0]), gor); err != nil {
+		log.Printf("Failed to parse request: %s", err)
+		return nil
+	}
+
+	requestId := args[2].String()
+	if strings.HasPrefix(requestId, "<T") {
+		requestId = "" // Make it return the error for an empty id
+	}
+
+	reporter := make(chan *proto.ProgressMetrics, 100)
+	go core.OptimizeGearAsync(gor, reporter, requestId)
+	go processAsyncProgress(args[1], reporter)
+	return js.Undefined()
+}
+
+func tuneAPLAsync(this js.Value, args []js.Value) interface{} {
+	atr := &proto.APLTuningRequest{}
+	if err := googleProto.Unmarshal(getArgsBinary(args[0]), atr); err != nil {
+		log.Printf("Failed to parse request: %s", err)
+		return nil
+	}
+
+	requestId := args[2].String()
+	if strings.HasPrefix(requestId, "<T") {
+		requestId = "" // Make it return the error for an empty id
+	}
+
+	reporter := make(chan *proto.ProgressMetrics, 100)
+	go core.TuneAPLAsync(atr, reporter, requestId)
+	go processAsyncProgress(args[1], reporter)
+	return js.Undefined()
+}
+
 func statWeights(this js.Value, args []js.Value) interface{} {
 	swr := &proto.StatWeightsRequest{}
 	if err := googleProto.Unmarshal(getArgsBinary(args[0]), swr); err != nil {
@@ -367,7 +424,7 @@ func processAsyncProgress(progFunc js.Value, reporter chan *proto.ProgressMetric
 			js.CopyBytesToJS(outArray, outbytes)
 			progFunc.Invoke(outArray)
 
-			if progMetric.FinalWeightResult != nil || progMetric.FinalRaidResult != nil {
+			if progMetric.FinalWeightResult != nil || progMetric.FinalRaidResult != nil || progMetric.FinalBulkResult != nil || progMetric.FinalGearOptimizationResult != nil || progMetric.FinalAplTuningResult != nil {
 				return
 			}
 		}
@@ -25,6 +25,7 @@ import (
 	"github.com/wowsims/mop/sim/core"
 	proto "github.com/wowsims/mop/sim/core/proto"
 	"github.com/wowsims/mop/sim/core/simsignals"
+	"github.com/wowsims/mop/sim/distributed"
 
 	googleProto "google.golang.org/protobuf/proto"
 )
@@ -48,6 +49,7 @@ func main() {
 	var host = flag.String("host", "localhost:3333", "URL to host the interface on.")
 	var launch = flag.Bool("launch", true, "auto launch browser")
 	var skipVersionCheck = flag.Bool("nvc", false, "set true to skip version check")
+	var workers = flag.String("workers", "", "Comma separated worker URLs (ex: http://10.0.0.2:3334). Raid sims and stat weights are split across them instead of run locally.")
 
 	flag.Parse()
 
@@ -82,6 +84,10 @@ func main() {
 		}()
 	}
 
+	if *workers != "" {
+		useWorkers(distributed.NewCoordinator(strings.Split(*workers, ",")))
+	}
+
 	s := &server{
 		progMut:         sync.RWMutex{},
 		asyncProgresses: map[string]*asyncProgress{},
@@ -94,6 +100,15 @@ var handlers = map[string]apiHandler{
 	"/raidSim": {msg: func() googleProto.Message { return &proto.RaidSimRequest{} }, handle: func(msg googleProto.Message) googleProto.Message {
 		return core.RunRaidSim(msg.(*proto.RaidSimRequest))
 	}},
+	"/bulkSim": {msg: func() googleProto.Message { return &proto.BulkSimRequest{} }, handle: func(msg googleProto.Message) googleProto.Message {
+		return core.RunBulkSim(msg.(*proto.BulkSimRequest))
+	}},
+	"/optimizeGear": {msg: func() googleProto.Message { return &proto.GearOptimizationRequest{} }, handle: func(msg googleProto.Message) googleProto.Message {
+		return core.OptimizeGear(msg.(*proto.GearOptimizationRequest))
+	}},
+	"/tuneAPL": {msg: func() googleProto.Message { return &proto.APLTuningRequest{} }, handle: func(msg googleProto.Message) googleProto.Message {
+		return core.TuneAPL(msg.(*proto.APLTuningRequest))
+	}},
 	"/statWeights": {msg: func() googleProto.Message { return &proto.StatWeightsRequest{} }, handle: func(msg googleProto.Message) googleProto.Message {
 		return core.StatWeights(msg.(*proto.StatWeightsRequest))
 	}},
@@ -120,6 +135,32 @@ var asyncAPIHandlers = map[string]asyncAPIHandler{
 	"/statWeightsAsync": {msg: func() googleProto.Message { return &proto.StatWeightsRequest{} }, handle: func(msg googleProto.Message, reporter chan *proto.ProgressMetrics, requestId string) {
 		core.StatWeightsAsync(msg.(*proto.StatWeightsRequest), reporter, requestId)
 	}},
+	"/bulkSimAsync": {msg: func() googleProto.Message { return &proto.BulkSimRequest{} }, handle: func(msg googleProto.Message, reporter chan *proto.ProgressMetrics, requestId string) {
+		core.BulkSimAsync(msg.(*proto.BulkSimRequest), reporter, requestId)
+	}},
+	"/optimizeGearAsync": {msg: func() googleProto.Message { return &proto.GearOptimizationRequest{} }, handle: func(msg googleProto.Message, reporter chan *proto.ProgressMetrics, requestId string) {
+		core.OptimizeGearAsync(msg.(*proto.GearOptimizationRequest), reporter, requestId)
+	}},
+	"/tuneAPLAsync": {msg: func() googleProto.Message { return &proto.APLTuningRequest{} }, handle: func(msg googleProto.Message, reporter chan *proto.ProgressMetrics, requestId string) {
+		core.TuneAPLAsync(msg.(*proto.APLTuningRequest), reporter, requestId)
+	}},
+}
+
+// Sends raid sims and stat weights to the coordinator's workers.
+func useWorkers(coordinator *distributed.Coordinator) {
+	log.Printf("Using %d sim workers.", len(coordinator.Workers))
+	handlers["/raidSim"] = apiHandler{msg: func() googleProto.Message { return &proto.RaidSimRequest{} }, handle: func(msg googleProto.Message) googleProto.Message {
+		return coordinator.RunRaidSim(msg.(*proto.RaidSimRequest))
+	}}
+	handlers["/statWeights"] = apiHandler{msg: func() googleProto.Message { return &proto.StatWeightsRequest{} }, handle: func(msg googleProto.Message) googleProto.Message {
+		return coordinator.StatWeights(msg.(*proto.StatWeightsRequest))
+	}}
+	asyncAPIHandlers["/raidSimAsync"] = asyncAPIHandler{msg: func() googleProto.Message { return &proto.RaidSimRequest{} }, handle: func(msg googleProto.Message, reporter chan *proto.ProgressMetrics, requestId string) {
+		coordinator.RunRaidSimAsync(msg.(*proto.RaidSimRequest), reporter, requestId)
+	}}
+	asyncAPIHandlers["/statWeightsAsync"] = asyncAPIHandler{msg: func() googleProto.Message { return &proto.StatWeightsRequest{} }, handle: func(msg googleProto.Message, reporter chan *proto.ProgressMetrics, requestId string) {
+		coordinator.StatWeightsAsync(msg.(*proto.StatWeightsRequest), reporter, requestId)
+	}}
 }
 
 type server struct {
@@ -200,7 +241,7 @@ func (s *server) handleAsyncAPI(w http.ResponseWriter, r *http.Request) {
 					return
 				}
 				simProgress.latestProgress.Store(progMetric)
-				if progMetric.FinalRaidResult != nil || progMetric.FinalWeightResult != nil {
+				if isFinalProgress(progMetric) {
 					return
 				}
 			}
@@ -258,7 +299,7 @@ func (s *server) setupAsyncServer() {
 		}
 
 		// If this was the last result, delete the cache for this simulation.
-		if latest.FinalRaidResult != nil || latest.FinalWeightResult != nil {
+		if isFinalProgress(latest) {
 			s.progMut.Lock()
 			delete(s.asyncProgresses, msg.ProgressId)
 			s.progMut.Unlock()
@@ -267,6 +308,11 @@ func (s *server) setupAsyncServer() {
 		w.Write(outbytes)
 	})))
 }
+
+func isFinalProgress(progMetric *proto.ProgressMetrics) bool {
+	return progMetric.FinalRaidResult != nil || progMetric.FinalWeightResult != nil || progMetric.FinalBulkResult != nil || progMetric.FinalGearOptimizationResult != nil || progMetric.FinalAplTuningResult != nil
+}
+
 func corsMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Access-Control-Allow-Origin", "*")
@@ -0,0 +1,227 @@
+package apltext
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/wowsims/mop/sim/core/proto"
+	goproto "google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+func TestParseExample(t *testing.T) {
+	rotation, err := Parse("actions+=/cast_spell,spell_id=12345,if=aura_remaining_time(67890)<2")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := &proto.APLRotation{
+		PriorityList: []*proto.APLListItem{{
+			Action: &proto.APLAction{
+				Condition: &proto.APLValue{Value: &proto.APLValue_Cmp{Cmp: &proto.APLValueCompare{
+					Op:  proto.APLValueCompare_OpLt,
+					Lhs: &proto.APLValue{Value: &proto.APLValue_AuraRemainingTime{AuraRemainingTime: &proto.APLValueAuraRemainingTime{AuraId: &proto.ActionID{RawId: &proto.ActionID_SpellId{SpellId: 67890}}}}},
+					Rhs: constValue("2"),
+				}}},
+				Action: &proto.APLAction_CastSpell{CastSpell: &proto.APLActionCastSpell{SpellId: &proto.ActionID{RawId: &proto.ActionID_SpellId{SpellId: 12345}}}},
+			},
+		}},
+	}
+	if !goproto.Equal(rotation, expected) {
+		t.Fatalf("Expected %v but got %v", expected, rotation)
+	}
+}
+
+func TestValueSyntax(t *testing.T) {
+	for _, tc := range []struct {
+		text      string
+		formatted string
+	}{
+		{"variable_ref(\"x\") || variable_ref(\"y\") && current_time() > 1s", "variable_ref(\"x\") || variable_ref(\"y\") && current_time()>1s"},
+		{"(current_time() > 1s || current_time() < -.5s) && true", "(current_time()>1s || current_time()<-.5s) && true"},
+		{"current_time() - (remaining_time() - 2s) * 3", "current_time()-(remaining_time()-2s)*3"},
+		{"!(current_time() > 1s)", "!(current_time()>1s)"},
+		{"and(current_time() > 1s)", "and(current_time()>1s)"},
+		{"cmp(lhs=current_time())", "cmp(lhs=current_time())"},
+		{"max(current_time(), 2s, none)", "max(current_time(), 2s, none)"},
+		{"\"not a number\"", "\"not a number\""},
+		{"aura_num_stacks(spell(97462, -1), Target(1), include_reaction_time=true)", "aura_num_stacks(spell(97462, -1), Target(1), true)"},
+		{"aura_is_active(source_unit=CurrentTarget, aura_id=other(OtherActionPotion))", "aura_is_active(other(OtherActionPotion), CurrentTarget)"},
+		{"const(\"4.5s\", tunable={min=0, max=9, step=0.5})", "const(\"4.5s\", {max=9,step=0.5})"},
+	} {
+		value, err := ParseValue(tc.text)
+		if err != nil {
+			t.Errorf("Failed to parse %q: %v", tc.text, err)
+			continue
+		}
+		if formatted := FormatValue(value); formatted != tc.formatted {
+			t.Errorf("Expected %q to format as %q but got %q", tc.text, tc.formatted, formatted)
+		}
+		reparsed, err := ParseValue(tc.formatted)
+		if err != nil || !goproto.Equal(value, reparsed) {
+			t.Errorf("%q does not round trip: %v", tc.formatted, err)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, text := range []string{
+		"actions+=/cast_spel,spell_id=123",
+		"actions+=/cast_spell,spell=123",
+		"actions+=/cast_spell,spell_id=123,spell_id=456",
+		"actions+=/cast_spell,spell_id=123,if",
+		"actions+=/cast_spell,if=current_time",
+		"actions+=/cast_spell,if=current_time()<-remaining_time()",
+		"actions+=/cast_spell,if=current_time()<1s<2s",
+		"actions+=/wait,duration=1s,at=0s",
+		"prepull+=/cast_spell,spell_id=123,notes=\"x\"",
+		"type=TypeNone",
+	} {
+		if _, err := Parse(text); err == nil {
+			t.Errorf("Expected an error for %q", text)
+		}
+	}
+}
+
+// The list item and condition parameters share a namespace with the fields of every action.
+func TestReservedParamNames(t *testing.T) {
+	for i := 0; i < actionKinds.Len(); i++ {
+		fields := actionKinds.Get(i).Message().Fields()
+		for _, reserved := range []string{conditionParam, hideParam, notesParam, doAtParam} {
+			if fields.ByName(protoreflect.Name(reserved)) != nil {
+				t.Errorf("Action %s has a field named %q", actionKinds.Get(i).Name(), reserved)
+			}
+		}
+	}
+	if valueKinds.ByName(emptyKeyword) != nil || actionKinds.ByName(emptyKeyword) != nil {
+		t.Errorf("%q is used as a value or action kind", emptyKeyword)
+	}
+}
+
+// Round trips every action and value kind with all of its fields set.
+func TestAllKindsRoundTrip(t *testing.T) {
+	rotation := &proto.APLRotation{Type: proto.APLRotation_TypeAPL}
+	for i := 0; i < actionKinds.Len(); i++ {
+		action := &proto.APLAction{}
+		fill(action.ProtoReflect().Mutable(actionKinds.Get(i)).Message(), 0)
+		action.Condition = constValue("true")
+		rotation.PriorityList = append(rotation.PriorityList, &proto.APLListItem{Action: action, Hide: true, Notes: "a \"note\""})
+	}
+	for i := 0; i < valueKinds.Len(); i++ {
+		value := &proto.APLValue{}
+		fill(value.ProtoReflect().Mutable(valueKinds.Get(i)).Message(), 0)
+		rotation.ValueVariables = append(rotation.ValueVariables, &proto.APLValueVariable{Name: string(valueKinds.Get(i).Name()), Value: value})
+	}
+	checkRoundTrip(t, "all kinds", rotation)
+}
+
+func TestPresetsRoundTrip(t *testing.T) {
+	files, err := filepath.Glob("../../ui/*/*/apls/*.apl.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) == 0 {
+		t.Fatal("No preset APLs found")
+	}
+
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rotation, err := ParseJSON(data)
+		if err != nil {
+			t.Errorf("Failed to parse %s: %v", file, err)
+			continue
+		}
+		checkRoundTrip(t, file, rotation)
+	}
+}
+
+func checkRoundTrip(t *testing.T, name string, rotation *proto.APLRotation) {
+	text := Format(rotation)
+	parsed, err := Parse(text)
+	if err != nil {
+		t.Errorf("Failed to parse the text form of %s: %v\n%s", name, err, text)
+		return
+	}
+	if !goproto.Equal(rotation, parsed) {
+		t.Errorf("Text form of %s does not round trip:\n%s", name, text)
+	}
+
+	data, err := FormatJSON(parsed)
+	if err != nil {
+		t.Errorf("Failed to format %s as json: %v", name, err)
+		return
+	}
+	fromJSON, err := ParseJSON(data)
+	if err != nil || !goproto.Equal(rotation, fromJSON) {
+		t.Errorf("JSON form of %s does not round trip: %v", name, err)
+	}
+}
+
+// Sets every field of the message, using simple values below the first level.
+func fill(msg protoreflect.Message, depth int) {
+	seenOneofs := []protoreflect.OneofDescriptor{}
+	for _, fd := range sortedFields(msg.Descriptor()) {
+		if oneof := fd.ContainingOneof(); oneof != nil && !oneof.IsSynthetic() {
+			if slices.Contains(seenOneofs, oneof) {
+				continue
+			}
+			seenOneofs = append(seenOneofs, oneof)
+		}
+		if fd.IsList() {
+			list := msg.Mutable(fd).List()
+			for range 2 {
+				list.Append(sampleValue(list.NewElement(), fd, depth))
+			}
+			continue
+		}
+		msg.Set(fd, sampleValue(msg.NewField(fd), fd, depth))
+	}
+}
+
+func sampleValue(empty protoreflect.Value, fd protoreflect.FieldDescriptor, depth int) protoreflect.Value {
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		return protoreflect.ValueOfBool(true)
+	case protoreflect.EnumKind:
+		values := fd.Enum().Values()
+		return protoreflect.ValueOfEnum(values.Get(values.Len() - 1).Number())
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return protoreflect.ValueOfInt32(-3)
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return protoreflect.ValueOfInt64(-3)
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return protoreflect.ValueOfUint32(3)
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return protoreflect.ValueOfUint64(3)
+	case protoreflect.FloatKind:
+		return protoreflect.ValueOfFloat32(1.5)
+	case protoreflect.DoubleKind:
+		return protoreflect.ValueOfFloat64(0.25)
+	case protoreflect.StringKind:
+		return protoreflect.ValueOfString("a b")
+	}
+
+	switch msg := empty.Message().Interface().(type) {
+	case *proto.APLValue:
+		msg.Value = constValue("-1.5s").Value
+	case *proto.APLAction:
+		msg.Action = &proto.APLAction_CastSpell{CastSpell: &proto.APLActionCastSpell{SpellId: &proto.ActionID{RawId: &proto.ActionID_ItemId{ItemId: 5512}}}}
+	case *proto.ActionID:
+		msg.RawId = &proto.ActionID_SpellId{SpellId: 12345}
+		msg.Tag = -1
+	case *proto.UnitReference:
+		msg.Type = proto.UnitReference_Pet
+		msg.Index = 2
+		msg.Owner = &proto.UnitReference{Type: proto.UnitReference_Player}
+	default:
+		if depth < 3 {
+			fill(empty.Message(), depth+1)
+		}
+	}
+	return empty
+}
@@ -0,0 +1,374 @@
+package apltext
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/wowsims/mop/sim/core/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+var (
+	identRegex  = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+	numberRegex = regexp.MustCompile(`^-?(\d+\.?\d*|\.\d+)[a-zA-Z%]*$`)
+)
+
+// Operator precedence, from loosest to tightest binding.
+const (
+	precOr = iota + 1
+	precAnd
+	precCmp
+	precAdd
+	precMul
+	precUnary
+	precPrimary
+)
+
+var compareSymbols = map[proto.APLValueCompare_ComparisonOperator]string{}
+var mathSymbols = map[proto.APLValueMath_MathOperator]string{}
+
+func init() {
+	for symbol, op := range compareOperators {
+		compareSymbols[op] = symbol
+	}
+	for symbol, op := range mathOperators {
+		mathSymbols[op] = symbol
+	}
+}
+
+// Format converts a rotation into its text form, one statement per line.
+//
+// Value UUIDs are only used by the UI editor and are not part of the text form.
+func Format(rotation *proto.APLRotation) string {
+	var sections [][]string
+
+	var header []string
+	if rotation.Type != proto.APLRotation_TypeUnknown {
+		header = append(header, "type="+rotation.Type.String())
+	}
+	if rotation.Simple != nil {
+		header = append(header, "simple="+formatMessage(rotation.Simple.ProtoReflect()))
+	}
+	sections = append(sections, header)
+
+	var variables []string
+	for _, variable := range rotation.ValueVariables {
+		variables = append(variables, "variable."+formatVariable(variable))
+	}
+	sections = append(sections, variables)
+
+	for _, group := range rotation.Groups {
+		prefix := "group." + formatName(group.Name)
+		lines := []string{}
+		for _, variable := range group.Variables {
+			lines = append(lines, prefix+".variable."+formatVariable(variable))
+		}
+		for _, item := range group.Actions {
+			lines = append(lines, prefix+"+=/"+formatListItem(item))
+		}
+		if len(lines) == 0 {
+			lines = append(lines, prefix)
+		}
+		sections = append(sections, lines)
+	}
+
+	var prepull []string
+	for _, action := range rotation.PrepullActions {
+		prepull = append(prepull, "prepull+=/"+formatPrepullAction(action))
+	}
+	sections = append(sections, prepull)
+
+	var actions []string
+	for _, item := range rotation.PriorityList {
+		actions = append(actions, "actions+=/"+formatListItem(item))
+	}
+	sections = append(sections, actions)
+
+	var sb strings.Builder
+	for _, lines := range sections {
+		if len(lines) == 0 {
+			continue
+		}
+		if sb.Len() > 0 {
+			sb.WriteString("\n")
+		}
+		for _, line := range lines {
+			sb.WriteString(line)
+			sb.WriteString("\n")
+		}
+	}
+	return sb.String()
+}
+
+// FormatValue converts a single value into its text form.
+func FormatValue(value *proto.APLValue) string {
+	text, _ := formatValue(value)
+	return text
+}
+
+func formatName(name string) string {
+	if identRegex.MatchString(name) {
+		return name
+	}
+	return strconv.Quote(name)
+}
+
+func formatVariable(variable *proto.APLValueVariable) string {
+	if variable.Value == nil {
+		return formatName(variable.Name)
+	}
+	return formatName(variable.Name) + "=" + FormatValue(variable.Value)
+}
+
+func formatListItem(item *proto.APLListItem) string {
+	params := formatAction(item.Action)
+	if item.Hide {
+		params = append(params, hideParam)
+	}
+	if item.Notes != "" {
+		params = append(params, notesParam+"="+strconv.Quote(item.Notes))
+	}
+	return strings.Join(params, ",")
+}
+
+func formatPrepullAction(prepull *proto.APLPrepullAction) string {
+	params := formatAction(prepull.Action)
+	if prepull.DoAtValue != nil {
+		params = append(params, doAtParam+"="+FormatValue(prepull.DoAtValue))
+	}
+	if prepull.Hide {
+		params = append(params, hideParam)
+	}
+	return strings.Join(params, ",")
+}
+
+// Returns the action kind followed by its parameters and condition.
+func formatAction(action *proto.APLAction) []string {
+	if action == nil {
+		return []string{emptyKeyword}
+	}
+
+	msg := action.ProtoReflect()
+	params := []string{emptyKeyword}
+	if fd := msg.WhichOneof(actionKinds.Get(0).ContainingOneof()); fd != nil {
+		params = []string{string(fd.Name())}
+		params = append(params, formatParams(msg.Get(fd).Message())...)
+	}
+	if action.Condition != nil {
+		params = append(params, conditionParam+"="+FormatValue(action.Condition))
+	}
+	return params
+}
+
+// Returns the set fields of a message as name=value, or just the name for true bools.
+func formatParams(msg protoreflect.Message) []string {
+	var params []string
+	for _, fd := range sortedFields(msg.Descriptor()) {
+		if !msg.Has(fd) {
+			continue
+		}
+		if fd.Kind() == protoreflect.BoolKind && !fd.IsList() && msg.Get(fd).Bool() {
+			params = append(params, string(fd.Name()))
+			continue
+		}
+		params = append(params, string(fd.Name())+"="+formatField(msg, fd))
+	}
+	return params
+}
+
+// Returns the text form of the value along with its precedence.
+func formatValue(value *proto.APLValue) (string, int) {
+	switch v := value.Value.(type) {
+	case nil:
+		return emptyKeyword, precPrimary
+	case *proto.APLValue_Const:
+		// Constants with a tunable range are written as calls to keep the range.
+		if v.Const.Tunable == nil {
+			return formatConst(v.Const.Val), precPrimary
+		}
+	case *proto.APLValue_Or:
+		if len(v.Or.Vals) >= 2 {
+			return formatOperands(v.Or.Vals, " || ", precOr), precOr
+		}
+	case *proto.APLValue_And:
+		if len(v.And.Vals) >= 2 {
+			return formatOperands(v.And.Vals, " && ", precAnd), precAnd
+		}
+	case *proto.APLValue_Not:
+		if v.Not.Val != nil {
+			return "!" + formatOperand(v.Not.Val, precUnary), precUnary
+		}
+	case *proto.APLValue_Cmp:
+		if symbol, ok := compareSymbols[v.Cmp.Op]; ok && v.Cmp.Lhs != nil && v.Cmp.Rhs != nil {
+			return formatOperand(v.Cmp.Lhs, precCmp+1) + symbol + formatOperand(v.Cmp.Rhs, precCmp+1), precCmp
+		}
+	case *proto.APLValue_Math:
+		if symbol, ok := mathSymbols[v.Math.Op]; ok && v.Math.Lhs != nil && v.Math.Rhs != nil {
+			prec := precAdd
+			if v.Math.Op == proto.APLValueMath_OpMul || v.Math.Op == proto.APLValueMath_OpDiv {
+				prec = precMul
+			}
+			return formatOperand(v.Math.Lhs, prec) + symbol + formatOperand(v.Math.Rhs, prec+1), prec
+		}
+	}
+
+	// Everything else, including operators that are missing operands, is written as a call.
+	msg := value.ProtoReflect()
+	fd := msg.WhichOneof(valueKinds.Get(0).ContainingOneof())
+	return string(fd.Name()) + "(" + strings.Join(formatArgs(msg.Get(fd).Message()), ", ") + ")", precPrimary
+}
+
+// Wraps the value in parentheses if it binds looser than minPrec.
+func formatOperand(value *proto.APLValue, minPrec int) string {
+	text, prec := formatValue(value)
+	if prec < minPrec {
+		return "(" + text + ")"
+	}
+	return text
+}
+
+// Nested operators of the same kind are parenthesized, so a && (b && c) keeps its structure.
+func formatOperands(vals []*proto.APLValue, separator string, prec int) string {
+	operands := make([]string, len(vals))
+	for i, val := range vals {
+		operands[i] = formatOperand(val, prec+1)
+	}
+	return strings.Join(operands, separator)
+}
+
+func formatConst(val string) string {
+	if numberRegex.MatchString(val) || val == "true" || val == "false" {
+		return val
+	}
+	return strconv.Quote(val)
+}
+
+// Leading set fields are positional and the rest are named, e.g. aura_num_stacks(123, include_reaction_time=true).
+func formatArgs(msg protoreflect.Message) []string {
+	fields := sortedFields(msg.Descriptor())
+	if spreadsList(fields) {
+		list := msg.Get(fields[0]).List()
+		args := make([]string, list.Len())
+		for i := range args {
+			args[i] = formatSingular(fields[0], list.Get(i))
+		}
+		return args
+	}
+
+	var args []string
+	positional := true
+	for _, fd := range fields {
+		if oneof := fd.ContainingOneof(); oneof != nil && !oneof.IsSynthetic() {
+			positional = false
+		}
+		if !msg.Has(fd) {
+			positional = false
+			continue
+		}
+		if positional {
+			args = append(args, formatField(msg, fd))
+		} else {
+			args = append(args, string(fd.Name())+"="+formatField(msg, fd))
+		}
+	}
+	return args
+}
+
+func formatField(msg protoreflect.Message, fd protoreflect.FieldDescriptor) string {
+	if fd.IsList() {
+		list := msg.Get(fd).List()
+		items := make([]string, list.Len())
+		for i := range items {
+			items[i] = formatSingular(fd, list.Get(i))
+		}
+		return "[" + strings.Join(items, ", ") + "]"
+	}
+	return formatSingular(fd, msg.Get(fd))
+}
+
+func formatSingular(fd protoreflect.FieldDescriptor, value protoreflect.Value) string {
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		return strconv.FormatBool(value.Bool())
+	case protoreflect.EnumKind:
+		if enumValue := fd.Enum().Values().ByNumber(value.Enum()); enumValue != nil {
+			return string(enumValue.Name())
+		}
+		return strconv.Itoa(int(value.Enum()))
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return strconv.FormatInt(value.Int(), 10)
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind, protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return strconv.FormatUint(value.Uint(), 10)
+	case protoreflect.FloatKind:
+		return strconv.FormatFloat(value.Float(), 'f', -1, 32)
+	case protoreflect.DoubleKind:
+		return strconv.FormatFloat(value.Float(), 'f', -1, 64)
+	case protoreflect.StringKind:
+		return strconv.Quote(value.String())
+	case protoreflect.MessageKind:
+		return formatMessage(value.Message())
+	}
+	panic(fmt.Sprintf("unsupported field kind %s for %s", fd.Kind(), fd.FullName()))
+}
+
+func formatMessage(msg protoreflect.Message) string {
+	switch m := msg.Interface().(type) {
+	case *proto.APLValue:
+		return FormatValue(m)
+	case *proto.APLAction:
+		return "{" + strings.Join(formatAction(m), ",") + "}"
+	case *proto.ActionID:
+		if text, ok := formatActionID(m); ok {
+			return text
+		}
+	case *proto.UnitReference:
+		if text, ok := formatUnitReference(m); ok {
+			return text
+		}
+	}
+	if params := formatParams(msg); len(params) > 0 {
+		return "{" + strings.Join(params, ",") + "}"
+	}
+	switch msg.Interface().(type) {
+	case *proto.ActionID, *proto.UnitReference:
+		return emptyKeyword
+	}
+	return "{}"
+}
+
+func formatActionID(id *proto.ActionID) (string, bool) {
+	var kind, rawID string
+	switch raw := id.RawId.(type) {
+	case *proto.ActionID_SpellId:
+		if id.Tag == 0 {
+			return strconv.Itoa(int(raw.SpellId)), true
+		}
+		kind, rawID = "spell", strconv.Itoa(int(raw.SpellId))
+	case *proto.ActionID_ItemId:
+		kind, rawID = "item", strconv.Itoa(int(raw.ItemId))
+	case *proto.ActionID_OtherId:
+		kind, rawID = "other", raw.OtherId.String()
+	default:
+		return "", false
+	}
+	if id.Tag != 0 {
+		return fmt.Sprintf("%s(%s, %d)", kind, rawID, id.Tag), true
+	}
+	return fmt.Sprintf("%s(%s)", kind, rawID), true
+}
+
+func formatUnitReference(unit *proto.UnitReference) (string, bool) {
+	if unit.Type == proto.UnitReference_Unknown {
+		return "", false
+	}
+	switch {
+	case unit.Owner != nil:
+		return fmt.Sprintf("%s(%d, %s)", unit.Type, unit.Index, formatMessage(unit.Owner.ProtoReflect())), true
+	case unit.Index != 0:
+		return fmt.Sprintf("%s(%d)", unit.Type, unit.Index), true
+	}
+	return unit.Type.String(), true
+}
@@ -0,0 +1,153 @@
+package apltext
+
+import (
+	"github.com/alecthomas/participle/v2"
+	"github.com/alecthomas/participle/v2/lexer"
+)
+
+var aplLexer = lexer.MustSimple([]lexer.SimpleRule{
+	{Name: "Comment", Pattern: `#[^\n]*`},
+	{Name: "String", Pattern: `"(\\.|[^"\\])*"`},
+	{Name: "Number", Pattern: `(\d+\.?\d*|\.\d+)[a-zA-Z%]*`},
+	{Name: "Ident", Pattern: `[a-zA-Z_][a-zA-Z0-9_]*`},
+	{Name: "Op", Pattern: `\+=|==|!=|<=|>=|&&|\|\||[-+*/<>!=(){}\[\],.]`},
+	{Name: "Whitespace", Pattern: `\s+`},
+})
+
+var aplParser = participle.MustBuild[aplFile](
+	participle.Lexer(aplLexer),
+	participle.Elide("Comment", "Whitespace"),
+	participle.UseLookahead(3),
+)
+
+type aplFile struct {
+	Statements []*statement `parser:"@@*"`
+}
+
+type statement struct {
+	Pos lexer.Position
+
+	Type     *string       `parser:"  'type' '=' @Ident"`
+	Simple   *braced       `parser:"| 'simple' '=' @@"`
+	Variable *variableDecl `parser:"| 'variable' '.' @@"`
+	Group    *groupStmt    `parser:"| 'group' '.' @@"`
+	Prepull  *listItem     `parser:"| 'prepull' '+=' '/' @@"`
+	Action   *listItem     `parser:"| 'actions' '+=' '/' @@"`
+}
+
+// Variable and group names, quoted unless they are identifiers.
+type name struct {
+	Ident  *string `parser:"  @Ident"`
+	String *string `parser:"| @String"`
+}
+
+type variableDecl struct {
+	Pos lexer.Position
+
+	Name  *name   `parser:"@@"`
+	Value *orExpr `parser:"( '=' @@ )?"`
+}
+
+type groupStmt struct {
+	Name     *name         `parser:"@@"`
+	Action   *listItem     `parser:"(   '+=' '/' @@"`
+	Variable *variableDecl `parser:"  | '.' 'variable' '.' @@ )?"`
+}
+
+// An action kind followed by its parameters, e.g. cast_spell,spell_id=123,if=...
+type listItem struct {
+	Pos lexer.Position
+
+	Kind   string   `parser:"@Ident"`
+	Params []*param `parser:"( ',' @@ )*"`
+}
+
+// A name=value parameter, or a bare name for flags.
+type param struct {
+	Pos lexer.Position
+
+	Name  string  `parser:"@Ident"`
+	Value *orExpr `parser:"( '=' @@ )?"`
+}
+
+type orExpr struct {
+	Pos lexer.Position
+
+	Operands []*andExpr `parser:"@@ ( '||' @@ )*"`
+}
+
+type andExpr struct {
+	Operands []*cmpExpr `parser:"@@ ( '&&' @@ )*"`
+}
+
+type cmpExpr struct {
+	Left  *addExpr `parser:"@@"`
+	Op    string   `parser:"( @( '==' | '!=' | '<=' | '>=' | '<' | '>' )"`
+	Right *addExpr `parser:"  @@ )?"`
+}
+
+type addExpr struct {
+	Left *mulExpr   `parser:"@@"`
+	Rest []*addTerm `parser:"@@*"`
+}
+
+type addTerm struct {
+	Op    string   `parser:"@( '+' | '-' )"`
+	Right *mulExpr `parser:"@@"`
+}
+
+type mulExpr struct {
+	Left *unaryExpr `parser:"@@"`
+	Rest []*mulTerm `parser:"@@*"`
+}
+
+type mulTerm struct {
+	Op    string     `parser:"@( '*' | '/' )"`
+	Right *unaryExpr `parser:"@@"`
+}
+
+type unaryExpr struct {
+	Pos lexer.Position
+
+	Op      string     `parser:"(   @( '!' | '-' )"`
+	Operand *unaryExpr `parser:"    @@ )"`
+	Primary *primary   `parser:"| @@"`
+}
+
+type primary struct {
+	Pos lexer.Position
+
+	Number *string `parser:"  @Number"`
+	String *string `parser:"| @String"`
+	Call   *call   `parser:"| @@"`
+	Ident  *string `parser:"| @Ident"`
+	List   *list   `parser:"| @@"`
+	Braced *braced `parser:"| @@"`
+	Paren  *orExpr `parser:"| '(' @@ ')'"`
+}
+
+// Value kinds, ActionIDs and UnitReferences, e.g. aura_remaining_time(123, Target).
+type call struct {
+	Name string `parser:"@Ident '('"`
+	Args []*arg `parser:"( @@ ( ',' @@ )* )? ')'"`
+}
+
+type arg struct {
+	Pos lexer.Position
+
+	Name  *string `parser:"( @Ident '=' )?"`
+	Value *orExpr `parser:"@@"`
+}
+
+type list struct {
+	Open  string    `parser:"@'['"`
+	Items []*orExpr `parser:"( @@ ( ',' @@ )* )? ']'"`
+}
+
+// Nested actions and other messages, e.g. {cast_spell,spell_id=123}.
+type braced struct {
+	Pos lexer.Position
+
+	Open   string   `parser:"@'{'"`
+	Params []*param `parser:"( @@ ( ',' @@ )* )? '}'"`
+}
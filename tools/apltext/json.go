@@ -0,0 +1,95 @@
+package apltext
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/wowsims/mop/sim/core/proto"
+	"google.golang.org/protobuf/encoding/protojson"
+	goproto "google.golang.org/protobuf/proto"
+)
+
+// ParseJSON reads a rotation in protojson format, such as a spec preset .apl.json file.
+// Like the UI, it ignores fields of removed action and value options.
+func ParseJSON(data []byte) (*proto.APLRotation, error) {
+	rotation := &proto.APLRotation{}
+	if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(data, rotation); err != nil {
+		return nil, err
+	}
+	return rotation, nil
+}
+
+// FormatJSON writes a rotation in the layout of the spec preset .apl.json files,
+// with every prepull action, list item, group and variable on its own line.
+func FormatJSON(rotation *proto.APLRotation) ([]byte, error) {
+	var buf bytes.Buffer
+	var fields [][]byte
+
+	if rotation.Type != proto.APLRotation_TypeUnknown {
+		fields = append(fields, []byte(fmt.Sprintf("\t\"type\": %q", rotation.Type.String())))
+	}
+	if rotation.Simple != nil {
+		simple, err := compactJSON(rotation.Simple)
+		if err != nil {
+			return nil, err
+		}
+		fields = append(fields, append([]byte("\t\"simple\": "), simple...))
+	}
+
+	addList := func(key string, count int, element func(i int) goproto.Message) error {
+		if count == 0 {
+			return nil
+		}
+		var field bytes.Buffer
+		fmt.Fprintf(&field, "\t%q: [\n", key)
+		for i := 0; i < count; i++ {
+			data, err := compactJSON(element(i))
+			if err != nil {
+				return err
+			}
+			field.WriteString("\t\t")
+			field.Write(data)
+			if i < count-1 {
+				field.WriteString(",")
+			}
+			field.WriteString("\n")
+		}
+		field.WriteString("\t]")
+		fields = append(fields, field.Bytes())
+		return nil
+	}
+	if err := addList("prepullActions", len(rotation.PrepullActions), func(i int) goproto.Message { return rotation.PrepullActions[i] }); err != nil {
+		return nil, err
+	}
+	if err := addList("priorityList", len(rotation.PriorityList), func(i int) goproto.Message { return rotation.PriorityList[i] }); err != nil {
+		return nil, err
+	}
+	if err := addList("groups", len(rotation.Groups), func(i int) goproto.Message { return rotation.Groups[i] }); err != nil {
+		return nil, err
+	}
+	if err := addList("valueVariables", len(rotation.ValueVariables), func(i int) goproto.Message { return rotation.ValueVariables[i] }); err != nil {
+		return nil, err
+	}
+
+	buf.WriteString("{\n")
+	buf.Write(bytes.Join(fields, []byte(",\n")))
+	if len(fields) > 0 {
+		buf.WriteString("\n")
+	}
+	buf.WriteString("}\n")
+	return buf.Bytes(), nil
+}
+
+// protojson deliberately varies its whitespace, so compact it for stable output.
+func compactJSON(msg goproto.Message) ([]byte, error) {
+	data, err := protojson.Marshal(msg)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, data); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
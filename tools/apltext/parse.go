@@ -0,0 +1,714 @@
+package apltext
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/alecthomas/participle/v2"
+	"github.com/alecthomas/participle/v2/lexer"
+	"github.com/wowsims/mop/sim/core/proto"
+	goproto "google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+var (
+	valueKinds  = (&proto.APLValue{}).ProtoReflect().Descriptor().Oneofs().ByName("value").Fields()
+	actionKinds = (&proto.APLAction{}).ProtoReflect().Descriptor().Oneofs().ByName("action").Fields()
+)
+
+var (
+	aplValueName      = (&proto.APLValue{}).ProtoReflect().Descriptor().FullName()
+	aplActionName     = (&proto.APLAction{}).ProtoReflect().Descriptor().FullName()
+	actionIDName      = (&proto.ActionID{}).ProtoReflect().Descriptor().FullName()
+	unitReferenceName = (&proto.UnitReference{}).ProtoReflect().Descriptor().FullName()
+)
+
+var compareOperators = map[string]proto.APLValueCompare_ComparisonOperator{
+	"==": proto.APLValueCompare_OpEq,
+	"!=": proto.APLValueCompare_OpNe,
+	"<":  proto.APLValueCompare_OpLt,
+	"<=": proto.APLValueCompare_OpLe,
+	">":  proto.APLValueCompare_OpGt,
+	">=": proto.APLValueCompare_OpGe,
+}
+
+var mathOperators = map[string]proto.APLValueMath_MathOperator{
+	"+": proto.APLValueMath_OpAdd,
+	"-": proto.APLValueMath_OpSub,
+	"*": proto.APLValueMath_OpMul,
+	"/": proto.APLValueMath_OpDiv,
+}
+
+// Written in place of an empty action, value or other message.
+const emptyKeyword = "none"
+
+// Parameter names that don't belong to the action's own message.
+const (
+	conditionParam = "if"
+	hideParam      = "hide"
+	notesParam     = "notes"
+	doAtParam      = "at"
+)
+
+// Parse converts the text form of a rotation into an APLRotation.
+func Parse(text string) (*proto.APLRotation, error) {
+	file, err := aplParser.ParseString("", text)
+	if err != nil {
+		return nil, err
+	}
+
+	rotation := &proto.APLRotation{}
+	groups := make(map[string]*proto.APLGroup)
+	for _, st := range file.Statements {
+		switch {
+		case st.Type != nil:
+			value, ok := proto.APLRotation_Type_value[*st.Type]
+			if !ok {
+				return nil, participle.Errorf(st.Pos, "unknown rotation type %q", *st.Type)
+			}
+			rotation.Type = proto.APLRotation_Type(value)
+		case st.Simple != nil:
+			rotation.Simple = &proto.SimpleRotation{}
+			if err := setParams(rotation.Simple.ProtoReflect(), st.Simple.Params); err != nil {
+				return nil, err
+			}
+		case st.Variable != nil:
+			variable, err := st.Variable.toVariable()
+			if err != nil {
+				return nil, err
+			}
+			rotation.ValueVariables = append(rotation.ValueVariables, variable)
+		case st.Group != nil:
+			groupName, err := st.Group.Name.value()
+			if err != nil {
+				return nil, err
+			}
+			group, ok := groups[groupName]
+			if !ok {
+				group = &proto.APLGroup{Name: groupName}
+				groups[groupName] = group
+				rotation.Groups = append(rotation.Groups, group)
+			}
+			if st.Group.Action != nil {
+				item, err := st.Group.Action.toListItem()
+				if err != nil {
+					return nil, err
+				}
+				group.Actions = append(group.Actions, item)
+			} else if st.Group.Variable != nil {
+				variable, err := st.Group.Variable.toVariable()
+				if err != nil {
+					return nil, err
+				}
+				group.Variables = append(group.Variables, variable)
+			}
+		case st.Prepull != nil:
+			prepull, err := st.Prepull.toPrepullAction()
+			if err != nil {
+				return nil, err
+			}
+			rotation.PrepullActions = append(rotation.PrepullActions, prepull)
+		case st.Action != nil:
+			item, err := st.Action.toListItem()
+			if err != nil {
+				return nil, err
+			}
+			rotation.PriorityList = append(rotation.PriorityList, item)
+		}
+	}
+	return rotation, nil
+}
+
+// ParseValue converts the text form of a single value, e.g. aura_remaining_time(123)<2s.
+func ParseValue(text string) (*proto.APLValue, error) {
+	rotation, err := Parse("variable.v=" + text)
+	if err != nil {
+		return nil, err
+	}
+	if len(rotation.ValueVariables) != 1 {
+		return nil, fmt.Errorf("expected a single value in %q", text)
+	}
+	return rotation.ValueVariables[0].Value, nil
+}
+
+func (n *name) value() (string, error) {
+	if n.Ident != nil {
+		return *n.Ident, nil
+	}
+	return strconv.Unquote(*n.String)
+}
+
+func (v *variableDecl) toVariable() (*proto.APLValueVariable, error) {
+	variableName, err := v.Name.value()
+	if err != nil {
+		return nil, participle.Errorf(v.Pos, "invalid variable name: %v", err)
+	}
+	variable := &proto.APLValueVariable{Name: variableName}
+	if v.Value != nil {
+		if variable.Value, err = v.Value.toValue(); err != nil {
+			return nil, err
+		}
+	}
+	return variable, nil
+}
+
+func (item *listItem) toListItem() (*proto.APLListItem, error) {
+	result := &proto.APLListItem{}
+	action, err := toAction(item.Pos, item.Kind, item.Params, func(p *param) (bool, error) {
+		switch p.Name {
+		case hideParam:
+			result.Hide = true
+			return true, nil
+		case notesParam:
+			notes, err := p.Value.stringLiteral()
+			result.Notes = notes
+			return true, err
+		}
+		return false, nil
+	})
+	result.Action = action
+	return result, err
+}
+
+func (item *listItem) toPrepullAction() (*proto.APLPrepullAction, error) {
+	result := &proto.APLPrepullAction{}
+	action, err := toAction(item.Pos, item.Kind, item.Params, func(p *param) (bool, error) {
+		switch p.Name {
+		case hideParam:
+			result.Hide = true
+			return true, nil
+		case doAtParam:
+			if p.Value == nil {
+				return true, participle.Errorf(p.Pos, "%s needs a value", p.Name)
+			}
+			doAt, err := p.Value.toValue()
+			result.DoAtValue = doAt
+			return true, err
+		}
+		return false, nil
+	})
+	result.Action = action
+	return result, err
+}
+
+// Builds an action of the given kind. itemParam handles the parameters that
+// belong to the enclosing list item rather than the action, if any.
+func toAction(pos lexer.Position, kind string, params []*param, itemParam func(*param) (bool, error)) (*proto.APLAction, error) {
+	action := &proto.APLAction{}
+	var kindMsg protoreflect.Message
+	if kind != emptyKeyword {
+		fd := actionKinds.ByName(protoreflect.Name(kind))
+		if fd == nil {
+			return nil, participle.Errorf(pos, "unknown action %q", kind)
+		}
+		kindMsg = action.ProtoReflect().Mutable(fd).Message()
+	}
+
+	for _, p := range params {
+		if p.Name == conditionParam {
+			if p.Value == nil {
+				return nil, participle.Errorf(p.Pos, "%s needs a value", p.Name)
+			}
+			condition, err := p.Value.toValue()
+			if err != nil {
+				return nil, err
+			}
+			action.Condition = condition
+			continue
+		}
+		if itemParam != nil {
+			if handled, err := itemParam(p); err != nil {
+				return nil, err
+			} else if handled {
+				continue
+			}
+		}
+		if kindMsg == nil {
+			return nil, participle.Errorf(p.Pos, "unknown parameter %q", p.Name)
+		}
+		if err := setParams(kindMsg, []*param{p}); err != nil {
+			return nil, err
+		}
+	}
+	return action, nil
+}
+
+func (e *orExpr) toValue() (*proto.APLValue, error) {
+	if len(e.Operands) == 1 {
+		return e.Operands[0].toValue()
+	}
+	vals := make([]*proto.APLValue, len(e.Operands))
+	for i, operand := range e.Operands {
+		val, err := operand.toValue()
+		if err != nil {
+			return nil, err
+		}
+		vals[i] = val
+	}
+	return &proto.APLValue{Value: &proto.APLValue_Or{Or: &proto.APLValueOr{Vals: vals}}}, nil
+}
+
+func (e *andExpr) toValue() (*proto.APLValue, error) {
+	if len(e.Operands) == 1 {
+		return e.Operands[0].toValue()
+	}
+	vals := make([]*proto.APLValue, len(e.Operands))
+	for i, operand := range e.Operands {
+		val, err := operand.toValue()
+		if err != nil {
+			return nil, err
+		}
+		vals[i] = val
+	}
+	return &proto.APLValue{Value: &proto.APLValue_And{And: &proto.APLValueAnd{Vals: vals}}}, nil
+}
+
+func (e *cmpExpr) toValue() (*proto.APLValue, error) {
+	lhs, err := e.Left.toValue()
+	if err != nil || e.Op == "" {
+		return lhs, err
+	}
+	rhs, err := e.Right.toValue()
+	if err != nil {
+		return nil, err
+	}
+	return &proto.APLValue{Value: &proto.APLValue_Cmp{Cmp: &proto.APLValueCompare{Op: compareOperators[e.Op], Lhs: lhs, Rhs: rhs}}}, nil
+}
+
+func (e *addExpr) toValue() (*proto.APLValue, error) {
+	result, err := e.Left.toValue()
+	if err != nil {
+		return nil, err
+	}
+	for _, term := range e.Rest {
+		rhs, err := term.Right.toValue()
+		if err != nil {
+			return nil, err
+		}
+		result = &proto.APLValue{Value: &proto.APLValue_Math{Math: &proto.APLValueMath{Op: mathOperators[term.Op], Lhs: result, Rhs: rhs}}}
+	}
+	return result, nil
+}
+
+func (e *mulExpr) toValue() (*proto.APLValue, error) {
+	result, err := e.Left.toValue()
+	if err != nil {
+		return nil, err
+	}
+	for _, term := range e.Rest {
+		rhs, err := term.Right.toValue()
+		if err != nil {
+			return nil, err
+		}
+		result = &proto.APLValue{Value: &proto.APLValue_Math{Math: &proto.APLValueMath{Op: mathOperators[term.Op], Lhs: result, Rhs: rhs}}}
+	}
+	return result, nil
+}
+
+func (e *unaryExpr) toValue() (*proto.APLValue, error) {
+	if number := e.number(); number != nil {
+		return constValue(*number), nil
+	}
+	switch e.Op {
+	case "":
+		return e.Primary.toValue()
+	case "!":
+		val, err := e.Operand.toValue()
+		if err != nil {
+			return nil, err
+		}
+		return &proto.APLValue{Value: &proto.APLValue_Not{Not: &proto.APLValueNot{Val: val}}}, nil
+	}
+	return nil, participle.Errorf(e.Pos, "unary minus can only be applied to numbers")
+}
+
+// Returns the number literal this expression consists of, folding in a leading minus.
+func (e *unaryExpr) number() *string {
+	if e.Op == "" {
+		return e.Primary.Number
+	}
+	if e.Op == "-" && e.Operand.Op == "" && e.Operand.Primary.Number != nil {
+		number := "-" + *e.Operand.Primary.Number
+		return &number
+	}
+	return nil
+}
+
+func (p *primary) toValue() (*proto.APLValue, error) {
+	switch {
+	case p.Number != nil:
+		return constValue(*p.Number), nil
+	case p.String != nil:
+		val, err := strconv.Unquote(*p.String)
+		if err != nil {
+			return nil, participle.Errorf(p.Pos, "invalid string: %v", err)
+		}
+		return constValue(val), nil
+	case p.Ident != nil:
+		switch *p.Ident {
+		case "true", "false":
+			return constValue(*p.Ident), nil
+		case emptyKeyword:
+			return &proto.APLValue{}, nil
+		}
+		return nil, participle.Errorf(p.Pos, "unknown value %q, values are written as calls like %s()", *p.Ident, *p.Ident)
+	case p.Call != nil:
+		fd := valueKinds.ByName(protoreflect.Name(p.Call.Name))
+		if fd == nil {
+			return nil, participle.Errorf(p.Pos, "unknown value %q", p.Call.Name)
+		}
+		value := &proto.APLValue{}
+		if err := setArgs(p.Pos, value.ProtoReflect().Mutable(fd).Message(), p.Call.Args); err != nil {
+			return nil, err
+		}
+		return value, nil
+	case p.Paren != nil:
+		return p.Paren.toValue()
+	}
+	return nil, participle.Errorf(p.Pos, "expected a value")
+}
+
+func constValue(val string) *proto.APLValue {
+	return &proto.APLValue{Value: &proto.APLValue_Const{Const: &proto.APLValueConst{Val: val}}}
+}
+
+// Returns the single primary this expression consists of, or nil if it has operators.
+// A minus in front of a number is folded into the number.
+func (e *orExpr) primary() *primary {
+	if len(e.Operands) != 1 || len(e.Operands[0].Operands) != 1 {
+		return nil
+	}
+	cmp := e.Operands[0].Operands[0]
+	if cmp.Op != "" || len(cmp.Left.Rest) != 0 || len(cmp.Left.Left.Rest) != 0 {
+		return nil
+	}
+	unary := cmp.Left.Left.Left
+	if unary.Op == "" {
+		return unary.Primary
+	}
+	if number := unary.number(); number != nil {
+		return &primary{Pos: unary.Pos, Number: number}
+	}
+	return nil
+}
+
+func (e *orExpr) stringLiteral() (string, error) {
+	if e != nil {
+		if p := e.primary(); p != nil && p.String != nil {
+			return strconv.Unquote(*p.String)
+		}
+	}
+	var pos lexer.Position
+	if e != nil {
+		pos = e.Pos
+	}
+	return "", participle.Errorf(pos, "expected a string")
+}
+
+// Fields of a message in field number order, which is the order of positional arguments.
+func sortedFields(md protoreflect.MessageDescriptor) []protoreflect.FieldDescriptor {
+	fields := make([]protoreflect.FieldDescriptor, md.Fields().Len())
+	for i := range fields {
+		fields[i] = md.Fields().Get(i)
+	}
+	sort.Slice(fields, func(i, j int) bool { return fields[i].Number() < fields[j].Number() })
+	return fields
+}
+
+// Whether the arguments of a message are its list elements, like max(a, b).
+func spreadsList(fields []protoreflect.FieldDescriptor) bool {
+	return len(fields) == 1 && fields[0].IsList()
+}
+
+func setArgs(pos lexer.Position, msg protoreflect.Message, args []*arg) error {
+	fields := sortedFields(msg.Descriptor())
+	positional := 0
+	named := false
+	for _, a := range args {
+		if a.Name != nil {
+			named = true
+			if err := setNamed(msg, a.Pos, *a.Name, a.Value); err != nil {
+				return err
+			}
+			continue
+		}
+		if named {
+			return participle.Errorf(a.Pos, "positional argument after named arguments")
+		}
+		if spreadsList(fields) {
+			if err := appendElement(msg, fields[0], a.Value); err != nil {
+				return err
+			}
+			continue
+		}
+		if positional >= len(fields) {
+			return participle.Errorf(a.Pos, "too many arguments, %s has %d fields", msg.Descriptor().Name(), len(fields))
+		}
+		fd := fields[positional]
+		positional++
+		if err := setField(msg, fd, a.Value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func setParams(msg protoreflect.Message, params []*param) error {
+	for _, p := range params {
+		if err := setNamed(msg, p.Pos, p.Name, p.Value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Sets the named field. A missing value sets a bool field to true.
+func setNamed(msg protoreflect.Message, pos lexer.Position, fieldName string, value *orExpr) error {
+	fd := msg.Descriptor().Fields().ByName(protoreflect.Name(fieldName))
+	if fd == nil {
+		return participle.Errorf(pos, "unknown field %q for %s", fieldName, msg.Descriptor().Name())
+	}
+	if msg.Has(fd) {
+		return participle.Errorf(pos, "%q is set more than once", fieldName)
+	}
+	if value == nil {
+		if fd.Kind() != protoreflect.BoolKind || fd.IsList() {
+			return participle.Errorf(pos, "%s needs a value", fieldName)
+		}
+		msg.Set(fd, protoreflect.ValueOfBool(true))
+		return nil
+	}
+	return setField(msg, fd, value)
+}
+
+func setField(msg protoreflect.Message, fd protoreflect.FieldDescriptor, value *orExpr) error {
+	if fd.IsMap() {
+		return participle.Errorf(value.Pos, "map fields are not supported")
+	}
+	if fd.IsList() {
+		p := value.primary()
+		if p == nil || p.List == nil {
+			return participle.Errorf(value.Pos, "expected a list for %s", fd.Name())
+		}
+		for _, item := range p.List.Items {
+			if err := appendElement(msg, fd, item); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if fd.Kind() == protoreflect.MessageKind {
+		field := msg.NewField(fd)
+		if err := setMessage(field.Message(), value); err != nil {
+			return err
+		}
+		msg.Set(fd, field)
+		return nil
+	}
+	scalar, err := toScalar(fd, value)
+	if err != nil {
+		return err
+	}
+	msg.Set(fd, scalar)
+	return nil
+}
+
+func appendElement(msg protoreflect.Message, fd protoreflect.FieldDescriptor, value *orExpr) error {
+	list := msg.Mutable(fd).List()
+	if fd.Kind() == protoreflect.MessageKind {
+		element := list.NewElement()
+		if err := setMessage(element.Message(), value); err != nil {
+			return err
+		}
+		list.Append(element)
+		return nil
+	}
+	scalar, err := toScalar(fd, value)
+	if err != nil {
+		return err
+	}
+	list.Append(scalar)
+	return nil
+}
+
+func toScalar(fd protoreflect.FieldDescriptor, value *orExpr) (protoreflect.Value, error) {
+	p := value.primary()
+	if p == nil {
+		return protoreflect.Value{}, participle.Errorf(value.Pos, "expected a literal for %s", fd.Name())
+	}
+
+	var err error
+	switch kind := fd.Kind(); {
+	case kind == protoreflect.BoolKind && p.Ident != nil && (*p.Ident == "true" || *p.Ident == "false"):
+		return protoreflect.ValueOfBool(*p.Ident == "true"), nil
+	case kind == protoreflect.EnumKind && p.Ident != nil:
+		enumValue := fd.Enum().Values().ByName(protoreflect.Name(*p.Ident))
+		if enumValue == nil {
+			return protoreflect.Value{}, participle.Errorf(p.Pos, "unknown %s %q", fd.Enum().Name(), *p.Ident)
+		}
+		return protoreflect.ValueOfEnum(enumValue.Number()), nil
+	case kind == protoreflect.StringKind && p.String != nil:
+		var s string
+		if s, err = strconv.Unquote(*p.String); err == nil {
+			return protoreflect.ValueOfString(s), nil
+		}
+	case p.Number != nil:
+		switch kind {
+		case protoreflect.EnumKind:
+			var n int64
+			if n, err = strconv.ParseInt(*p.Number, 10, 32); err == nil {
+				return protoreflect.ValueOfEnum(protoreflect.EnumNumber(n)), nil
+			}
+		case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+			var n int64
+			if n, err = strconv.ParseInt(*p.Number, 10, 32); err == nil {
+				return protoreflect.ValueOfInt32(int32(n)), nil
+			}
+		case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+			var n int64
+			if n, err = strconv.ParseInt(*p.Number, 10, 64); err == nil {
+				return protoreflect.ValueOfInt64(n), nil
+			}
+		case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+			var n uint64
+			if n, err = strconv.ParseUint(*p.Number, 10, 32); err == nil {
+				return protoreflect.ValueOfUint32(uint32(n)), nil
+			}
+		case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+			var n uint64
+			if n, err = strconv.ParseUint(*p.Number, 10, 64); err == nil {
+				return protoreflect.ValueOfUint64(n), nil
+			}
+		case protoreflect.FloatKind:
+			var f float64
+			if f, err = strconv.ParseFloat(*p.Number, 32); err == nil {
+				return protoreflect.ValueOfFloat32(float32(f)), nil
+			}
+		case protoreflect.DoubleKind:
+			var f float64
+			if f, err = strconv.ParseFloat(*p.Number, 64); err == nil {
+				return protoreflect.ValueOfFloat64(f), nil
+			}
+		}
+	}
+	if err != nil {
+		return protoreflect.Value{}, participle.Errorf(p.Pos, "invalid %s: %v", fd.Name(), err)
+	}
+	return protoreflect.Value{}, participle.Errorf(p.Pos, "unexpected value for %s %s", fd.Kind(), fd.Name())
+}
+
+// Fills an empty message from its text form.
+func setMessage(msg protoreflect.Message, value *orExpr) error {
+	switch msg.Descriptor().FullName() {
+	case aplValueName:
+		val, err := value.toValue()
+		if err != nil {
+			return err
+		}
+		goproto.Merge(msg.Interface(), val)
+		return nil
+	}
+
+	p := value.primary()
+	if p == nil {
+		return participle.Errorf(value.Pos, "expected a %s", msg.Descriptor().Name())
+	}
+	if p.Ident != nil && *p.Ident == emptyKeyword {
+		return nil
+	}
+	if p.Braced != nil && msg.Descriptor().FullName() == aplActionName {
+		if len(p.Braced.Params) == 0 || p.Braced.Params[0].Value != nil {
+			return participle.Errorf(p.Pos, "expected an action kind")
+		}
+		action, err := toAction(p.Pos, p.Braced.Params[0].Name, p.Braced.Params[1:], nil)
+		if err != nil {
+			return err
+		}
+		goproto.Merge(msg.Interface(), action)
+		return nil
+	}
+	if p.Braced != nil {
+		return setParams(msg, p.Braced.Params)
+	}
+
+	switch msg.Descriptor().FullName() {
+	case actionIDName:
+		return setActionID(msg.Interface().(*proto.ActionID), p)
+	case unitReferenceName:
+		return setUnitReference(msg.Interface().(*proto.UnitReference), p)
+	}
+	return participle.Errorf(p.Pos, "expected a %s", msg.Descriptor().Name())
+}
+
+// ActionIDs are a spell ID, or spell(id, tag), item(id, tag) or other(OtherAction, tag).
+func setActionID(id *proto.ActionID, p *primary) error {
+	if p.Number != nil {
+		spellID, err := strconv.ParseInt(*p.Number, 10, 32)
+		if err != nil {
+			return participle.Errorf(p.Pos, "invalid spell ID: %v", err)
+		}
+		id.RawId = &proto.ActionID_SpellId{SpellId: int32(spellID)}
+		return nil
+	}
+	if p.Call == nil || len(p.Call.Args) == 0 || len(p.Call.Args) > 2 {
+		return participle.Errorf(p.Pos, "expected an ActionID like 123, spell(123, tag), item(123) or other(OtherActionPotion)")
+	}
+
+	msg := id.ProtoReflect()
+	var idField string
+	switch p.Call.Name {
+	case "spell":
+		idField = "spell_id"
+	case "item":
+		idField = "item_id"
+	case "other":
+		idField = "other_id"
+	default:
+		return participle.Errorf(p.Pos, "unknown ActionID kind %q", p.Call.Name)
+	}
+	argNames := []string{idField, "tag"}
+	for i, a := range p.Call.Args {
+		if a.Name != nil {
+			return participle.Errorf(a.Pos, "ActionID arguments are positional")
+		}
+		if err := setNamed(msg, a.Pos, argNames[i], a.Value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UnitReferences are a unit type, optionally called with the index and owner, e.g. Target(1).
+func setUnitReference(unit *proto.UnitReference, p *primary) error {
+	typeName := p.Ident
+	var args []*arg
+	if p.Call != nil {
+		typeName = &p.Call.Name
+		args = p.Call.Args
+	}
+	if typeName == nil {
+		return participle.Errorf(p.Pos, "expected a UnitReference like CurrentTarget or Target(1)")
+	}
+	unitType, ok := proto.UnitReference_Type_value[*typeName]
+	if !ok {
+		return participle.Errorf(p.Pos, "unknown unit type %q", *typeName)
+	}
+	unit.Type = proto.UnitReference_Type(unitType)
+
+	msg := unit.ProtoReflect()
+	argNames := []string{"index", "owner"}
+	if len(args) > len(argNames) {
+		return participle.Errorf(p.Pos, "too many arguments for a UnitReference")
+	}
+	for i, a := range args {
+		if a.Name != nil {
+			return participle.Errorf(a.Pos, "UnitReference arguments are positional")
+		}
+		if err := setNamed(msg, a.Pos, argNames[i], a.Value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,315 @@
+// Package logreplay replays the casts of a player from a combat log through
+// the sim and reports where the sim disagrees with the log.
+//
+// Logs use the event format of the WarcraftLogs events API, either as JSON
+// (an array of events, a response object or one event per line) or as CSV
+// with a header row naming the same fields.
+package logreplay
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ClassResource is the state of one of the source's resources at the time of
+// an event, in WarcraftLogs units.
+type ClassResource struct {
+	Amount float64 `json:"amount"`
+	Max    float64 `json:"max"`
+	Type   int32   `json:"type"`
+	Cost   float64 `json:"cost"`
+}
+
+type Ability struct {
+	Guid int32 `json:"guid"`
+}
+
+type Event struct {
+	Timestamp      float64         `json:"timestamp"` // Milliseconds.
+	Type           string          `json:"type"`
+	SourceID       int32           `json:"sourceID"`
+	TargetID       int32           `json:"targetID"`
+	AbilityGameID  int32           `json:"abilityGameID"`
+	Ability        *Ability        `json:"ability,omitempty"` // Used instead of AbilityGameID by older exports.
+	ClassResources []ClassResource `json:"classResources,omitempty"`
+}
+
+func (event *Event) SpellID() int32 {
+	if event.AbilityGameID == 0 && event.Ability != nil {
+		return event.Ability.Guid
+	}
+	return event.AbilityGameID
+}
+
+type Log struct {
+	Events    []Event // Sorted by timestamp.
+	StartTime float64 // Timestamp of the pull.
+}
+
+// ReadFile parses a .csv log, or a JSON log for any other extension.
+func ReadFile(path string) (*Log, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var log *Log
+	if strings.EqualFold(filepath.Ext(path), ".csv") {
+		log, err = ParseCSV(file)
+	} else {
+		log, err = ParseJSON(file)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse combat log %q: %w", path, err)
+	}
+	return log, nil
+}
+
+// The shapes a JSON log can take besides a single event or an array of them.
+type jsonLogWrapper struct {
+	Events []Event `json:"events"`
+	Data   *struct {
+		ReportData struct {
+			Report struct {
+				Events struct {
+					Data []Event `json:"data"`
+				} `json:"events"`
+			} `json:"report"`
+		} `json:"reportData"`
+	} `json:"data"`
+}
+
+func ParseJSON(r io.Reader) (*Log, error) {
+	log := &Log{}
+	decoder := json.NewDecoder(r)
+	for {
+		var value json.RawMessage
+		if err := decoder.Decode(&value); err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+
+		value = bytes.TrimSpace(value)
+		if len(value) > 0 && value[0] == '[' {
+			var events []Event
+			if err := json.Unmarshal(value, &events); err != nil {
+				return nil, err
+			}
+			log.Events = append(log.Events, events...)
+			continue
+		}
+
+		var wrapper jsonLogWrapper
+		if err := json.Unmarshal(value, &wrapper); err != nil {
+			return nil, err
+		}
+		if wrapper.Events != nil {
+			log.Events = append(log.Events, wrapper.Events...)
+		} else if wrapper.Data != nil {
+			log.Events = append(log.Events, wrapper.Data.ReportData.Report.Events.Data...)
+		} else {
+			var event Event
+			if err := json.Unmarshal(value, &event); err != nil {
+				return nil, err
+			}
+			log.Events = append(log.Events, event)
+		}
+	}
+
+	return log.sorted()
+}
+
+// ParseCSV parses a log with one event per row. The header names the event
+// fields (timestamp, type, sourceID, targetID, abilityGameID) and optionally
+// a single resource (resourceType, resourceAmount, resourceMax, resourceCost).
+func ParseCSV(r io.Reader) (*Log, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, err
+	}
+	columns := map[string]int{}
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, required := range []string{"timestamp", "type", "sourceid", "abilitygameid"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("missing %q column", required)
+		}
+	}
+
+	log := &Log{}
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+
+		var parseErr error
+		field := func(name string) string {
+			if idx, ok := columns[name]; ok && idx < len(record) {
+				return strings.TrimSpace(record[idx])
+			}
+			return ""
+		}
+		number := func(name string) float64 {
+			value := field(name)
+			if value == "" {
+				return 0
+			}
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil && parseErr == nil {
+				parseErr = fmt.Errorf("line %d: invalid %s %q", line, name, value)
+			}
+			return parsed
+		}
+
+		event := Event{
+			Timestamp:     number("timestamp"),
+			Type:          field("type"),
+			SourceID:      int32(number("sourceid")),
+			TargetID:      int32(number("targetid")),
+			AbilityGameID: int32(number("abilitygameid")),
+		}
+		if field("resourcetype") != "" {
+			event.ClassResources = []ClassResource{{
+				Type:   int32(number("resourcetype")),
+				Amount: number("resourceamount"),
+				Max:    number("resourcemax"),
+				Cost:   number("resourcecost"),
+			}}
+		}
+		if parseErr != nil {
+			return nil, parseErr
+		}
+		log.Events = append(log.Events, event)
+	}
+
+	return log.sorted()
+}
+
+func (log *Log) sorted() (*Log, error) {
+	if len(log.Events) == 0 {
+		return nil, errors.New("no events")
+	}
+	slices.SortStableFunc(log.Events, func(a, b Event) int {
+		if a.Timestamp < b.Timestamp {
+			return -1
+		} else if a.Timestamp > b.Timestamp {
+			return 1
+		}
+		return 0
+	})
+
+	// The pull is the encounter start event if there is one, otherwise the
+	// first event.
+	log.StartTime = log.Events[0].Timestamp
+	for _, event := range log.Events {
+		if event.Type == "encounterstart" {
+			log.StartTime = event.Timestamp
+			break
+		}
+	}
+	return log, nil
+}
+
+func (log *Log) Duration() time.Duration {
+	return log.relativeTime(log.Events[len(log.Events)-1].Timestamp)
+}
+
+func (log *Log) relativeTime(timestamp float64) time.Duration {
+	return time.Duration((timestamp - log.StartTime) * float64(time.Millisecond))
+}
+
+// DefaultSourceID returns the source with the most casts.
+func (log *Log) DefaultSourceID() int32 {
+	counts := map[int32]int{}
+	bestID, bestCount := int32(0), 0
+	for _, event := range log.Events {
+		if event.Type != "cast" {
+			continue
+		}
+		counts[event.SourceID]++
+		if counts[event.SourceID] > bestCount {
+			bestID, bestCount = event.SourceID, counts[event.SourceID]
+		}
+	}
+	return bestID
+}
+
+type Cast struct {
+	Time      time.Duration // Relative to the pull, negative for prepull casts.
+	SpellID   int32
+	TargetID  int32
+	Resources []ClassResource // Before paying the cost.
+}
+
+// Melee swings and auto shots are logged as casts, but are not actions.
+var autoAttackSpellIDs = []int32{1, 75, 6603}
+
+// Begincast events older than this are considered cancelled.
+const maxCastTimeMs = 10000
+
+// Casts returns the casts of the source. Casts with a cast time are placed at
+// the time they started.
+func (log *Log) Casts(sourceID int32) []Cast {
+	started := map[int32]float64{}
+	var casts []Cast
+	for _, event := range log.Events {
+		if event.SourceID != sourceID || slices.Contains(autoAttackSpellIDs, event.SpellID()) {
+			continue
+		}
+
+		switch event.Type {
+		case "begincast":
+			started[event.SpellID()] = event.Timestamp
+		case "cast":
+			timestamp := event.Timestamp
+			if startedAt, ok := started[event.SpellID()]; ok {
+				if timestamp-startedAt <= maxCastTimeMs {
+					timestamp = startedAt
+				}
+				delete(started, event.SpellID())
+			}
+			casts = append(casts, Cast{
+				Time:      log.relativeTime(timestamp),
+				SpellID:   event.SpellID(),
+				TargetID:  event.TargetID,
+				Resources: event.ClassResources,
+			})
+		}
+	}
+
+	slices.SortStableFunc(casts, func(a, b Cast) int {
+		return int(a.Time - b.Time)
+	})
+	return casts
+}
+
+// SelfAuraGains counts the buffs the source applied to itself, by spell ID.
+func (log *Log) SelfAuraGains(sourceID int32) map[int32]int {
+	gains := map[int32]int{}
+	for _, event := range log.Events {
+		if event.Type == "applybuff" && event.SourceID == sourceID && event.TargetID == sourceID {
+			gains[event.SpellID()]++
+		}
+	}
+	return gains
+}
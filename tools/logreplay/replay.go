@@ -0,0 +1,324 @@
+package logreplay
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/wowsims/mop/sim/core"
+	"github.com/wowsims/mop/sim/core/proto"
+	googleProto "google.golang.org/protobuf/proto"
+)
+
+type Options struct {
+	// The log source to replay, defaults to the one with the most casts.
+	SourceID int32
+
+	// How long the sim may delay a cast before skipping it.
+	CastTolerance time.Duration
+
+	// Casts delayed by more than this are reported.
+	DelayTolerance time.Duration
+
+	// Resources which differ by more than this fraction of the logged maximum
+	// are reported.
+	ResourceTolerance float64
+}
+
+func (options *Options) setDefaults() {
+	if options.CastTolerance <= 0 {
+		options.CastTolerance = time.Second
+	}
+	if options.DelayTolerance <= 0 {
+		options.DelayTolerance = time.Millisecond * 100
+	}
+	if options.ResourceTolerance <= 0 {
+		options.ResourceTolerance = 0.05
+	}
+}
+
+const (
+	KindCast     = "cast"     // A logged cast was never performed by the sim.
+	KindDelay    = "delay"    // The sim performed a cast later than logged.
+	KindCooldown = "cooldown" // The sim still had the spell on cooldown.
+	KindResource = "resource" // Resources differ, or the sim couldn't afford a cast.
+	KindProc     = "proc"     // An aura was gained a different number of times.
+)
+
+type Discrepancy struct {
+	Time    float64 `json:"time"` // Seconds after the pull.
+	Kind    string  `json:"kind"`
+	SpellID int32   `json:"spellId"`
+	Message string  `json:"message"`
+}
+
+type Report struct {
+	SourceID      int32         `json:"sourceId"`
+	Casts         int           `json:"casts"`
+	MatchedCasts  int           `json:"matchedCasts"`
+	Discrepancies []Discrepancy `json:"discrepancies"`
+}
+
+// Replay runs a single iteration of the request with the casts of the log
+// forced onto the first player, and compares the result against the log.
+func Replay(request *proto.RaidSimRequest, log *Log, options Options) (*Report, error) {
+	options.setDefaults()
+	if options.SourceID == 0 {
+		options.SourceID = log.DefaultSourceID()
+	}
+	casts := log.Casts(options.SourceID)
+	if len(casts) == 0 {
+		return nil, fmt.Errorf("source %d has no casts in the log", options.SourceID)
+	}
+
+	request = googleProto.Clone(request).(*proto.RaidSimRequest)
+	player, playerIndex := firstPlayer(request.Raid)
+	if player == nil {
+		return nil, errors.New("request has no player to replay the log on")
+	}
+
+	mapper := newSpellMapper()
+	player.Rotation = replayRotation(casts, mapper, options.CastTolerance)
+
+	request.SimOptions = &proto.SimOptions{
+		Iterations:          1,
+		RandomSeed:          request.GetSimOptions().GetRandomSeed(),
+		DebugFirstIteration: true,
+		CombatLog:           true,
+	}
+	if request.Encounter == nil {
+		request.Encounter = &proto.Encounter{}
+	}
+	request.Encounter.Duration = max(log.Duration(), casts[len(casts)-1].Time+time.Second).Seconds()
+	request.Encounter.DurationVariation = 0
+	request.Encounter.UseHealth = false
+
+	result := core.RunRaidSim(request)
+	if result.Error != nil {
+		return nil, fmt.Errorf("replay sim failed: %s", result.Error.Message)
+	}
+
+	comparer := newComparer(result.CombatLog, playerIndex, options)
+	report := &Report{
+		SourceID:      options.SourceID,
+		Casts:         len(casts),
+		Discrepancies: []Discrepancy{},
+	}
+	for _, cast := range casts {
+		if comparer.compareCast(cast, mapper.matchingKeys(cast.SpellID)) {
+			report.MatchedCasts++
+		}
+	}
+	comparer.compareAuras(log.SelfAuraGains(options.SourceID), mapper)
+	report.Discrepancies = comparer.discrepancies
+	slices.SortStableFunc(report.Discrepancies, func(a, b Discrepancy) int {
+		return cmp.Compare(a.Time, b.Time)
+	})
+	return report, nil
+}
+
+func firstPlayer(raid *proto.Raid) (*proto.Player, int32) {
+	for partyIdx, party := range raid.GetParties() {
+		for playerIdx, player := range party.Players {
+			if player != nil && player.Class != proto.Class_ClassUnknown {
+				return player, int32(partyIdx*5 + playerIdx)
+			}
+		}
+	}
+	return nil, 0
+}
+
+func replayRotation(casts []Cast, mapper *spellMapper, tolerance time.Duration) *proto.APLRotation {
+	rotation := &proto.APLRotation{Type: proto.APLRotation_TypeAPL}
+
+	replay := &proto.APLActionReplay{ToleranceSeconds: tolerance.Seconds()}
+	for _, cast := range casts {
+		if cast.Time < 0 {
+			rotation.PrepullActions = append(rotation.PrepullActions, &proto.APLPrepullAction{
+				Action: &proto.APLAction{Action: &proto.APLAction_CastSpell{CastSpell: &proto.APLActionCastSpell{
+					SpellId: mapper.actionID(cast.SpellID),
+				}}},
+				DoAtValue: &proto.APLValue{Value: &proto.APLValue_Const{Const: &proto.APLValueConst{
+					Val: fmt.Sprintf("%.3fs", cast.Time.Seconds()),
+				}}},
+			})
+			continue
+		}
+		replay.Casts = append(replay.Casts, &proto.APLReplayCast{
+			TimeSeconds: cast.Time.Seconds(),
+			SpellId:     mapper.actionID(cast.SpellID),
+		})
+	}
+
+	if len(replay.Casts) > 0 {
+		rotation.PriorityList = []*proto.APLListItem{{
+			Action: &proto.APLAction{Action: &proto.APLAction_Replay{Replay: replay}},
+		}}
+	}
+	return rotation
+}
+
+type simCast struct {
+	time       float64
+	eventIndex int
+	used       bool
+}
+
+type simFailure struct {
+	time   float64
+	reason string
+	used   bool
+}
+
+type comparer struct {
+	options Options
+	events  []*proto.CombatLogEvent
+
+	casts      map[actionKey][]*simCast
+	failures   map[actionKey][]*simFailure
+	auraGains  map[actionKey]int
+	resourceAt map[proto.ResourceType][]int // Indices of resource change events.
+
+	discrepancies []Discrepancy
+}
+
+func newComparer(events []*proto.CombatLogEvent, playerIndex int32, options Options) *comparer {
+	c := &comparer{
+		options:    options,
+		events:     events,
+		casts:      map[actionKey][]*simCast{},
+		failures:   map[actionKey][]*simFailure{},
+		auraGains:  map[actionKey]int{},
+		resourceAt: map[proto.ResourceType][]int{},
+	}
+
+	for i, event := range events {
+		source := event.Source
+		if source.GetType() != proto.UnitReference_Player || source.Index != playerIndex {
+			continue
+		}
+
+		key := actionKeyFromProto(event.ActionId)
+		switch event.Type {
+		case proto.CombatLogEventType_CombatLogEventCastStart:
+			c.casts[key] = append(c.casts[key], &simCast{time: event.Timestamp, eventIndex: i})
+		case proto.CombatLogEventType_CombatLogEventCastFailed:
+			if reason, ok := strings.CutPrefix(event.Message, "replay: "); ok {
+				c.failures[key] = append(c.failures[key], &simFailure{time: event.Timestamp, reason: reason})
+			}
+		case proto.CombatLogEventType_CombatLogEventAuraGained:
+			c.auraGains[key]++
+		case proto.CombatLogEventType_CombatLogEventResourceChange:
+			c.resourceAt[event.ResourceType] = append(c.resourceAt[event.ResourceType], i)
+		}
+	}
+	return c
+}
+
+func (c *comparer) report(time float64, kind string, spellID int32, format string, args ...interface{}) {
+	c.discrepancies = append(c.discrepancies, Discrepancy{
+		Time:    time,
+		Kind:    kind,
+		SpellID: spellID,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+// Returns whether the sim performed the cast.
+func (c *comparer) compareCast(cast Cast, keys []actionKey) bool {
+	const epsilon = 0.001
+	loggedAt := cast.Time.Seconds()
+	latest := loggedAt + c.options.CastTolerance.Seconds() + epsilon
+
+	for _, key := range keys {
+		for _, simCast := range c.casts[key] {
+			if simCast.used || simCast.time < loggedAt-epsilon {
+				continue
+			}
+			if simCast.time > latest {
+				break
+			}
+
+			simCast.used = true
+			if delay := simCast.time - loggedAt; delay > c.options.DelayTolerance.Seconds() {
+				c.report(loggedAt, KindDelay, cast.SpellID, "Cast %.3fs later than logged", delay)
+			}
+			c.compareResources(cast, simCast.eventIndex)
+			return true
+		}
+	}
+
+	for _, key := range keys {
+		for _, failure := range c.failures[key] {
+			if failure.used || failure.time < loggedAt-epsilon || failure.time > latest {
+				continue
+			}
+
+			failure.used = true
+			kind := KindCast
+			switch failure.reason {
+			case core.ReplayFailureCooldown:
+				kind = KindCooldown
+			case core.ReplayFailureResources:
+				kind = KindResource
+			}
+			c.report(loggedAt, kind, cast.SpellID, "Skipped by the sim: %s", failure.reason)
+			return false
+		}
+	}
+
+	c.report(loggedAt, KindCast, cast.SpellID, "Never cast by the sim, the spell may be unknown to it")
+	return false
+}
+
+// Compares the logged resources with the sim's resources right before the
+// cast event.
+func (c *comparer) compareResources(cast Cast, eventIndex int) {
+	for _, resource := range cast.Resources {
+		mapping, ok := logResourceTypes[resource.Type]
+		if !ok {
+			continue
+		}
+
+		simAmount, ok := c.resourceBefore(mapping.resourceType, eventIndex)
+		if !ok {
+			continue
+		}
+		loggedAmount := resource.Amount * mapping.scale
+		tolerance := max(1, resource.Max*mapping.scale*c.options.ResourceTolerance)
+		if math.Abs(simAmount-loggedAmount) > tolerance {
+			c.report(cast.Time.Seconds(), KindResource, cast.SpellID, "%s is %.0f in the sim but %.0f in the log",
+				strings.TrimPrefix(mapping.resourceType.String(), "ResourceType"), simAmount, loggedAmount)
+		}
+	}
+}
+
+func (c *comparer) resourceBefore(resourceType proto.ResourceType, eventIndex int) (float64, bool) {
+	changes := c.resourceAt[resourceType]
+	amount, found := 0.0, false
+	for _, changeIndex := range changes {
+		if changeIndex >= eventIndex {
+			break
+		}
+		amount, found = c.events[changeIndex].ResourceAfter, true
+	}
+	return amount, found
+}
+
+func (c *comparer) compareAuras(loggedGains map[int32]int, mapper *spellMapper) {
+	for _, spellID := range slices.Sorted(maps.Keys(loggedGains)) {
+		loggedCount := loggedGains[spellID]
+		simCount := 0
+		for _, key := range mapper.matchingKeys(spellID) {
+			simCount += c.auraGains[key]
+		}
+		if simCount != loggedCount {
+			c.report(0, KindProc, spellID, "Gained %d times in the sim but %d times in the log", simCount, loggedCount)
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package logreplay
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/wowsims/mop/sim/core"
+	"github.com/wowsims/mop/sim/core/proto"
+	"github.com/wowsims/mop/sim/warrior/arms"
+)
+
+func testRequest() *proto.RaidSimRequest {
+	player := &proto.Player{
+		Name:      "John",
+		Race:      proto.Race_RaceOrc,
+		Class:     proto.Class_ClassWarrior,
+		Equipment: &proto.EquipmentSpec{},
+		Rotation:  &proto.APLRotation{},
+		Spec: &proto.Player_ArmsWarrior{
+			ArmsWarrior: &proto.ArmsWarrior{
+				Options: &proto.ArmsWarrior_Options{
+					ClassOptions: &proto.WarriorOptions{},
+				},
+			},
+		},
+		Glyphs:        &proto.Glyphs{},
+		TalentsString: "000000",
+		Buffs:         &proto.IndividualBuffs{},
+	}
+
+	return &proto.RaidSimRequest{
+		Raid: core.SinglePlayerRaidProto(player, &proto.PartyBuffs{}, &proto.RaidBuffs{}, &proto.Debuffs{}),
+		Encounter: &proto.Encounter{
+			Targets: []*proto.Target{
+				core.NewDefaultTarget(),
+			},
+		},
+		SimOptions: &proto.SimOptions{
+			RandomSeed: 101,
+		},
+	}
+}
+
+// Mortal Strike at the pull with full rage, and again 2 seconds later while
+// it is still on cooldown. Melee swings are ignored.
+const testJSONLog = `
+{"timestamp": 1000, "type": "encounterstart"}
+{"timestamp": 1000, "type": "cast", "sourceID": 5, "targetID": 9, "abilityGameID": 12294, "classResources": [{"amount": 1000, "max": 1000, "type": 1, "cost": 300}]}
+{"timestamp": 1100, "type": "cast", "sourceID": 5, "targetID": 9, "abilityGameID": 1}
+{"timestamp": 3000, "type": "cast", "sourceID": 5, "targetID": 9, "abilityGameID": 12294}
+{"timestamp": 3500, "type": "cast", "sourceID": 7, "targetID": 9, "abilityGameID": 133}
+`
+
+const testCSVLog = `timestamp,type,sourceID,targetID,abilityGameID,resourceType,resourceAmount,resourceMax
+1000,encounterstart,,,,,,
+1000,cast,5,9,12294,1,1000,1000
+1100,cast,5,9,1,,,
+3000,cast,5,9,12294,,,
+3500,cast,7,9,133,,,
+`
+
+func TestParseFormats(t *testing.T) {
+	jsonLog, err := ParseJSON(strings.NewReader(testJSONLog))
+	if err != nil {
+		t.Fatal(err)
+	}
+	csvLog, err := ParseCSV(strings.NewReader(testCSVLog))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, log := range []*Log{jsonLog, csvLog} {
+		if sourceID := log.DefaultSourceID(); sourceID != 5 {
+			t.Fatalf("Expected source 5, got %d", sourceID)
+		}
+		casts := log.Casts(5)
+		if len(casts) != 2 || casts[0].Time != 0 || casts[1].Time.Seconds() != 2 || casts[0].SpellID != 12294 {
+			t.Fatalf("Unexpected casts: %v", casts)
+		}
+		if len(casts[0].Resources) != 1 || casts[0].Resources[0].Amount != 1000 {
+			t.Fatalf("Unexpected resources: %v", casts[0].Resources)
+		}
+	}
+}
+
+func TestReplayReportsDiscrepancies(t *testing.T) {
+	arms.RegisterArmsWarrior()
+
+	log, err := ParseJSON(strings.NewReader(testJSONLog))
+	if err != nil {
+		t.Fatal(err)
+	}
+	report, err := Replay(testRequest(), log, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if report.Casts != 2 || report.MatchedCasts != 1 {
+		t.Fatalf("Expected 1 of 2 casts to match, got %d of %d", report.MatchedCasts, report.Casts)
+	}
+	if len(report.Discrepancies) != 2 {
+		t.Fatalf("Expected 2 discrepancies, got %v", report.Discrepancies)
+	}
+	if discrepancy := report.Discrepancies[0]; discrepancy.Kind != KindResource || discrepancy.Time != 0 {
+		t.Fatalf("Expected a rage discrepancy at the pull, got %v", discrepancy)
+	}
+	if discrepancy := report.Discrepancies[1]; discrepancy.Kind != KindCooldown || discrepancy.Time != 2 || discrepancy.SpellID != 12294 {
+		t.Fatalf("Expected a cooldown discrepancy, got %v", discrepancy)
+	}
+}
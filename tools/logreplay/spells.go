@@ -0,0 +1,94 @@
+package logreplay
+
+import (
+	"github.com/wowsims/mop/sim/core"
+	"github.com/wowsims/mop/sim/core/proto"
+)
+
+// spellMapper maps the spell IDs of a log to the ActionIDs used by the sim.
+// Most spells use their spell ID, but items and consumables are keyed by
+// their item ID.
+type spellMapper struct {
+	itemsBySpellID   map[int32]int32
+	potionsBySpellID map[int32]int32
+}
+
+func newSpellMapper() *spellMapper {
+	mapper := &spellMapper{
+		itemsBySpellID:   map[int32]int32{},
+		potionsBySpellID: map[int32]int32{},
+	}
+
+	for _, item := range core.ItemsByID {
+		if item.ItemEffect != nil && item.ItemEffect.BuffId != 0 {
+			mapper.itemsBySpellID[item.ItemEffect.BuffId] = item.ID
+		}
+	}
+	for _, consumable := range core.ConsumablesByID {
+		for _, effectID := range consumable.EffectIds {
+			effect := core.SpellEffectsById[effectID]
+			if effect == nil || effect.SpellId == 0 {
+				continue
+			}
+			if consumable.Type == proto.ConsumableType_ConsumableTypePotion {
+				mapper.potionsBySpellID[effect.SpellId] = consumable.Id
+			} else {
+				mapper.itemsBySpellID[effect.SpellId] = consumable.Id
+			}
+		}
+	}
+	return mapper
+}
+
+// The ActionID to reference the spell with in an APL.
+func (mapper *spellMapper) actionID(spellID int32) *proto.ActionID {
+	if _, ok := mapper.potionsBySpellID[spellID]; ok {
+		return core.ActionID{OtherID: proto.OtherAction_OtherActionPotion}.ToProto()
+	}
+	if itemID, ok := mapper.itemsBySpellID[spellID]; ok {
+		return core.ActionID{ItemID: itemID}.ToProto()
+	}
+	return core.ActionID{SpellID: spellID}.ToProto()
+}
+
+// The ActionIDs the sim might log the spell, or its aura, with.
+func (mapper *spellMapper) matchingKeys(spellID int32) []actionKey {
+	keys := []actionKey{{spellID: spellID}}
+	if itemID, ok := mapper.potionsBySpellID[spellID]; ok {
+		keys = append(keys, actionKey{itemID: itemID})
+	}
+	if itemID, ok := mapper.itemsBySpellID[spellID]; ok {
+		keys = append(keys, actionKey{itemID: itemID})
+	}
+	return keys
+}
+
+// Identifies an action regardless of its tag.
+type actionKey struct {
+	spellID int32
+	itemID  int32
+	otherID proto.OtherAction
+}
+
+func actionKeyFromProto(actionID *proto.ActionID) actionKey {
+	return actionKey{
+		spellID: actionID.GetSpellId(),
+		itemID:  actionID.GetItemId(),
+		otherID: actionID.GetOtherId(),
+	}
+}
+
+// WarcraftLogs resource types which the sim also tracks. Rage and runic power
+// are logged in tenths.
+var logResourceTypes = map[int32]struct {
+	resourceType proto.ResourceType
+	scale        float64
+}{
+	0:  {proto.ResourceType_ResourceTypeMana, 1},
+	1:  {proto.ResourceType_ResourceTypeRage, 0.1},
+	2:  {proto.ResourceType_ResourceTypeFocus, 1},
+	3:  {proto.ResourceType_ResourceTypeEnergy, 1},
+	4:  {proto.ResourceType_ResourceTypeComboPoints, 1},
+	6:  {proto.ResourceType_ResourceTypeRunicPower, 0.1},
+	12: {proto.ResourceType_ResourceTypeChi, 1},
+}
@@ -26,6 +26,14 @@ export class APLGroupEditor extends Input<Player<any>, APLGroup> {
 		this.index = config.index;
 		const container = this.rootElem.appendChild(<div className="apl-action-picker-root" />) as HTMLElement;
 
+		if (this.rootElem.parentElement!.classList.contains('list-picker-item')) {
+			ListPicker.makeListItemValidations(
+				ListPicker.getItemHeaderElem(this),
+				player,
+				player => player.getCurrentStats().rotationStats?.groups?.[this.index]?.validations || [],
+			);
+		}
+
 		// Create the group name input within our container
 		this.namePicker = new AdaptiveStringPicker(container, this.modObject, {
 			id: randomUUID(),
@@ -20,12 +20,16 @@ import {
 	APLActionItemSwap,
 	APLActionItemSwap_SwapSet as ItemSwapSet,
 	APLActionMove,
+	APLActionModifyVariable,
+	APLActionModifyVariable_ModifyOperation as ModifyOperation,
 	APLActionMoveDuration,
 	APLActionMultidot,
 	APLActionMultishield,
+	APLActionReplay,
 	APLActionResetSequence,
 	APLActionSchedule,
 	APLActionSequence,
+	APLActionSetVariable,
 	APLActionStrictMultidot,
 	APLActionStrictSequence,
 	APLActionTriggerICD,
@@ -681,6 +685,13 @@ const actionKindFactories: { [f in NonNullable<APLActionKind>]: ActionKindConfig
 		newValue: () => APLActionCustomRotation.create(),
 		fields: [],
 	}),
+	['replay']: inputBuilder({
+		label: 'Replay',
+		shortDescription: 'Forces a cast sequence imported from a combat log.',
+		includeIf: (_player: Player<any>, _isPrepull: boolean) => false, // Never show this, because its internal only.
+		newValue: () => APLActionReplay.create(),
+		fields: [],
+	}),
 	['groupReference']: inputBuilder({
 		label: 'Group Reference',
 		submenu: ['Groups'],
@@ -705,6 +716,47 @@ const actionKindFactories: { [f in NonNullable<APLActionKind>]: ActionKindConfig
 			}),
 		],
 	}),
+	['setVariable']: inputBuilder({
+		label: 'Set Variable',
+		submenu: ['Variables'],
+		shortDescription: 'Stores a number or duration in a mutable variable, which can be read with <b>Variable Value</b>.',
+		fullDescription: `
+			<p>Mutable variables start at 0 at the beginning of each iteration and keep their value until they are set or modified again.
+			Durations are stored in seconds.</p>
+			<p>This action does not use the GCD, and only runs once at each timestamp.</p>
+		`,
+		newValue: () =>
+			APLActionSetVariable.create({
+				name: '',
+			}),
+		fields: [
+			AplHelpers.stringFieldConfig('name', {
+				labelTooltip: 'Name of the mutable variable to set.',
+			}),
+			AplValues.valueFieldConfig('value'),
+		],
+	}),
+	['modifyVariable']: inputBuilder({
+		label: 'Modify Variable',
+		submenu: ['Variables'],
+		shortDescription: 'Adds to, subtracts from, multiplies, divides or resets a mutable variable.',
+		fullDescription: `
+			<p>Useful for counters, e.g. adding 1 each time a spell is cast, or latches that record when a phase of the fight has started.</p>
+			<p>Dividing by 0 leaves the variable unchanged. This action does not use the GCD, and only runs once at each timestamp.</p>
+		`,
+		newValue: () =>
+			APLActionModifyVariable.create({
+				name: '',
+				op: ModifyOperation.OpAdd,
+			}),
+		fields: [
+			AplHelpers.stringFieldConfig('name', {
+				labelTooltip: 'Name of the mutable variable to modify.',
+			}),
+			AplHelpers.modifyVariableOpFieldConfig('op'),
+			AplValues.valueFieldConfig('value'),
+		],
+	}),
 
 	// Class/spec specific actions
 	['catOptimalRotationAction']: inputBuilder({
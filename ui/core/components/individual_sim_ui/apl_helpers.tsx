@@ -10,6 +10,7 @@ import {
 	APLValueRuneSlot,
 	APLValueRuneType,
 	APLActionDamageAmplifier_AmplificationType,
+	APLActionModifyVariable_ModifyOperation as ModifyOperation,
 } from '../../proto/apl.js';
 import { ActionID, OtherAction, Stat, UnitReference, UnitReference_Type as UnitType } from '../../proto/common.js';
 import { FeralDruid_Rotation_AplType } from '../../proto/druid.js';
@@ -1275,6 +1276,28 @@ export function damageAmpTypeFieldConfig(field: string): APLPickerBuilderFieldCo
 	};
 }
 
+export function modifyVariableOpFieldConfig(field: string): APLPickerBuilderFieldConfig<any, any> {
+	return {
+		field: field,
+		label: 'Operation',
+		newValue: () => ModifyOperation.OpAdd,
+		factory: (parent, player, config) =>
+			new TextDropdownPicker(parent, player, {
+				id: randomUUID(),
+				...config,
+				defaultLabel: 'None',
+				equals: (a, b) => a == b,
+				values: [
+					{ value: ModifyOperation.OpAdd, label: 'Add' },
+					{ value: ModifyOperation.OpSub, label: 'Subtract' },
+					{ value: ModifyOperation.OpMul, label: 'Multiply' },
+					{ value: ModifyOperation.OpDiv, label: 'Divide' },
+					{ value: ModifyOperation.OpReset, label: 'Reset', tooltip: 'Sets the variable back to 0. The value is not used.' },
+				],
+			}),
+	};
+}
+
 export function useRuneRegenBaseValueCheckbox(): APLPickerBuilderFieldConfig<any, any> {
 	return booleanFieldConfig('useBaseValue', 'Use base value', {
 		labelTooltip: 'If checked, will return your base (unmodified by procs/lust etc) rune regen rate',
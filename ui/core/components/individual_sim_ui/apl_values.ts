@@ -51,6 +51,7 @@ import {
 	APLValueAfflictionCurrentSnapshot,
 	APLValueEnergyRegenPerSecond,
 	APLValueEnergyTimeToTarget,
+	APLValueExternalBuffTimeToNext,
 	APLValueFocusRegenPerSecond,
 	APLValueFocusTimeToTarget,
 	APLValueFrontOfTarget,
@@ -1332,6 +1333,15 @@ const valueKindFactories: { [f in ValidAPLValueKind]: ValueKindConfig<APLValueIm
 		],
 	}),
 
+	externalBuffTimeToNext: inputBuilder({
+		label: i18n.t('rotation_tab.apl.values.external_buff_time_to_next.label'),
+		submenu: ['aura'],
+		shortDescription: i18n.t('rotation_tab.apl.values.external_buff_time_to_next.tooltip'),
+		newValue: APLValueExternalBuffTimeToNext.create,
+		includeIf: (_: Player<any>, isPrepull: boolean) => !isPrepull,
+		fields: [AplHelpers.actionIdFieldConfig('spellId', 'castable_spells', '')],
+	}),
+
 	// Aura Sets
 	allTrinketStatProcsActive: inputBuilder({
 		label: i18n.t('rotation_tab.apl.values.all_trinket_stat_procs_active.label'),
@@ -1718,6 +1728,20 @@ const valueKindFactories: { [f in ValidAPLValueKind]: ValueKindConfig<APLValueIm
 			}),
 		],
 	}),
+	variableValue: inputBuilder({
+		label: 'Variable Value',
+		submenu: ['Variables'],
+		shortDescription: 'Current value of a mutable variable, changed by the <b>Set Variable</b> and <b>Modify Variable</b> actions.',
+		fullDescription: `
+			<p>Mutable variables are 0 at the start of each iteration. Durations are stored in seconds.</p>
+		`,
+		newValue: () => ({ name: '' }),
+		fields: [
+			AplHelpers.stringFieldConfig('name', {
+				labelTooltip: 'Name of the mutable variable to read.',
+			}),
+		],
+	}),
 	activeItemSwapSet: inputBuilder({
 		label: 'Item Swap',
 		submenu: ['Misc'],
@@ -127,6 +127,12 @@ export const UnholyFrenzy = makeMultistateIndividualBuffInput({
 	fieldName: 'unholyFrenzyCount',
 	label: i18n.t('settings_tab.external_damage_cooldowns.unholy_frenzy'),
 });
+export const PowerInfusion = makeMultistateIndividualBuffInput({
+	actionId: ActionId.fromSpellId(10060),
+	numStates: 11,
+	fieldName: 'powerInfusionCount',
+	label: i18n.t('settings_tab.external_damage_cooldowns.power_infusion'),
+});
 export const ShatteringThrow = makeMultistateIndividualBuffInput({
 	actionId: ActionId.fromSpellId(1249459),
 	numStates: 11,
@@ -279,6 +285,11 @@ export const RAID_BUFFS_EXTERNAL_DAMAGE_COOLDOWN = [
 		picker: IconPicker,
 		stats: [Stat.StatAttackPower, Stat.StatRangedAttackPower],
 	},
+	{
+		config: PowerInfusion,
+		picker: IconPicker,
+		stats: [Stat.StatSpellPower],
+	},
 	{
 		config: ShatteringThrow,
 		picker: IconPicker,
@@ -1,5 +1,5 @@
 import { Player } from '../../player.js';
-import { UnitReference } from '../../proto/common.js';
+import { RaidDamageProfile, UnitReference } from '../../proto/common.js';
 import { emptyUnitReference } from '../../proto_utils/utils.js';
 import { Sim } from '../../sim.js';
 import { EventID } from '../../typed_event.js';
@@ -255,6 +255,43 @@ export const BurstWindow = {
 	enableWhen: (player: Player<any>) => (player.getRaid()?.getTanks() || []).find(tank => UnitReference.equals(tank, player.makeUnitReference())) != null,
 };
 
+function makeRaidDamageInput(id: string, i18nKey: string, field: keyof RaidDamageProfile, float: boolean, scale = 1) {
+	return {
+		id: id,
+		type: 'number' as const,
+		float: float,
+		label: i18n.t(`settings_tab.other.${i18nKey}.label`),
+		labelTooltip: i18n.t(`settings_tab.other.${i18nKey}.tooltip`),
+		changedEvent: (player: Player<any>) => player.sim.encounter.raidDamageChangeEmitter,
+		getValue: (player: Player<any>) => player.sim.encounter.getRaidDamage()[field] * scale,
+		setValue: (eventID: EventID, player: Player<any>, newValue: number) => {
+			const raidDamage = player.sim.encounter.getRaidDamage();
+			raidDamage[field] = newValue / scale;
+			player.sim.encounter.setRaidDamage(eventID, raidDamage);
+		},
+	};
+}
+
+export const RaidPulseDamage = makeRaidDamageInput('raid-pulse-damage', 'raid_pulse_damage', 'pulseDamage', false);
+export const RaidPulseInterval = makeRaidDamageInput('raid-pulse-interval', 'raid_pulse_interval', 'pulseInterval', true);
+export const RaidSpikeDamage = makeRaidDamageInput('raid-spike-damage', 'raid_spike_damage', 'spikeDamage', false);
+export const RaidSpikeInterval = makeRaidDamageInput('raid-spike-interval', 'raid_spike_interval', 'spikeInterval', true);
+export const TankSwingDamage = makeRaidDamageInput('tank-swing-damage', 'tank_swing_damage', 'tankSwingDamage', false);
+export const TankSwingSpeed = makeRaidDamageInput('tank-swing-speed', 'tank_swing_speed', 'tankSwingSpeed', true);
+export const RaidDamageVariance = makeRaidDamageInput('raid-damage-variance', 'raid_damage_variance', 'damageVariance', true, 100);
+export const TargetDummyHealth = makeRaidDamageInput('target-dummy-health', 'target_dummy_health', 'targetDummyHealth', false);
+
+export const RaidDamageInputs = [
+	RaidPulseDamage,
+	RaidPulseInterval,
+	RaidSpikeDamage,
+	RaidSpikeInterval,
+	TankSwingDamage,
+	TankSwingSpeed,
+	RaidDamageVariance,
+	TargetDummyHealth,
+];
+
 export const HpPercentForDefensives = {
 	id: 'hp-percent-for-defensives',
 	type: 'number' as const,
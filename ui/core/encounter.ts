@@ -1,7 +1,17 @@
 import * as Mechanics from './constants/mechanics';
 import { CURRENT_API_VERSION } from './constants/other';
 import { UnitMetadataList } from './player';
-import { Encounter as EncounterProto, MobType, PresetEncounter, PresetTarget, SpellSchool, Stat, Target as TargetProto, TargetInput } from './proto/common';
+import {
+	Encounter as EncounterProto,
+	MobType,
+	PresetEncounter,
+	PresetTarget,
+	RaidDamageProfile,
+	SpellSchool,
+	Stat,
+	Target as TargetProto,
+	TargetInput,
+} from './proto/common';
 import { Stats } from './proto_utils/stats';
 import { Sim } from './sim';
 import { EventID, TypedEvent } from './typed_event';
@@ -18,12 +28,14 @@ export class Encounter {
 	private executeProportion45 = 0.45;
 	private executeProportion90 = 0.9;
 	private useHealth = false;
+	private raidDamage = RaidDamageProfile.create();
 	targets: Array<TargetProto>;
 	targetsMetadata: UnitMetadataList;
 
 	readonly targetsChangeEmitter = new TypedEvent<void>();
 	readonly durationChangeEmitter = new TypedEvent<void>();
 	readonly executeProportionChangeEmitter = new TypedEvent<void>();
+	readonly raidDamageChangeEmitter = new TypedEvent<void>();
 
 	// Emits when any of the above emitters emit.
 	readonly changeEmitter = new TypedEvent<void>();
@@ -33,7 +45,7 @@ export class Encounter {
 		this.targets = [Encounter.defaultTargetProto()];
 		this.targetsMetadata = new UnitMetadataList();
 
-		[this.targetsChangeEmitter, this.durationChangeEmitter, this.executeProportionChangeEmitter].forEach(emitter =>
+		[this.targetsChangeEmitter, this.durationChangeEmitter, this.executeProportionChangeEmitter, this.raidDamageChangeEmitter].forEach(emitter =>
 			emitter.on(eventID => this.changeEmitter.emit(eventID)),
 		);
 	}
@@ -118,6 +130,18 @@ export class Encounter {
 		this.executeProportionChangeEmitter.emit(eventID);
 	}
 
+	getRaidDamage(): RaidDamageProfile {
+		// Make a defensive copy
+		return RaidDamageProfile.clone(this.raidDamage);
+	}
+	setRaidDamage(eventID: EventID, newRaidDamage: RaidDamageProfile) {
+		if (RaidDamageProfile.equals(newRaidDamage, this.raidDamage)) return;
+
+		// Make a defensive copy
+		this.raidDamage = RaidDamageProfile.clone(newRaidDamage);
+		this.raidDamageChangeEmitter.emit(eventID);
+	}
+
 	matchesPreset(preset: PresetEncounter): boolean {
 		return preset.targets.length == this.targets.length && this.targets.every((t, i) => TargetProto.equals(t, preset.targets[i].target));
 	}
@@ -143,6 +167,7 @@ export class Encounter {
 			executeProportion90: this.executeProportion90,
 			useHealth: this.useHealth,
 			targets: this.targets,
+			raidDamage: this.raidDamage,
 			apiVersion: CURRENT_API_VERSION,
 		});
 	}
@@ -160,6 +185,7 @@ export class Encounter {
 			this.setExecuteProportion45(eventID, proto.executeProportion45);
 			this.setExecuteProportion90(eventID, proto.executeProportion90);
 			this.setUseHealth(eventID, proto.useHealth);
+			this.setRaidDamage(eventID, proto.raidDamage || RaidDamageProfile.create());
 			this.targets = proto.targets;
 			this.targetsChangeEmitter.emit(eventID);
 		});
@@ -163,6 +163,23 @@ export class ActionId {
 				}
 				iconUrl = 'https://wow.zamimg.com/images/wow/icons/medium/spell_nature_abolishmagic.jpg';
 				break;
+			case OtherAction.OtherActionRaidDamage:
+				baseName = 'Raid Damage';
+				switch (this.tag) {
+					case 1:
+						baseName += ' (Pulse)';
+						iconUrl = 'https://wow.zamimg.com/images/wow/icons/medium/spell_shadow_shadowfury.jpg';
+						break;
+					case 2:
+						baseName += ' (Spike)';
+						iconUrl = 'https://wow.zamimg.com/images/wow/icons/medium/spell_shadow_shadowbolt.jpg';
+						break;
+					default:
+						baseName += ' (Tank Melee)';
+						iconUrl = 'https://wow.zamimg.com/images/wow/icons/large/inv_sword_04.jpg';
+						break;
+				}
+				break;
 		}
 		this.baseName = baseName ?? '';
 		this.name = (name || baseName) ?? '';
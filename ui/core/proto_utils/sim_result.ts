@@ -322,6 +322,7 @@ export class UnitMetrics {
 	readonly classColor: string;
 	readonly dps: DistributionMetricsProto;
 	readonly hps: DistributionMetricsProto;
+	readonly aps: DistributionMetricsProto;
 	readonly tps: DistributionMetricsProto;
 	readonly dtps: DistributionMetricsProto;
 	readonly tmi: DistributionMetricsProto;
@@ -376,6 +377,7 @@ export class UnitMetrics {
 					.replace(/\s/g, '-') ?? '');
 		this.dps = this.metrics.dps!;
 		this.hps = this.metrics.hps!;
+		this.aps = this.metrics.aps!;
 		this.tps = this.metrics.threat!;
 		this.dtps = this.metrics.dtps!;
 		this.tmi = this.metrics.tmi!;
@@ -927,6 +929,10 @@ export class ActionMetrics {
 		return this.combinedMetrics.critHealing / this.iterations;
 	}
 
+	get overhealingPercent() {
+		return this.combinedMetrics.overhealingPercent;
+	}
+
 	get hps() {
 		return this.combinedMetrics.hps;
 	}
@@ -1370,6 +1376,14 @@ export class TargetedActionMetrics {
 		return this.data.critHealing / this.iterations;
 	}
 
+	get overhealing() {
+		return this.data.overhealing;
+	}
+
+	get overhealingPercent() {
+		return this.healing ? (this.data.overhealing / this.healing) * 100 : 0;
+	}
+
 	get shielding() {
 		return this.data.shielding;
 	}
@@ -1587,6 +1601,7 @@ export class TargetedActionMetrics {
 				threat: sum(actions.map(a => a.data.threat)),
 				healing: sum(actions.map(a => a.data.healing)),
 				critHealing: sum(actions.map(a => a.data.critHealing)),
+				overhealing: sum(actions.map(a => a.data.overhealing)),
 				shielding: sum(actions.map(a => a.data.shielding)),
 				castTimeMs: sum(actions.map(a => a.data.castTimeMs)),
 			}),
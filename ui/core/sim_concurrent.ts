@@ -243,6 +243,11 @@ export const runConcurrentStatWeights = async (
 		statReqData.requestHigh!.requestId = id;
 		iterationsTotal += statReqData.requestLow!.simOptions!.iterations + statReqData.requestHigh!.simOptions!.iterations;
 		simsTotal += 2;
+		for (const scalingRequest of statReqData.scalingRequests) {
+			scalingRequest.requestId = id;
+			iterationsTotal += scalingRequest.simOptions!.iterations;
+			simsTotal++;
+		}
 	}
 
 	console.log(`Need to run a total of ${simsTotal} sims and ${iterationsTotal} iterations.`);
@@ -271,6 +276,7 @@ export const runConcurrentStatWeights = async (
 		baseResult: baseLine,
 		epReferenceStat: manualResponse.epReferenceStat,
 		statSimResults: [],
+		scaling: manualResponse.scaling,
 	});
 
 	for (const statReqData of manualResponse.statSimRequests) {
@@ -284,11 +290,20 @@ export const runConcurrentStatWeights = async (
 		const highRes = await runConcurrentSim(statReqData.requestHigh!, workerPool, progressHandler, signals);
 		if (highRes.error) return makeAndSendWeightsError(highRes.error, onProgress);
 
+		const scalingResults = [];
+		for (const scalingRequest of statReqData.scalingRequests) {
+			lastIterations = 0;
+			const scalingRes = await runConcurrentSim(scalingRequest, workerPool, progressHandler, signals);
+			if (scalingRes.error) return makeAndSendWeightsError(scalingRes.error, onProgress);
+			scalingResults.push(scalingRes);
+		}
+
 		calcRequest.statSimResults.push(
 			StatWeightsStatResultData.create({
 				statData: statReqData.statData,
 				resultLow: lowRes,
 				resultHigh: highRes,
+				scalingResults: scalingResults,
 			}),
 		);
 	}
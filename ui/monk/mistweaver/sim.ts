@@ -120,7 +120,7 @@ const SPEC_CONFIG = registerSpecConfig(Spec.SpecMistweaverMonk, {
 	excludeBuffDebuffInputs: [],
 	// Inputs to include in the 'Other' section on the settings tab.
 	otherInputs: {
-		inputs: [OtherInputs.InFrontOfTarget, OtherInputs.InputDelay],
+		inputs: [OtherInputs.InFrontOfTarget, OtherInputs.InputDelay, ...OtherInputs.RaidDamageInputs],
 	},
 	encounterPicker: {
 		// Whether to include 'Execute Duration (%)' in the 'Encounter' section of the settings tab.
@@ -132,13 +132,13 @@ const SPEC_CONFIG = registerSpecConfig(Spec.SpecMistweaverMonk, {
 		// Preset talents that the user can quickly select.
 		talents: [Presets.DefaultTalents],
 		// Preset rotations that the user can quickly select.
-		rotations: [],
+		rotations: [Presets.ROTATION_PRESET],
 		// Preset gear configurations that the user can quickly select.
 		gear: [Presets.PREBIS_GEAR_PRESET],
 	},
 
 	autoRotation: (_: Player<Spec.SpecMistweaverMonk>): APLRotation => {
-		return APLRotation.create();
+		return Presets.ROTATION_PRESET.rotation.rotation!;
 	},
 
 	raidSimPresets: [